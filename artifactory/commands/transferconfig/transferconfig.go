@@ -499,6 +499,7 @@ func (tcc *TransferConfigCommand) updateServerDetails() error {
 	newTargetServerDetails.ArtifactoryTokenRefreshInterval = tcc.SourceServerDetails.ArtifactoryTokenRefreshInterval
 	newTargetServerDetails.ClientCertPath = tcc.SourceServerDetails.ClientCertPath
 	newTargetServerDetails.ClientCertKeyPath = tcc.SourceServerDetails.ClientCertKeyPath
+	newTargetServerDetails.ClientCertKeyPassphrase = tcc.SourceServerDetails.ClientCertKeyPassphrase
 
 	// Ping to validate the transfer ended successfully
 	pingCmd := generic.NewPingCommand().SetServerDetails(newTargetServerDetails)
@@ -98,7 +98,7 @@ func UnmarshalDeployableArtifacts(deployableArtifactsFilePath, projectConfigPath
 // configPath -  path to the project's local configuration file.
 func getDeployerUrlAndRepo(modulesMap *map[string][]clientutils.DeployableArtifactDetails, configPath string) (string, string, error) {
 	repo := getTargetRepoFromMap(modulesMap)
-	vConfig, err := project.ReadConfigFile(configPath, project.YAML)
+	vConfig, err := project.ReadProjectConfigFile(configPath, project.Maven, project.Gradle)
 	if err != nil {
 		return "", "", err
 	}
@@ -29,6 +29,7 @@ import (
 	clientConfig "github.com/jfrog/jfrog-client-go/config"
 	"github.com/jfrog/jfrog-client-go/distribution"
 	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/http/jfroghttpclient"
 	"github.com/jfrog/jfrog-client-go/jfconnect"
 	"github.com/jfrog/jfrog-client-go/lifecycle"
 	"github.com/jfrog/jfrog-client-go/metadata"
@@ -100,7 +101,7 @@ func CreateServiceManager(serverDetails *config.ServerDetails, httpRetries, http
 }
 
 // Create a service manager with threads.
-// If the value sent for httpRetries is negative, the default will be used.
+// If the value sent for httpRetries is negative, the server's HTTP retries will be used, or the default if not configured for the server.
 func CreateServiceManagerWithThreads(serverDetails *config.ServerDetails, isDryRun bool, threads, httpRetries, httpRetryWaitMilliSecs int) (artifactory.ArtifactoryServicesManager, error) {
 	return CreateServiceManagerWithContext(context.Background(), serverDetails, isDryRun, threads, httpRetries, httpRetryWaitMilliSecs, 0)
 }
@@ -120,6 +121,10 @@ func CreateServiceManagerWithContext(context context.Context, serverDetails *con
 		SetInsecureTls(serverDetails.InsecureTls).
 		SetDryRun(isDryRun).
 		SetContext(context)
+	httpClient, err := config.ApplyHttpSettings(configBuilder, serverDetails)
+	if err != nil {
+		return nil, err
+	}
 	if httpRetries >= 0 {
 		configBuilder.SetHttpRetries(httpRetries)
 		configBuilder.SetHttpRetryWaitMilliSecs(httpRetryWaitMilliSecs)
@@ -129,6 +134,10 @@ func CreateServiceManagerWithContext(context context.Context, serverDetails *con
 	}
 	if timeout > 0 {
 		configBuilder.SetOverallRequestTimeout(timeout)
+		// The server's HTTP client replaces the default client, so the timeout is applied to it as well.
+		if httpClient != nil {
+			httpClient.Timeout = timeout
+		}
 	}
 	serviceConfig, err := configBuilder.Build()
 	if err != nil {
@@ -146,12 +155,16 @@ func CreateServiceManagerWithProgressBar(serverDetails *config.ServerDetails, th
 	if err != nil {
 		return nil, err
 	}
-	servicesConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(artAuth).
 		SetDryRun(dryRun).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serverDetails.InsecureTls).
-		SetThreads(threads).
+		SetThreads(threads)
+	if _, err = config.ApplyHttpSettings(configBuilder, serverDetails); err != nil {
+		return nil, err
+	}
+	servicesConfig, err := configBuilder.
 		SetHttpRetries(httpRetries).
 		SetHttpRetryWaitMilliSecs(httpRetryWaitMilliSecs).
 		Build()
@@ -171,12 +184,15 @@ func CreateDistributionServiceManager(serviceDetails *config.ServerDetails, isDr
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(distAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
-		SetDryRun(isDryRun).
-		Build()
+		SetDryRun(isDryRun)
+	if _, err = config.ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -184,6 +200,38 @@ func CreateDistributionServiceManager(serviceDetails *config.ServerDetails, isDr
 }
 
 func CreateAccessServiceManager(serviceDetails *config.ServerDetails, isDryRun bool) (*access.AccessServicesManager, error) {
+	serviceConfig, err := createAccessServiceConfig(serviceDetails, isDryRun)
+	if err != nil {
+		return nil, err
+	}
+	return access.New(serviceConfig)
+}
+
+// Creates an HTTP client for the Access APIs which the Access services manager doesn't implement.
+// The client is created from the config of the Access services manager, so it has the same HTTP settings.
+func CreateAccessHttpClient(serviceDetails *config.ServerDetails) (*jfroghttpclient.JfrogHttpClient, auth.ServiceDetails, error) {
+	serviceConfig, err := createAccessServiceConfig(serviceDetails, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	accessAuth := serviceConfig.GetServiceDetails()
+	client, err := jfroghttpclient.JfrogClientBuilder().
+		SetCertificatesPath(serviceConfig.GetCertificatesPath()).
+		SetInsecureTls(serviceConfig.IsInsecureTls()).
+		SetClientCertPath(accessAuth.GetClientCertPath()).
+		SetClientCertKeyPath(accessAuth.GetClientCertKeyPath()).
+		AppendPreRequestInterceptor(accessAuth.RunPreRequestFunctions).
+		SetHttpClient(serviceConfig.GetHttpClient()).
+		SetRetries(serviceConfig.GetHttpRetries()).
+		SetRetryWaitMilliSecs(serviceConfig.GetHttpRetryWaitMilliSecs()).
+		Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, accessAuth, nil
+}
+
+func createAccessServiceConfig(serviceDetails *config.ServerDetails, isDryRun bool) (clientConfig.Config, error) {
 	certsPath, err := coreutils.GetJfrogCertsDir()
 	if err != nil {
 		return nil, err
@@ -192,16 +240,15 @@ func CreateAccessServiceManager(serviceDetails *config.ServerDetails, isDryRun b
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(accessAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
-		SetDryRun(isDryRun).
-		Build()
-	if err != nil {
+		SetDryRun(isDryRun)
+	if _, err = config.ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
 		return nil, err
 	}
-	return access.New(serviceConfig)
+	return configBuilder.Build()
 }
 
 func CreateLifecycleServiceManager(serviceDetails *config.ServerDetails, isDryRun bool) (*lifecycle.LifecycleServicesManager, error) {
@@ -213,12 +260,15 @@ func CreateLifecycleServiceManager(serviceDetails *config.ServerDetails, isDryRu
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(lcAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
-		SetDryRun(isDryRun).
-		Build()
+		SetDryRun(isDryRun)
+	if _, err = config.ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -234,12 +284,15 @@ func CreateJPDServiceManager(serviceDetails *config.ServerDetails, isDryRun bool
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(evdAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
-		SetDryRun(isDryRun).
-		Build()
+		SetDryRun(isDryRun)
+	if _, err = config.ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -255,12 +308,15 @@ func CreateEvidenceServiceManager(serviceDetails *config.ServerDetails, isDryRun
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(evdAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
-		SetDryRun(isDryRun).
-		Build()
+		SetDryRun(isDryRun)
+	if _, err = config.ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -276,12 +332,15 @@ func CreateApptrustServiceManager(serviceDetails *config.ServerDetails, isDryRun
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(apptrustAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
-		SetDryRun(isDryRun).
-		Build()
+		SetDryRun(isDryRun)
+	if _, err = config.ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -297,12 +356,15 @@ func CreateMetadataServiceManager(serviceDetails *config.ServerDetails, isDryRun
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(mdAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
-		SetDryRun(isDryRun).
-		Build()
+		SetDryRun(isDryRun)
+	if _, err = config.ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -318,12 +380,15 @@ func CreateOnemodelServiceManager(serviceDetails *config.ServerDetails, isDryRun
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(mdAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
-		SetDryRun(isDryRun).
-		Build()
+		SetDryRun(isDryRun)
+	if _, err = config.ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -339,10 +404,14 @@ func CreateJfConnectServiceManager(serverDetails *config.ServerDetails, httpRetr
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(jfConnectAuth).
 		SetCertificatesPath(certsPath).
-		SetInsecureTls(serverDetails.InsecureTls).
+		SetInsecureTls(serverDetails.InsecureTls)
+	if _, err = config.ApplyHttpSettings(configBuilder, serverDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.
 		SetHttpRetries(httpRetries).
 		SetHttpRetryWaitMilliSecs(httpRetryWaitMilliSecs).
 		Build()
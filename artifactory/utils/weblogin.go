@@ -1,7 +1,14 @@
 package utils
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,9 +19,45 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/pkg/browser"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/exp/slices"
 )
 
+const (
+	DefaultWebLoginTimeout = 5 * time.Minute
+	// The polling interval starts at the initial interval, and grows up to the maximal interval.
+	webLoginInitialPollInterval = time.Second
+	webLoginMaxPollInterval     = 10 * time.Second
+)
+
+// Until the login is completed, getting its token fails with a bad request status. The other retried statuses are transient.
+var webLoginRetriedStatusCodes = []int{http.StatusBadRequest, http.StatusRequestTimeout, http.StatusTooManyRequests}
+
+// The client reports the status of an unexpected response in the error message.
+var serverResponseStatusPattern = regexp.MustCompile(`server response: (\d{3})`)
+
+// WebLoginOptions are the options of the web login.
+type WebLoginOptions struct {
+	// If true, the browser isn't opened, and the login URL and code are printed for opening on another device.
+	// The web login is headless regardless of this option, if there's no browser on the current machine, such as in SSH sessions.
+	Headless bool
+	// If true, the login URL is also rendered as a QR code in the terminal.
+	ShowQrCode bool
+	// The maximal time to wait for the login to complete. If zero, DefaultWebLoginTimeout is used.
+	Timeout time.Duration
+}
+
+// The access API used to poll for the token of the web login.
+type loginTokenGetter interface {
+	GetLoginAuthenticationToken(clientUuid string) (auth.CommonTokenParams, error)
+}
+
 func DoWebLogin(serverDetails *config.ServerDetails) (token auth.CommonTokenParams, err error) {
+	return DoWebLoginWithOptions(serverDetails, WebLoginOptions{})
+}
+
+// Performs a web login, waiting until the login is completed in the browser, the timeout expires, or the login is canceled with Ctrl-C.
+func DoWebLoginWithOptions(serverDetails *config.ServerDetails, options WebLoginOptions) (token auth.CommonTokenParams, err error) {
 	if err = sendUnauthenticatedPing(serverDetails); err != nil {
 		return
 	}
@@ -34,27 +77,38 @@ func DoWebLogin(serverDetails *config.ServerDetails) (token auth.CommonTokenPara
 				"Don't worry! You can use the \"jf c add\" command to authenticate with the JFrog Platform using other methods"))
 		return
 	}
-	log.Info("After logging in via your web browser, please enter the code if prompted: " + coreutils.PrintBoldTitle(uuidStr[len(uuidStr)-4:]))
-
+	loginCode := uuidStr[len(uuidStr)-4:]
 	loginUrl := clientUtils.AddTrailingSlashIfNeeded(serverDetails.Url) + "ui/login?jfClientSession=" + uuidStr + "&jfClientName=JFrog-CLI&jfClientCode=1"
-	log.Info("Please open the following URL in your browser to authenticate:")
-	log.Info(loginUrl)
-
-	// Attempt to open in browser if available
-	if err = browser.OpenURL(loginUrl); err != nil {
-		log.Warn("Failed to automatically open the browser. Please open the URL manually.")
-		// Do not return, continue the flow
+	if options.Headless || isHeadlessEnvironment() {
+		log.Info("Please open the following URL in a browser on any device to authenticate:")
+		log.Output(loginUrl)
+		log.Info("Enter the following code when prompted: " + coreutils.PrintBoldTitle(loginCode))
+	} else {
+		log.Info("After logging in via your web browser, please enter the code if prompted: " + coreutils.PrintBoldTitle(loginCode))
+		log.Info("Please open the following URL in your browser to authenticate:")
+		log.Info(loginUrl)
+		// Attempt to open in browser if available
+		if err = browser.OpenURL(loginUrl); err != nil {
+			log.Warn("Failed to automatically open the browser. Please open the URL manually.")
+			// Do not return, continue the flow
+		}
+	}
+	if options.ShowQrCode {
+		printQrCode(loginUrl)
 	}
 
-	time.Sleep(1 * time.Second)
-	log.Debug("Attempting to get the authentication token...")
-	token, err = accessManager.GetLoginAuthenticationToken(uuidStr)
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWebLoginTimeout
+	}
+	// Cancel the login on Ctrl-C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	log.Info("Waiting for the login to complete. Press Ctrl-C to cancel.")
+	token, err = pollLoginAuthenticationToken(ctx, accessManager, uuidStr, timeout, webLoginInitialPollInterval)
 	if err != nil {
 		return
 	}
-	if token.AccessToken == "" {
-		return token, errorutils.CheckErrorf("failed getting authentication token after web login")
-	}
 	log.Info("You're now logged in!")
 	return
 }
@@ -67,3 +121,64 @@ func sendUnauthenticatedPing(serverDetails *config.ServerDetails) error {
 	_, err = artifactoryManager.Ping()
 	return err
 }
+
+// Polls for the token of the web login with a growing interval, until the token is received, the timeout expires or the context is canceled.
+// Failures are retried only while the login isn't completed or if they are transient. Other failures, such as an unauthorized client, fail the login.
+func pollLoginAuthenticationToken(ctx context.Context, tokenGetter loginTokenGetter, clientUuid string, timeout, pollInterval time.Duration) (token auth.CommonTokenParams, err error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		log.Debug("Attempting to get the authentication token...")
+		token, err = tokenGetter.GetLoginAuthenticationToken(clientUuid)
+		if err == nil && token.AccessToken != "" {
+			return token, nil
+		}
+		if err != nil {
+			if !isRetriedLoginTokenError(err) {
+				return token, err
+			}
+			log.Debug("The authentication token isn't available yet: " + err.Error())
+		}
+		if time.Now().Add(pollInterval).After(deadline) {
+			timeoutErr := errorutils.CheckErrorf("the web login wasn't completed within %s", timeout)
+			if err != nil {
+				return token, errors.Join(timeoutErr, err)
+			}
+			return token, timeoutErr
+		}
+		select {
+		case <-ctx.Done():
+			return token, errorutils.CheckErrorf("the web login was canceled")
+		case <-time.After(pollInterval):
+		}
+		pollInterval = min(pollInterval*3/2, webLoginMaxPollInterval)
+	}
+}
+
+// Returns true if the failure to get the token of the web login should be retried.
+// Failures without a response status, such as network errors, are transient.
+func isRetriedLoginTokenError(err error) bool {
+	match := serverResponseStatusPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return true
+	}
+	// The pattern matches digits only, so the conversion can't fail.
+	statusCode, _ := strconv.Atoi(match[1])
+	return statusCode >= http.StatusInternalServerError || slices.Contains(webLoginRetriedStatusCodes, statusCode)
+}
+
+// Returns true if there's probably no browser on the current machine, such as in SSH sessions and containers without a display.
+func isHeadlessEnvironment() bool {
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		return true
+	}
+	return runtime.GOOS == "linux" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+}
+
+func printQrCode(loginUrl string) {
+	qrCode, err := qrcode.New(loginUrl, qrcode.Low)
+	if err != nil {
+		log.Warn("Failed rendering the login URL as a QR code: " + err.Error())
+		return
+	}
+	log.Output(qrCode.ToSmallString(false))
+}
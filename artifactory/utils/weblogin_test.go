@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns the token only after the configured number of failed attempts.
+type testLoginTokenGetter struct {
+	failures int
+	attempts int
+	// The error of the failed attempts. If nil, a network error is returned.
+	err error
+}
+
+func (tg *testLoginTokenGetter) GetLoginAuthenticationToken(clientUuid string) (auth.CommonTokenParams, error) {
+	tg.attempts++
+	if tg.attempts <= tg.failures {
+		if tg.err != nil {
+			return auth.CommonTokenParams{}, tg.err
+		}
+		return auth.CommonTokenParams{}, errors.New("token not found")
+	}
+	return auth.CommonTokenParams{AccessToken: "token-of-" + clientUuid}, nil
+}
+
+func TestPollLoginAuthenticationToken(t *testing.T) {
+	tokenGetter := &testLoginTokenGetter{failures: 2}
+	token, err := pollLoginAuthenticationToken(context.Background(), tokenGetter, "uuid", time.Minute, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, "token-of-uuid", token.AccessToken)
+	assert.Equal(t, 3, tokenGetter.attempts)
+}
+
+func TestPollLoginAuthenticationTokenFailures(t *testing.T) {
+	testCases := []struct {
+		name             string
+		err              error
+		expectedAttempts int
+	}{
+		{"pending", errors.New("server response: 400 Bad Request\n{\"errors\":[{\"message\":\"token not found\"}]}"), 3},
+		{"unavailable", errors.New("server response: 503 Service Unavailable"), 3},
+		{"unauthorized", errors.New("server response: 401 Unauthorized"), 1},
+		{"notFound", errors.New("server response: 404 Not Found"), 1},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokenGetter := &testLoginTokenGetter{failures: 2, err: testCase.err}
+			_, err := pollLoginAuthenticationToken(context.Background(), tokenGetter, "uuid", time.Minute, time.Millisecond)
+			if testCase.expectedAttempts == 1 {
+				assert.ErrorIs(t, err, testCase.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedAttempts, tokenGetter.attempts)
+		})
+	}
+}
+
+func TestPollLoginAuthenticationTokenTimeout(t *testing.T) {
+	tokenGetter := &testLoginTokenGetter{failures: 1000}
+	_, err := pollLoginAuthenticationToken(context.Background(), tokenGetter, "uuid", 50*time.Millisecond, time.Millisecond)
+	assert.ErrorContains(t, err, "wasn't completed within 50ms")
+	assert.ErrorContains(t, err, "token not found")
+	assert.Greater(t, tokenGetter.attempts, 1)
+}
+
+func TestPollLoginAuthenticationTokenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tokenGetter := &testLoginTokenGetter{failures: 1000}
+	_, err := pollLoginAuthenticationToken(ctx, tokenGetter, "uuid", time.Minute, time.Millisecond)
+	assert.ErrorContains(t, err, "canceled")
+	assert.Equal(t, 1, tokenGetter.attempts)
+}
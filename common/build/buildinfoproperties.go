@@ -148,11 +148,14 @@ var gradleConfigMapping = map[string]string{
 	"publish.ivy.artPattern":                            DeployerPrefix + ArtifactPattern,
 }
 
+// Reads the maven config file, merged with the maven config files of the .jfrog directories of its parent dirs.
+// The path may be of the unified project config file, whose maven section is read.
+// If the path is empty, the config is created from the properties.
 func ReadMavenConfig(path string, mvnProps map[string]any) (config *viper.Viper, err error) {
 	if path == "" {
 		config = createDefaultConfigWithParams(project.YAML, project.Maven.String(), mvnProps)
 	} else {
-		config, err = project.ReadConfigFile(path, project.YAML)
+		config, err = project.ReadProjectConfigFile(path, project.Maven)
 	}
 	return
 }
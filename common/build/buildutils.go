@@ -57,16 +57,17 @@ func PrepareBuildPrerequisites(buildConfiguration *BuildConfiguration) (build *b
 		build, err = buildInfoService.GetOrCreateBuildWithProject(buildName, buildNumber, projectKey)
 		if err != nil {
 			err = errorutils.CheckError(err)
+			return
 		}
+		// Add the build's name, number and project to the general details saved by build-info-go, to allow identifying the build locally.
+		err = SaveBuildGeneralDetails(buildName, buildNumber, projectKey)
 	}
 
 	return
 }
 
 func GetBuildDir(buildName, buildNumber, projectKey string) (string, error) {
-	hash := sha256.Sum256([]byte(buildName + "_" + buildNumber + "_" + projectKey))
-	buildsDir := filepath.Join(coreutils.GetCliPersistentTempDirPath(),
-		build.NewBuildInfoService().GetUserSpecificBuildDirName(), hex.EncodeToString(hash[:]))
+	buildsDir := getBuildDirPath(buildName, buildNumber, projectKey)
 	err := os.MkdirAll(buildsDir, 0777)
 	if errorutils.CheckError(err) != nil {
 		return "", err
@@ -74,6 +75,17 @@ func GetBuildDir(buildName, buildNumber, projectKey string) (string, error) {
 	return buildsDir, nil
 }
 
+// Returns the dir containing the build-info collected for all the builds, until they are published.
+func getBuildsDir() string {
+	return filepath.Join(coreutils.GetCliPersistentTempDirPath(), build.NewBuildInfoService().GetUserSpecificBuildDirName())
+}
+
+// Returns the dir of the build-info collected for the build, without creating it.
+func getBuildDirPath(buildName, buildNumber, projectKey string) string {
+	hash := sha256.Sum256([]byte(buildName + "_" + buildNumber + "_" + projectKey))
+	return filepath.Join(getBuildsDir(), hex.EncodeToString(hash[:]))
+}
+
 func CreateBuildProperties(buildName, buildNumber, projectKey string) (string, error) {
 	if buildName == "" || buildNumber == "" {
 		return "", nil
@@ -110,6 +122,13 @@ func saveBuildData(action interface{}, buildName, buildNumber, projectKey string
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
+	unlock, err := lockBuildDirForWriting(buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
 	dirPath, err := getPartialsBuildDir(buildName, buildNumber, projectKey)
 	if err != nil {
 		return err
@@ -136,6 +155,13 @@ func SaveBuildInfo(buildName, buildNumber, projectKey string, buildInfo *buildIn
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
+	unlock, err := lockBuildDirForWriting(buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
 	dirPath, err := GetBuildDir(buildName, buildNumber, projectKey)
 	if err != nil {
 		return err
@@ -152,7 +178,14 @@ func SaveBuildInfo(buildName, buildNumber, projectKey string, buildInfo *buildIn
 	return errorutils.CheckError(err)
 }
 
-func SaveBuildGeneralDetails(buildName, buildNumber, projectKey string) error {
+func SaveBuildGeneralDetails(buildName, buildNumber, projectKey string) (err error) {
+	unlock, err := lockBuildDirForWriting(buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
 	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber, projectKey)
 	if err != nil {
 		return err
@@ -164,13 +197,15 @@ func SaveBuildGeneralDetails(buildName, buildNumber, projectKey string) error {
 	if err != nil {
 		return err
 	}
+	details := &buildGeneralDetails{General: buildInfo.General{Timestamp: time.Now()}}
 	if exists {
-		return nil
-	}
-	meta := buildInfo.General{
-		Timestamp: time.Now(),
+		// The details saved by build-info-go or by older versions don't include the build's name, number and project.
+		if details, err = readBuildGeneralDetailsFile(detailsFilePath); err != nil || details.BuildName != "" {
+			return err
+		}
 	}
-	b, err := json.Marshal(&meta)
+	details.BuildName, details.BuildNumber, details.ProjectKey = buildName, buildNumber, projectKey
+	b, err := json.Marshal(details)
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
@@ -272,14 +307,8 @@ func ReadBuildInfoGeneralDetails(buildName, buildNumber, projectKey string) (*bu
 		return nil, err
 	}
 	if !fileExists {
-		var buildString string
-		if projectKey != "" {
-			buildString = fmt.Sprintf("build-name: <%s>, build-number: <%s> and project: <%s>", buildName, buildNumber, projectKey)
-		} else {
-			buildString = fmt.Sprintf("build-name: <%s> and build-number: <%s>", buildName, buildNumber)
-		}
 		return nil, errors.New("Failed to construct the build-info to be published. " +
-			"This may be because there were no previous commands, which collected build-info for " + buildString)
+			"This may be because there were no previous commands, which collected build-info for " + getBuildString(buildName, buildNumber, projectKey))
 	}
 	content, err := fileutils.ReadFile(generalDetailsFilePath)
 	if err != nil {
@@ -293,6 +322,13 @@ func ReadBuildInfoGeneralDetails(buildName, buildNumber, projectKey string) (*bu
 	return details, nil
 }
 
+func getBuildString(buildName, buildNumber, projectKey string) string {
+	if projectKey != "" {
+		return fmt.Sprintf("build-name: <%s>, build-number: <%s> and project: <%s>", buildName, buildNumber, projectKey)
+	}
+	return fmt.Sprintf("build-name: <%s> and build-number: <%s>", buildName, buildNumber)
+}
+
 func RemoveBuildDir(buildName, buildNumber, projectKey string) error {
 	tempDirPath, err := GetBuildDir(buildName, buildNumber, projectKey)
 	if err != nil {
@@ -357,15 +393,11 @@ func (bc *BuildConfiguration) GetBuildName() (string, error) {
 }
 
 func (bc *BuildConfiguration) getBuildNameFromConfigFile() (string, error) {
-	confFilePath, exist, err := project.GetProjectConfFilePath(project.Build)
+	vConfig, _, err := project.GetProjectConfig(project.Build)
 	if os.IsPermission(err) {
 		log.Debug("The 'build-name' cannot be read from JFrog config due to permission denied.")
 		return "", nil
 	}
-	if err != nil || !exist {
-		return "", err
-	}
-	vConfig, err := project.ReadConfigFile(confFilePath, project.YAML)
 	if err != nil || vConfig == nil {
 		return "", err
 	}
@@ -396,7 +428,18 @@ func (bc *BuildConfiguration) GetProject() string {
 		return bc.project
 	}
 	// Resolve from env var.
-	bc.project = os.Getenv(coreutils.Project)
+	if bc.project = os.Getenv(coreutils.Project); bc.project != "" {
+		return bc.project
+	}
+	// Resolve from the unified project config file in the '.jfrog' folder.
+	unifiedConfig, err := project.GetUnifiedProjectConfig()
+	if err != nil {
+		log.Debug("The project key cannot be read from the project config file: " + err.Error())
+		return ""
+	}
+	if unifiedConfig != nil {
+		bc.project = unifiedConfig.Project
+	}
 	return bc.project
 }
 
@@ -0,0 +1,263 @@
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/build-info-go/build"
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/lock"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The dir in the JFrog locks dir, containing the file locks of the local builds.
+	buildsLockDirName = "builds"
+	// The time to wait for the lock of a build when removing it. The lock is held shortly by the processes writing the build's files.
+	localBuildRemovalLockTimeout = 5 * time.Second
+)
+
+// The content of the general details file of a build.
+// In addition to the general details read by build-info-go, the build's name, number and project are saved, to allow identifying the builds collected locally.
+type buildGeneralDetails struct {
+	buildInfo.General
+	BuildName   string `json:"BuildName,omitempty"`
+	BuildNumber string `json:"BuildNumber,omitempty"`
+	ProjectKey  string `json:"ProjectKey,omitempty"`
+}
+
+func readBuildGeneralDetailsFile(detailsFilePath string) (*buildGeneralDetails, error) {
+	content, err := fileutils.ReadFile(detailsFilePath)
+	if err != nil {
+		return nil, err
+	}
+	details := new(buildGeneralDetails)
+	err = json.Unmarshal(content, details)
+	return details, errorutils.CheckError(err)
+}
+
+// LocalBuild is a build whose build-info is collected locally, until it is published.
+type LocalBuild struct {
+	// The name of the build's dir, which identifies the build if its name and number are unknown.
+	Id string `json:"id"`
+	// The name, number and project are unknown for builds collected by older versions.
+	BuildName   string `json:"buildName,omitempty"`
+	BuildNumber string `json:"buildNumber,omitempty"`
+	ProjectKey  string `json:"projectKey,omitempty"`
+	// The time the build-info collection started. If unknown, the last modification time of the build's dir.
+	Started       time.Time `json:"started"`
+	PartialsCount int       `json:"partialsCount"`
+	// The total size of the build's files, in bytes.
+	Size int64  `json:"size"`
+	Path string `json:"path"`
+}
+
+// Returns the age of the build, according to the time its build-info collection started.
+func (lb *LocalBuild) Age() time.Duration {
+	return time.Since(lb.Started)
+}
+
+// Removes the build while holding its lock exclusively. Fails if the build's files are being written.
+func (lb *LocalBuild) Remove() (err error) {
+	unlock, err := lockBuildDir(lb.Id, lock.Exclusive, localBuildRemovalLockTimeout)
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+	if err != nil {
+		return errorutils.CheckErrorf("the local build-info at %s is being written: %s", lb.Path, err.Error())
+	}
+	return lb.removeFiles()
+}
+
+func (lb *LocalBuild) removeFiles() error {
+	log.Debug("Removing the local build-info at: " + lb.Path)
+	return errorutils.CheckError(fileutils.RemoveTempDir(lb.Path))
+}
+
+// Returns the builds collected locally, sorted by the time their build-info collection started, oldest first.
+func GetLocalBuilds() ([]*LocalBuild, error) {
+	buildsDir := getBuildsDir()
+	exists, err := fileutils.IsDirExists(buildsDir, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	entries, err := os.ReadDir(buildsDir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var localBuilds []*LocalBuild
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		localBuild, err := readLocalBuild(filepath.Join(buildsDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		localBuilds = append(localBuilds, localBuild)
+	}
+	sort.SliceStable(localBuilds, func(i, j int) bool {
+		return localBuilds[i].Started.Before(localBuilds[j].Started)
+	})
+	return localBuilds, nil
+}
+
+// Returns the local build with the name, number and project, or nil if no build-info was collected for it.
+func GetLocalBuild(buildName, buildNumber, projectKey string) (*LocalBuild, error) {
+	buildDir := getBuildDirPath(buildName, buildNumber, projectKey)
+	exists, err := fileutils.IsDirExists(buildDir, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	localBuild, err := readLocalBuild(buildDir)
+	if err != nil {
+		return nil, err
+	}
+	// The details of builds collected by older versions don't include the build's name, number and project.
+	localBuild.BuildName, localBuild.BuildNumber, localBuild.ProjectKey = buildName, buildNumber, projectKey
+	return localBuild, nil
+}
+
+func readLocalBuild(buildDir string) (*LocalBuild, error) {
+	localBuild := &LocalBuild{Id: filepath.Base(buildDir), Path: buildDir}
+	err := filepath.WalkDir(buildDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		localBuild.Size += info.Size()
+		if filepath.Base(filepath.Dir(path)) != "partials" {
+			return nil
+		}
+		if !strings.HasSuffix(path, BuildInfoDetails) {
+			localBuild.PartialsCount++
+			return nil
+		}
+		details, err := readBuildGeneralDetailsFile(path)
+		if err != nil {
+			// The build is still listed, to allow removing it.
+			log.Debug("Failed reading the build general details at " + path + ": " + err.Error())
+			return nil
+		}
+		localBuild.BuildName, localBuild.BuildNumber, localBuild.ProjectKey = details.BuildName, details.BuildNumber, details.ProjectKey
+		localBuild.Started = details.Timestamp
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if localBuild.Started.IsZero() {
+		info, err := os.Stat(buildDir)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		localBuild.Started = info.ModTime()
+	}
+	return localBuild, nil
+}
+
+// Returns the build-info that would be published for the build, merged by build-info-go from the partials and general details collected for it,
+// but without the agent details which are added on publish.
+// The build's files are only read, so the build-info isn't saved and the build's general details aren't created if missing.
+func GetLocalBuildInfo(buildName, buildNumber, projectKey string) (*buildInfo.BuildInfo, error) {
+	localBuild, err := GetLocalBuild(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	// Reading the build's files creates its partials dir if missing, so the general details' existence is validated first.
+	var detailsExist bool
+	if localBuild != nil {
+		if detailsExist, err = fileutils.IsFileExists(filepath.Join(localBuild.Path, "partials", BuildInfoDetails), false); err != nil {
+			return nil, err
+		}
+	}
+	if !detailsExist {
+		return nil, errorutils.CheckErrorf("no build-info was collected locally for %s", getBuildString(buildName, buildNumber, projectKey))
+	}
+	generalDetails, err := ReadBuildInfoGeneralDetails(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	// The build is created directly, rather than by the build-info service, which creates the general details of the build if missing.
+	bld := build.NewBuild(buildName, buildNumber, generalDetails.Timestamp, projectKey, getBuildsDir(), log.Logger)
+	localBuildInfo, err := bld.ToBuildInfo()
+	return localBuildInfo, errorutils.CheckError(err)
+}
+
+// Removes the local builds whose build-info collection started longer than the duration ago, and returns the removed builds.
+// Each build is removed while holding its lock exclusively, so builds whose files are being written are skipped.
+func RemoveLocalBuildsOlderThan(age time.Duration) ([]*LocalBuild, error) {
+	localBuilds, err := GetLocalBuilds()
+	if err != nil {
+		return nil, err
+	}
+	var removedBuilds []*LocalBuild
+	for _, localBuild := range localBuilds {
+		if localBuild.Age() < age {
+			// The builds are sorted oldest first.
+			break
+		}
+		var removed bool
+		if removed, err = removeUnlockedLocalBuild(localBuild); err != nil {
+			return removedBuilds, err
+		}
+		if removed {
+			removedBuilds = append(removedBuilds, localBuild)
+		}
+	}
+	return removedBuilds, nil
+}
+
+// Removes the build if its lock is acquired in time. Returns false if the build is locked by a process writing its files.
+func removeUnlockedLocalBuild(localBuild *LocalBuild) (removed bool, err error) {
+	unlock, err := lockBuildDir(localBuild.Id, lock.Exclusive, localBuildRemovalLockTimeout)
+	if err != nil {
+		log.Warn(fmt.Sprintf("Skipping the local build-info at %s, which is being written: %s", localBuild.Path, err.Error()))
+		return false, nil
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+	return true, localBuild.removeFiles()
+}
+
+// Returns the dir of the file lock of a local build, named like the build's dir.
+// The lock is held shared while the build's files are written, and exclusively while the build is removed.
+// The lock file is never removed, since processes waiting for it would otherwise lock a file which isn't locked by the next processes.
+func getBuildLockDirPath(buildDirName string) (string, error) {
+	locksDirPath, err := coreutils.GetJfrogLocksDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(locksDirPath, buildsLockDirName, buildDirName), nil
+}
+
+func lockBuildDir(buildDirName string, mode lock.LockMode, timeout time.Duration) (unlock func() error, err error) {
+	lockDirPath, err := getBuildLockDirPath(buildDirName)
+	if err != nil {
+		return nil, err
+	}
+	return lock.CreateFileLock(context.Background(), lockDirPath, mode, timeout)
+}
+
+// Holds the lock of the build shared, to prevent removing the build while its files are written.
+func lockBuildDirForWriting(buildName, buildNumber, projectKey string) (unlock func() error, err error) {
+	return lockBuildDir(filepath.Base(getBuildDirPath(buildName, buildNumber, projectKey)), lock.Shared, lock.DefaultFileLockTimeout)
+}
@@ -0,0 +1,185 @@
+package build
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBuilds(t *testing.T) {
+	buildName, buildNumber, projectKey := "local-build-"+timestamp, "1", "proj"
+	require.NoError(t, SaveBuildGeneralDetails(buildName, buildNumber, projectKey))
+	defer func() {
+		assert.NoError(t, RemoveBuildDir(buildName, buildNumber, projectKey))
+	}()
+	require.NoError(t, SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildInfo.Partial) {
+		partial.ModuleId = "module"
+		partial.ModuleType = buildInfo.Generic
+		partial.Artifacts = []buildInfo.Artifact{{Name: "a.zip", Path: "repo/a.zip", Checksum: buildInfo.Checksum{Sha1: "sha1"}}}
+	}))
+
+	localBuilds, err := GetLocalBuilds()
+	require.NoError(t, err)
+	localBuild := findLocalBuild(localBuilds, buildName)
+	require.NotNil(t, localBuild)
+	assert.Equal(t, buildNumber, localBuild.BuildNumber)
+	assert.Equal(t, projectKey, localBuild.ProjectKey)
+	assert.Equal(t, 1, localBuild.PartialsCount)
+	assert.Positive(t, localBuild.Size)
+	assert.Less(t, localBuild.Age(), time.Hour)
+	assert.Equal(t, getBuildDirPath(buildName, buildNumber, projectKey), localBuild.Path)
+
+	// Show the build-info which would be published.
+	localBuildInfo, err := GetLocalBuildInfo(buildName, buildNumber, projectKey)
+	require.NoError(t, err)
+	assert.Equal(t, buildName, localBuildInfo.Name)
+	require.Len(t, localBuildInfo.Modules, 1)
+	assert.Equal(t, "module", localBuildInfo.Modules[0].Id)
+	assert.Len(t, localBuildInfo.Modules[0].Artifacts, 1)
+
+	require.NoError(t, localBuild.Remove())
+	assert.NoDirExists(t, localBuild.Path)
+}
+
+func TestGetLocalBuildInfoMergesPartials(t *testing.T) {
+	buildName, buildNumber := "merged-local-build-"+timestamp, "1"
+	require.NoError(t, SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	defer func() {
+		assert.NoError(t, RemoveBuildDir(buildName, buildNumber, ""))
+	}()
+	artifact := buildInfo.Artifact{Name: "a.zip", Path: "repo/a.zip", Checksum: buildInfo.Checksum{Sha1: "sha1"}}
+	dependency := buildInfo.Dependency{Id: "dep:1.0", Checksum: buildInfo.Checksum{Sha1: "sha1"}}
+	for _, populate := range []populatePartialBuildInfo{
+		func(partial *buildInfo.Partial) {
+			partial.ModuleType = buildInfo.Generic
+			partial.Artifacts = []buildInfo.Artifact{artifact}
+		},
+		// The same artifact, collected again.
+		func(partial *buildInfo.Partial) {
+			partial.ModuleType = buildInfo.Generic
+			partial.Artifacts = []buildInfo.Artifact{artifact}
+		},
+		func(partial *buildInfo.Partial) {
+			partial.ModuleType = buildInfo.Generic
+			partial.Dependencies = []buildInfo.Dependency{dependency, {Checksum: buildInfo.Checksum{Sha1: "no-id"}}}
+		},
+		func(partial *buildInfo.Partial) {
+			partial.Env = buildInfo.Env{"buildInfo.env.KEY": "value"}
+		},
+	} {
+		require.NoError(t, SavePartialBuildInfo(buildName, buildNumber, "", populate))
+	}
+	partialsBuildDir := filepath.Join(getBuildDirPath(buildName, buildNumber, ""), "partials")
+	partialsBefore, err := os.ReadDir(partialsBuildDir)
+	require.NoError(t, err)
+
+	localBuildInfo, err := GetLocalBuildInfo(buildName, buildNumber, "")
+	require.NoError(t, err)
+	assert.Equal(t, buildNumber, localBuildInfo.Number)
+	assert.NotEmpty(t, localBuildInfo.Started)
+	assert.Equal(t, buildInfo.Env{"buildInfo.env.KEY": "value"}, localBuildInfo.Properties)
+	require.Len(t, localBuildInfo.Modules, 1)
+	// The module without ID is named after the build.
+	assert.Equal(t, buildName, localBuildInfo.Modules[0].Id)
+	assert.Equal(t, []buildInfo.Artifact{artifact}, localBuildInfo.Modules[0].Artifacts)
+	assert.Equal(t, []buildInfo.Dependency{dependency}, localBuildInfo.Modules[0].Dependencies)
+
+	// The build's files are only read.
+	partialsAfter, err := os.ReadDir(partialsBuildDir)
+	require.NoError(t, err)
+	assert.Len(t, partialsAfter, len(partialsBefore))
+	buildDirEntries, err := os.ReadDir(getBuildDirPath(buildName, buildNumber, ""))
+	require.NoError(t, err)
+	assert.Len(t, buildDirEntries, 1)
+}
+
+func TestRemoveLocalBuildsOlderThanSkipsLockedBuilds(t *testing.T) {
+	buildName, buildNumber := "locked-local-build-"+timestamp, "1"
+	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber, "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, RemoveBuildDir(buildName, buildNumber, ""))
+	}()
+	// The build is started long ago, to be garbage-collected.
+	content, err := json.Marshal(buildGeneralDetails{General: buildInfo.General{Timestamp: time.Now().AddDate(-100, 0, 0)}, BuildName: buildName, BuildNumber: buildNumber})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(partialsBuildDir, BuildInfoDetails), content, 0600))
+	maxAge := 99 * 365 * 24 * time.Hour
+
+	// The build is locked by a process writing its files.
+	unlock, err := lockBuildDirForWriting(buildName, buildNumber, "")
+	require.NoError(t, err)
+	removedBuilds, err := RemoveLocalBuildsOlderThan(maxAge)
+	require.NoError(t, err)
+	assert.Nil(t, findLocalBuild(removedBuilds, buildName))
+	// Deleting the build fails, rather than skipping it.
+	localBuild, err := GetLocalBuild(buildName, buildNumber, "")
+	require.NoError(t, err)
+	require.NotNil(t, localBuild)
+	assert.ErrorContains(t, localBuild.Remove(), "is being written")
+	assert.NoError(t, unlock())
+	assert.DirExists(t, getBuildDirPath(buildName, buildNumber, ""))
+
+	removedBuilds, err = RemoveLocalBuildsOlderThan(maxAge)
+	require.NoError(t, err)
+	assert.NotNil(t, findLocalBuild(removedBuilds, buildName))
+	assert.NoDirExists(t, getBuildDirPath(buildName, buildNumber, ""))
+	// The lock file is kept, since other processes may be waiting for it.
+	lockDirPath, err := getBuildLockDirPath(filepath.Base(getBuildDirPath(buildName, buildNumber, "")))
+	require.NoError(t, err)
+	assert.DirExists(t, lockDirPath)
+}
+
+func TestGetLocalBuildInfoNotCollected(t *testing.T) {
+	buildName := "missing-local-build-" + timestamp
+	_, err := GetLocalBuildInfo(buildName, "1", "")
+	assert.ErrorContains(t, err, "no build-info was collected locally")
+	// Reading a missing build doesn't create its dir.
+	assert.NoDirExists(t, getBuildDirPath(buildName, "1", ""))
+}
+
+func TestSaveBuildGeneralDetailsAddsBuildIdentity(t *testing.T) {
+	buildName, buildNumber := "legacy-local-build-"+timestamp, "1"
+	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber, "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, RemoveBuildDir(buildName, buildNumber, ""))
+	}()
+	// The general details, as saved by build-info-go or by older versions. The build is started long ago, to be garbage-collected.
+	started := time.Now().AddDate(-100, 0, 0).Truncate(time.Second)
+	content, err := json.Marshal(buildInfo.General{Timestamp: started})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(partialsBuildDir, BuildInfoDetails), content, 0600))
+
+	localBuild, err := readLocalBuild(getBuildDirPath(buildName, buildNumber, ""))
+	require.NoError(t, err)
+	assert.Empty(t, localBuild.BuildName)
+
+	// The build's identity is added, and the start time is kept.
+	require.NoError(t, SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	localBuild, err = readLocalBuild(getBuildDirPath(buildName, buildNumber, ""))
+	require.NoError(t, err)
+	assert.Equal(t, buildName, localBuild.BuildName)
+	assert.Equal(t, buildNumber, localBuild.BuildNumber)
+	assert.True(t, started.Equal(localBuild.Started))
+
+	removedBuilds, err := RemoveLocalBuildsOlderThan(99 * 365 * 24 * time.Hour)
+	require.NoError(t, err)
+	assert.NotNil(t, findLocalBuild(removedBuilds, buildName))
+	assert.NoDirExists(t, localBuild.Path)
+}
+
+func findLocalBuild(localBuilds []*LocalBuild, buildName string) *LocalBuild {
+	for _, localBuild := range localBuilds {
+		if localBuild.BuildName == buildName {
+			return localBuild
+		}
+	}
+	return nil
+}
@@ -2,15 +2,38 @@ package cliutils
 
 import (
 	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
 )
 
 // GetOutputFormat returns the output format from the --format flag on a urfave/cli
-// context, falling back to defaultFormat when the flag is not set.
+// context, falling back to the output format of the active config context, and then
+// to defaultFormat when neither is set.
 // Accepted values are json and table.
 func GetOutputFormat(c *cli.Context, defaultFormat format.OutputFormat) (format.OutputFormat, error) {
+	supportedFormats := []format.OutputFormat{format.Json, format.Table}
 	if !c.IsSet("format") {
-		return defaultFormat, nil
+		return getContextOutputFormat(defaultFormat, supportedFormats), nil
 	}
-	return format.ParseOutputFormat(c.String("format"), []format.OutputFormat{format.Json, format.Table})
+	return format.ParseOutputFormat(c.String("format"), supportedFormats)
+}
+
+// Returns the output format of the active config context, or defaultFormat if no context is active,
+// the context doesn't set an output format, or the command doesn't support it.
+func getContextOutputFormat(defaultFormat format.OutputFormat, supportedFormats []format.OutputFormat) format.OutputFormat {
+	context, err := config.GetActiveContext()
+	if err != nil {
+		log.Debug("Couldn't resolve the active config context: " + err.Error())
+		return defaultFormat
+	}
+	if context == nil || context.OutputFormat == "" {
+		return defaultFormat
+	}
+	contextFormat, err := format.ParseOutputFormat(context.OutputFormat, supportedFormats)
+	if err != nil {
+		log.Debug("Ignoring the output format of the active config context: " + err.Error())
+		return defaultFormat
+	}
+	return contextFormat
 }
@@ -252,7 +252,7 @@ func CreateServerDetailsWithConfigOffer(createServerDetails func() (*config.Serv
 func credentialsChanged(details *config.ServerDetails) bool {
 	return details.Url != "" || details.ArtifactoryUrl != "" || details.DistributionUrl != "" || details.XrayUrl != "" ||
 		details.User != "" || details.Password != "" || details.SshKeyPath != "" || details.SshPassphrase != "" || details.AccessToken != "" ||
-		details.ClientCertKeyPath != "" || details.ClientCertPath != ""
+		details.ClientCertKeyPath != "" || details.ClientCertPath != "" || details.ClientCertKeyPassphrase != ""
 }
 
 func FixWinPathsForFileSystemSourcedCmds(uploadSpec *spec.SpecFiles, specFlag, exclusionsFlag bool) {
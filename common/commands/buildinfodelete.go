@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// BuildInfoDeleteCommand deletes the build-info collected locally for builds, without publishing it.
+// The builds are specified by their name, number and project, or by their IDs, as printed by the build-info list command.
+type BuildInfoDeleteCommand struct {
+	buildName     string
+	buildNumber   string
+	projectKey    string
+	buildIds      []string
+	deletedBuilds []*build.LocalBuild
+}
+
+func NewBuildInfoDeleteCommand() *BuildInfoDeleteCommand {
+	return &BuildInfoDeleteCommand{}
+}
+
+// Sets the build to delete. If the build number is empty, all the numbers of the build are deleted.
+// Only the provided values are used. Unlike other build commands, the build name, number and project aren't read from
+// the environment variables or from the project config, so that builds which weren't specified aren't deleted.
+func (bid *BuildInfoDeleteCommand) SetBuild(buildName, buildNumber, projectKey string) *BuildInfoDeleteCommand {
+	bid.buildName, bid.buildNumber, bid.projectKey = buildName, buildNumber, projectKey
+	return bid
+}
+
+func (bid *BuildInfoDeleteCommand) SetBuildIds(buildIds []string) *BuildInfoDeleteCommand {
+	bid.buildIds = buildIds
+	return bid
+}
+
+// Returns the builds deleted by the last run.
+func (bid *BuildInfoDeleteCommand) DeletedBuilds() []*build.LocalBuild {
+	return bid.deletedBuilds
+}
+
+func (bid *BuildInfoDeleteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (bid *BuildInfoDeleteCommand) CommandName() string {
+	return "build_info_delete"
+}
+
+func (bid *BuildInfoDeleteCommand) Run() error {
+	localBuilds, err := bid.getBuildsToDelete()
+	if err != nil {
+		return err
+	}
+	bid.deletedBuilds = nil
+	for _, localBuild := range localBuilds {
+		if err = localBuild.Remove(); err != nil {
+			return err
+		}
+		bid.deletedBuilds = append(bid.deletedBuilds, localBuild)
+		log.Info("Deleted the local build-info of " + getLocalBuildDescription(localBuild) + ".")
+	}
+	return nil
+}
+
+func (bid *BuildInfoDeleteCommand) getBuildsToDelete() ([]*build.LocalBuild, error) {
+	buildName, buildNumber, projectKey := bid.buildName, bid.buildNumber, bid.projectKey
+	if buildName == "" && buildNumber != "" {
+		return nil, errorutils.CheckErrorf("a build number was provided without a build name")
+	}
+	if buildName == "" && len(bid.buildIds) == 0 {
+		return nil, errorutils.CheckErrorf("either a build name or build IDs must be provided")
+	}
+	var allLocalBuilds, localBuilds []*build.LocalBuild
+	var err error
+	if len(bid.buildIds) > 0 || buildNumber == "" {
+		if allLocalBuilds, err = build.GetLocalBuilds(); err != nil {
+			return nil, err
+		}
+	}
+	for _, buildId := range bid.buildIds {
+		localBuild := findLocalBuild(allLocalBuilds, func(localBuild *build.LocalBuild) bool { return localBuild.Id == buildId })
+		if localBuild == nil {
+			return nil, errorutils.CheckErrorf("no build-info was collected locally for build ID '%s'", buildId)
+		}
+		localBuilds = append(localBuilds, localBuild)
+	}
+	switch {
+	case buildName == "":
+		return localBuilds, nil
+	case buildNumber != "":
+		// The build is found by its dir, since builds collected by older versions can't be found by their name.
+		localBuild, err := build.GetLocalBuild(buildName, buildNumber, projectKey)
+		if err != nil {
+			return nil, err
+		}
+		if localBuild == nil {
+			return nil, errorutils.CheckErrorf("no build-info was collected locally for build '%s/%s'", buildName, buildNumber)
+		}
+		return append(localBuilds, localBuild), nil
+	default:
+		buildsCount, unnamedBuildsCount := len(localBuilds), 0
+		for _, localBuild := range allLocalBuilds {
+			if localBuild.BuildName == buildName && localBuild.ProjectKey == projectKey {
+				localBuilds = append(localBuilds, localBuild)
+			}
+			if localBuild.BuildName == "" {
+				unnamedBuildsCount++
+			}
+		}
+		if len(localBuilds) > buildsCount {
+			return localBuilds, nil
+		}
+		if unnamedBuildsCount == 0 {
+			return nil, errorutils.CheckErrorf("no build-info was collected locally for build '%s'", buildName)
+		}
+		// The details of builds collected by older versions don't include the build's name, so they're found only by their number or ID.
+		return nil, errorutils.CheckErrorf("no build-info was collected locally for build '%s' by its name. "+
+			"%d local builds were collected by older versions, which don't save the build's name, so they can't be deleted by name only. "+
+			"Delete them by their build number, or by their IDs using the --build-ids option. The IDs are shown by the build-info list command", buildName, unnamedBuildsCount)
+	}
+}
+
+func findLocalBuild(localBuilds []*build.LocalBuild, match func(*build.LocalBuild) bool) *build.LocalBuild {
+	for _, localBuild := range localBuilds {
+		if match(localBuild) {
+			return localBuild
+		}
+	}
+	return nil
+}
+
+func getLocalBuildDescription(localBuild *build.LocalBuild) string {
+	if localBuild.BuildName == "" {
+		return fmt.Sprintf("build ID '%s'", localBuild.Id)
+	}
+	return fmt.Sprintf("build '%s/%s'", localBuild.BuildName, localBuild.BuildNumber)
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInfoDeleteCommand(t *testing.T) {
+	buildName := "delete-local-build-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	for _, buildNumber := range []string{"1", "2", "3"} {
+		require.NoError(t, build.SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	}
+
+	// Delete a single build.
+	deleteCmd := NewBuildInfoDeleteCommand().SetBuild(buildName, "1", "")
+	require.NoError(t, deleteCmd.Run())
+	require.Len(t, deleteCmd.DeletedBuilds(), 1)
+	assert.NoDirExists(t, deleteCmd.DeletedBuilds()[0].Path)
+	assert.ErrorContains(t, deleteCmd.Run(), "no build-info was collected locally")
+
+	// Delete all the numbers of the build.
+	deleteCmd.SetBuild(buildName, "", "")
+	require.NoError(t, deleteCmd.Run())
+	var deletedNumbers []string
+	for _, localBuild := range deleteCmd.DeletedBuilds() {
+		deletedNumbers = append(deletedNumbers, localBuild.BuildNumber)
+	}
+	assert.ElementsMatch(t, []string{"2", "3"}, deletedNumbers)
+
+	assert.ErrorContains(t, NewBuildInfoDeleteCommand().Run(), "either a build name or build IDs must be provided")
+	// The build name isn't read from the environment.
+	t.Setenv(coreutils.BuildName, buildName)
+	assert.ErrorContains(t, NewBuildInfoDeleteCommand().Run(), "either a build name or build IDs must be provided")
+	assert.ErrorContains(t, NewBuildInfoDeleteCommand().SetBuild("", "1", "").Run(), "without a build name")
+}
+
+func TestBuildInfoDeleteCommandUnnamedBuild(t *testing.T) {
+	buildName := "unnamed-local-build-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	// The general details, as saved by older versions, without the build's name.
+	buildDir, err := build.GetBuildDir(buildName, "1", "")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, build.RemoveBuildDir(buildName, "1", ""))
+	}()
+	require.NoError(t, os.MkdirAll(filepath.Join(buildDir, "partials"), 0700))
+	content, err := json.Marshal(buildInfo.General{Timestamp: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(buildDir, "partials", build.BuildInfoDetails), content, 0600))
+
+	deleteCmd := NewBuildInfoDeleteCommand().SetBuild(buildName, "", "")
+	assert.ErrorContains(t, deleteCmd.Run(), "--build-ids")
+
+	deleteCmd = NewBuildInfoDeleteCommand().SetBuildIds([]string{filepath.Base(buildDir)})
+	require.NoError(t, deleteCmd.Run())
+	assert.NoDirExists(t, buildDir)
+}
+
+func TestBuildInfoGcCommandRequiresPositiveDays(t *testing.T) {
+	for _, olderThanDays := range []int{0, -1} {
+		assert.ErrorContains(t, NewBuildInfoGcCommand().SetOlderThanDays(olderThanDays).Run(), "the number of days must be positive")
+	}
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const defaultLocalBuildsMaxAgeDays = 30
+
+// BuildInfoGcCommand deletes the build-info collected locally for builds which were never published,
+// if their build-info collection started longer than the given number of days ago.
+type BuildInfoGcCommand struct {
+	olderThanDays int
+	deletedBuilds []*build.LocalBuild
+}
+
+func NewBuildInfoGcCommand() *BuildInfoGcCommand {
+	return &BuildInfoGcCommand{olderThanDays: defaultLocalBuildsMaxAgeDays}
+}
+
+func (big *BuildInfoGcCommand) SetOlderThanDays(olderThanDays int) *BuildInfoGcCommand {
+	big.olderThanDays = olderThanDays
+	return big
+}
+
+// Returns the builds deleted by the last run.
+func (big *BuildInfoGcCommand) DeletedBuilds() []*build.LocalBuild {
+	return big.deletedBuilds
+}
+
+func (big *BuildInfoGcCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (big *BuildInfoGcCommand) CommandName() string {
+	return "build_info_gc"
+}
+
+func (big *BuildInfoGcCommand) Run() (err error) {
+	// A build whose build-info is still being collected may have started moments ago, so the builds of the last day are never garbage-collected.
+	// They can be deleted by the build-info delete command.
+	if big.olderThanDays < 1 {
+		return errorutils.CheckErrorf("the number of days must be positive, got %d", big.olderThanDays)
+	}
+	big.deletedBuilds, err = build.RemoveLocalBuildsOlderThan(time.Duration(big.olderThanDays) * 24 * time.Hour)
+	for _, localBuild := range big.deletedBuilds {
+		log.Debug("Deleted the local build-info of " + getLocalBuildDescription(localBuild) + ".")
+	}
+	if err != nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Deleted the local build-info of %d builds older than %d days.", len(big.deletedBuilds), big.olderThanDays))
+	return
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	servicesUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// BuildInfoListCommand prints the builds whose build-info is collected locally, until they are published.
+type BuildInfoListCommand struct {
+	outputFormat format.OutputFormat
+}
+
+type localBuildRow struct {
+	Id            string `col-name:"ID"`
+	BuildName     string `col-name:"Build Name"`
+	BuildNumber   string `col-name:"Build Number"`
+	ProjectKey    string `col-name:"Project"`
+	Age           string `col-name:"Age"`
+	PartialsCount int    `col-name:"Partials"`
+	Size          string `col-name:"Size"`
+}
+
+func NewBuildInfoListCommand() *BuildInfoListCommand {
+	return &BuildInfoListCommand{outputFormat: format.Table}
+}
+
+func (bil *BuildInfoListCommand) SetOutputFormat(outputFormat format.OutputFormat) *BuildInfoListCommand {
+	bil.outputFormat = outputFormat
+	return bil
+}
+
+func (bil *BuildInfoListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (bil *BuildInfoListCommand) CommandName() string {
+	return "build_info_list"
+}
+
+func (bil *BuildInfoListCommand) Run() error {
+	localBuilds, err := build.GetLocalBuilds()
+	if err != nil {
+		return err
+	}
+	switch bil.outputFormat {
+	case format.Json:
+		if localBuilds == nil {
+			localBuilds = []*build.LocalBuild{}
+		}
+		jsonContent, err := coreutils.GetJsonIndent(localBuilds)
+		if err != nil {
+			return err
+		}
+		log.Output(jsonContent)
+		return nil
+	case format.Table, format.None:
+		if len(localBuilds) == 0 {
+			log.Info("No build-info is collected locally.")
+			return nil
+		}
+		var rows []localBuildRow
+		for _, localBuild := range localBuilds {
+			rows = append(rows, localBuildRow{Id: localBuild.Id, BuildName: localBuild.BuildName, BuildNumber: localBuild.BuildNumber, ProjectKey: localBuild.ProjectKey,
+				Age: formatLocalBuildAge(localBuild.Age()), PartialsCount: localBuild.PartialsCount, Size: servicesUtils.ConvertIntToStorageSizeString(localBuild.Size)})
+		}
+		return coreutils.PrintTable(rows, "Local builds", "", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. The supported formats are: %s", bil.outputFormat, format.Join([]format.OutputFormat{format.Table, format.Json}))
+	}
+}
+
+func formatLocalBuildAge(age time.Duration) string {
+	switch {
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	}
+}
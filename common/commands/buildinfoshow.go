@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// BuildInfoShowCommand prints the build-info that would be published for a build, merged from the build-info collected for it locally.
+type BuildInfoShowCommand struct {
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewBuildInfoShowCommand() *BuildInfoShowCommand {
+	return &BuildInfoShowCommand{}
+}
+
+func (bis *BuildInfoShowCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildInfoShowCommand {
+	bis.buildConfiguration = buildConfiguration
+	return bis
+}
+
+func (bis *BuildInfoShowCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (bis *BuildInfoShowCommand) CommandName() string {
+	return "build_info_show"
+}
+
+func (bis *BuildInfoShowCommand) Run() error {
+	buildName, err := bis.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bis.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	if buildName == "" || buildNumber == "" {
+		return errorutils.CheckErrorf("the build-name and build-number options are mandatory")
+	}
+	buildInfo, err := build.GetLocalBuildInfo(buildName, buildNumber, bis.buildConfiguration.GetProject())
+	if err != nil {
+		return err
+	}
+	jsonContent, err := coreutils.GetJsonIndent(buildInfo)
+	if err != nil {
+		return err
+	}
+	log.Output(jsonContent)
+	return nil
+}
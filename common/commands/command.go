@@ -33,6 +33,9 @@ func Exec(command Command) error {
 	commandName := command.CommandName()
 	flags := GetContextFlags()
 	CollectMetrics(commandName, flags)
+	if err := checkCredentialExpiry(command); err != nil {
+		return err
+	}
 	channel := make(chan bool)
 	// Triggers the report usage.
 	go reportCommandUsage(command, channel)
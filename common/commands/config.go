@@ -61,7 +61,8 @@ type ConfigCommand struct {
 	useBasicAuthOnly bool
 	serverId         string
 	// Preselected web login authentication method, supported on an interactive command only.
-	useWebLogin bool
+	useWebLogin     bool
+	webLoginOptions utils.WebLoginOptions
 	// Preserve per-service URL prompts (Artifactory/Distribution/Xray/Mission Control/Pipelines),
 	// for Artifactory v6.x self-hosted customers where these products don't share a single platform URL.
 	legacy bool
@@ -97,6 +98,11 @@ func (cc *ConfigCommand) SetUseWebLogin(useWebLogin bool) *ConfigCommand {
 	return cc
 }
 
+func (cc *ConfigCommand) SetWebLoginOptions(webLoginOptions utils.WebLoginOptions) *ConfigCommand {
+	cc.webLoginOptions = webLoginOptions
+	return cc
+}
+
 func (cc *ConfigCommand) SetLegacy(legacy bool) *ConfigCommand {
 	cc.legacy = legacy
 	return cc
@@ -123,6 +129,10 @@ func (cc *ConfigCommand) SetDetails(details *config.ServerDetails) *ConfigComman
 }
 
 func (cc *ConfigCommand) Run() (err error) {
+	// Fail before prompting for the server details, since all the config actions write the config file.
+	if config.IsConfigReadOnly() {
+		return errorutils.CheckError(config.ErrConfigReadOnly)
+	}
 	log.Debug("Locking config file to run config " + cc.cmdType + " command.")
 	unlockFunc, err := lockConfig()
 	// Defer the lockFile.Unlock() function before throwing a possible error to avoid deadlock situations.
@@ -186,6 +196,9 @@ func (cc *ConfigCommand) config() error {
 	if err = cc.assertUrlsSafe(); err != nil {
 		return err
 	}
+	if err = cc.details.ValidateHttpClientSettings(); err != nil {
+		return err
+	}
 	if err = cc.encPasswordIfNeeded(); err != nil {
 		return err
 	}
@@ -225,6 +238,14 @@ func (cc *ConfigCommand) getConfigurationNonInteractively() error {
 
 // When a user is configuration a new server with OIDC, we will exchange the token and set the access token.
 func exchangeOidcTokenAndSetAccessToken(cc *ConfigCommand) error {
+	// If no OIDC token was provided, try acquiring it from the CI or workload environment of the provider.
+	if cc.oidcSetupParams.TokenId == "" {
+		tokenId, err := generic.AcquireOidcTokenId(cc.oidcSetupParams.ProviderType, cc.oidcSetupParams.Audience)
+		if err != nil {
+			return err
+		}
+		cc.oidcSetupParams.TokenId = tokenId
+	}
 	if err := validateOidcParams(cc.details.Url, cc.oidcSetupParams); err != nil {
 		return err
 	}
@@ -252,8 +273,15 @@ func exchangeOidcTokenAndSetAccessToken(cc *ConfigCommand) error {
 	if err != nil {
 		return err
 	}
-	// Update the config server details with the exchanged token
+	// Update the config server details with the exchanged token, and keep the exchange parameters for re-exchanging it before it expires
 	cc.details.AccessToken = exchangeOidcTokenCmd.GetExchangedToken()
+	cc.details.OidcExchangeParams = &config.OidcExchangeParams{
+		ProviderName:   cc.oidcSetupParams.ProviderName,
+		ProviderType:   cc.oidcSetupParams.ProviderType.String(),
+		Audience:       cc.oidcSetupParams.Audience,
+		ProjectKey:     cc.oidcSetupParams.ProjectKey,
+		ApplicationKey: cc.oidcSetupParams.ApplicationKey,
+	}
 	return nil
 }
 
@@ -339,6 +367,10 @@ func (cc *ConfigCommand) prepareConfigurationData() ([]*config.ServerDetails, er
 	// Set default server details if the server existed in the configurations list.
 	// Otherwise, if default details were not set, initialize empty default details.
 	if tempConfiguration != nil {
+		// The secrets of the edited server are used as the defaults of its new details.
+		if err = config.ResolveServerSecrets(tempConfiguration); err != nil {
+			return configurations, err
+		}
 		cc.defaultDetails = tempConfiguration
 		cc.details.IsDefault = tempConfiguration.IsDefault
 	} else if cc.defaultDetails == nil {
@@ -422,11 +454,12 @@ func (cc *ConfigCommand) fillSpecificUrlsFromPlatform() (disallowUsingSavedPassw
 	return
 }
 
-func (cc *ConfigCommand) checkCertificateForMTLS() {
-	if cc.details.ClientCertPath != "" && cc.details.ClientCertKeyPath != "" {
-		return
+func (cc *ConfigCommand) checkCertificateForMTLS() error {
+	// A PKCS#12 bundle includes the private key.
+	if cc.details.ClientCertPath == "" || cc.details.ClientCertKeyPath == "" && !config.IsPkcs12ClientCert(cc.details.ClientCertPath) {
+		cc.readClientCertInfoFromConsole()
 	}
-	cc.readClientCertInfoFromConsole()
+	return readClientCertPassphraseFromConsole(cc.details)
 }
 
 func (cc *ConfigCommand) promptAuthMethods() (selectedMethod AuthenticationMethod, err error) {
@@ -478,7 +511,9 @@ func (cc *ConfigCommand) promptForCredentials(disallowUsingSavedPassword bool) (
 	case AccessToken:
 		return cc.promptForAccessToken()
 	case MTLS:
-		cc.checkCertificateForMTLS()
+		if err = cc.checkCertificateForMTLS(); err != nil {
+			return
+		}
 		log.Warn("Please notice that authentication using client certificates (mTLS) is not supported by commands which integrate with package managers.")
 		return nil
 	case WebLogin:
@@ -514,9 +549,30 @@ func (cc *ConfigCommand) readClientCertInfoFromConsole() {
 	if cc.details.ClientCertPath == "" {
 		ioutils.ScanFromConsole("Client certificate file path", &cc.details.ClientCertPath, cc.defaultDetails.ClientCertPath)
 	}
-	if cc.details.ClientCertKeyPath == "" {
-		ioutils.ScanFromConsole("Client certificate key path", &cc.details.ClientCertKeyPath, cc.defaultDetails.ClientCertKeyPath)
+	if cc.details.ClientCertKeyPath == "" && !config.IsPkcs12ClientCert(cc.details.ClientCertPath) {
+		ioutils.ScanFromConsole("Client certificate key path (optional if included in the certificate file)", &cc.details.ClientCertKeyPath, cc.defaultDetails.ClientCertKeyPath)
+	}
+}
+
+// Reads the passphrase of a PKCS#12 bundle or an encrypted client certificate key, if not provided.
+func readClientCertPassphraseFromConsole(details *config.ServerDetails) error {
+	if details.ClientCertKeyPassphrase != "" {
+		return nil
+	}
+	encrypted, err := details.IsClientCertEncrypted()
+	if err != nil {
+		log.Info("Could not read the client certificate key. You may place the key file there later.")
+		return nil
+	}
+	if !encrypted {
+		return nil
+	}
+	passphrase, err := ioutils.ScanPasswordFromConsole("Client certificate passphrase:")
+	if err != nil {
+		return err
 	}
+	details.SetClientCertKeyPassphrase(passphrase)
+	return nil
 }
 
 func (cc *ConfigCommand) SetOidcExchangeTokenId(id string) {
@@ -591,7 +647,7 @@ func ShowConfig(serverName string) error {
 		configuration = []*config.ServerDetails{singleConfig}
 	} else {
 		var err error
-		configuration, err = config.GetAllServersConfigs()
+		configuration, err = config.GetAllServersConfigsWithEnvOverlay()
 		if err != nil {
 			return err
 		}
@@ -640,8 +696,9 @@ func Import(configTokenString string) (err error) {
 	return configCommand.config()
 }
 
+// Exports the server as saved in the config file. The values set by environment variables aren't exported.
 func Export(serverName string) error {
-	serverDetails, err := config.GetSpecificConfig(serverName, true, false)
+	serverDetails, err := config.GetSavedServerConfig(serverName, true)
 	if err != nil {
 		return err
 	}
@@ -656,6 +713,61 @@ func Export(serverName string) error {
 	return nil
 }
 
+// Imports all the servers bundled in the config token. The passphrase is required if the token is encrypted.
+func ImportServers(configTokenString, passphrase string) (err error) {
+	servers, err := config.ImportServers(configTokenString, passphrase)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Locking config file to run config import command.")
+	unlockFunc, err := lockConfig()
+	// Defer the lockFile.Unlock() function before throwing a possible error to avoid deadlock situations.
+	defer func() {
+		err = errors.Join(err, unlockFunc())
+	}()
+	if err != nil {
+		return err
+	}
+	for _, serverDetails := range servers {
+		log.Info("Importing server ID", "'"+serverDetails.ServerId+"'")
+		configCommand := &ConfigCommand{
+			details:     serverDetails,
+			serverId:    serverDetails.ServerId,
+			makeDefault: serverDetails.IsDefault,
+		}
+		if err = configCommand.config(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exports the provided servers to a single config token. If no server IDs are provided, all the servers are exported.
+// The servers are exported as saved in the config file. The values set by environment variables aren't exported.
+func ExportServers(serverIds []string, options config.ExportServersOptions) error {
+	if len(serverIds) == 0 {
+		serverIds = GetAllServerIds()
+	}
+	if len(serverIds) == 0 {
+		return errorutils.CheckErrorf("cannot export config, because it is empty. Run 'jf c add' and then export again")
+	}
+	servers := make([]*config.ServerDetails, 0, len(serverIds))
+	for _, serverId := range serverIds {
+		serverDetails, err := config.GetSavedServerConfig(serverId, false)
+		if err != nil {
+			return err
+		}
+		servers = append(servers, serverDetails)
+	}
+	configTokenString, err := config.ExportServers(servers, options)
+	if err != nil {
+		return err
+	}
+	log.Output(configTokenString)
+	return nil
+}
+
 func moveDefaultConfigToSliceEnd(configuration []*config.ServerDetails) []*config.ServerDetails {
 	lastIndex := len(configuration) - 1
 	// If configuration list has more than one config and the last one is not default, switch the last default config with the last one
@@ -676,27 +788,56 @@ func printConfigs(configuration []*config.ServerDetails) {
 	configuration = moveDefaultConfigToSliceEnd(configuration)
 
 	for _, details := range configuration {
-		isDefault := details.IsDefault
-		logIfNotEmpty(details.ServerId, "Server ID:\t\t\t", false, isDefault)
-		logIfNotEmpty(details.Url, "JFrog Platform URL:\t\t", false, isDefault)
-		logIfNotEmpty(details.ArtifactoryUrl, "Artifactory URL:\t\t", false, isDefault)
-		logIfNotEmpty(details.DistributionUrl, "Distribution URL:\t\t", false, isDefault)
-		logIfNotEmpty(details.XrayUrl, "Xray URL:\t\t\t", false, isDefault)
-		logIfNotEmpty(details.MissionControlUrl, "Mission Control URL:\t\t", false, isDefault)
-		logIfNotEmpty(details.PipelinesUrl, "Pipelines URL:\t\t\t", false, isDefault)
-		logIfNotEmpty(details.User, "User:\t\t\t\t", false, isDefault)
-		logIfNotEmpty(details.Password, "Password:\t\t\t", true, isDefault)
-		logAccessTokenIfNotEmpty(details.AccessToken, isDefault)
-		logIfNotEmpty(details.RefreshToken, "Refresh token:\t\t\t", true, isDefault)
-		logIfNotEmpty(details.SshKeyPath, "SSH key file path:\t\t", false, isDefault)
-		logIfNotEmpty(details.SshPassphrase, "SSH passphrase:\t\t\t", true, isDefault)
-		logIfNotEmpty(details.ClientCertPath, "Client certificate file path:\t", false, isDefault)
-		logIfNotEmpty(details.ClientCertKeyPath, "Client certificate key path:\t", false, isDefault)
-		logIfNotEmpty(strconv.FormatBool(details.IsDefault), "Default:\t\t\t", false, isDefault)
+		logServerFieldIfNotEmpty(details, "serverId", details.ServerId, "Server ID:\t\t\t", false)
+		logServerFieldIfNotEmpty(details, "url", details.Url, "JFrog Platform URL:\t\t", false)
+		logServerFieldIfNotEmpty(details, "artifactoryUrl", details.ArtifactoryUrl, "Artifactory URL:\t\t", false)
+		logServerFieldIfNotEmpty(details, "distributionUrl", details.DistributionUrl, "Distribution URL:\t\t", false)
+		logServerFieldIfNotEmpty(details, "xrayUrl", details.XrayUrl, "Xray URL:\t\t\t", false)
+		logServerFieldIfNotEmpty(details, "missionControlUrl", details.MissionControlUrl, "Mission Control URL:\t\t", false)
+		logServerFieldIfNotEmpty(details, "pipelinesUrl", details.PipelinesUrl, "Pipelines URL:\t\t\t", false)
+		logServerFieldIfNotEmpty(details, "user", details.User, "User:\t\t\t\t", false)
+		logServerFieldIfNotEmpty(details, "password", details.Password, "Password:\t\t\t", true)
+		logAccessTokenIfNotEmpty(details)
+		logServerFieldIfNotEmpty(details, "refreshToken", details.RefreshToken, "Refresh token:\t\t\t", true)
+		logServerFieldIfNotEmpty(details, "sshKeyPath", details.SshKeyPath, "SSH key file path:\t\t", false)
+		logServerFieldIfNotEmpty(details, "sshPassphrase", details.SshPassphrase, "SSH passphrase:\t\t\t", true)
+		logServerFieldIfNotEmpty(details, "clientCertPath", details.ClientCertPath, "Client certificate file path:\t", false)
+		logServerFieldIfNotEmpty(details, "clientCertKeyPath", details.ClientCertKeyPath, "Client certificate key path:\t", false)
+		logServerFieldIfNotEmpty(details, "clientCertKeyPassphrase", details.ClientCertKeyPassphrase, "Client certificate passphrase:\t", true)
+		logServerFieldIfNotEmpty(details, "proxy", details.Proxy, "Proxy:\t\t\t\t", false)
+		logServerFieldIfNotEmpty(details, "caBundlePath", details.CaBundlePath, "CA bundle file path:\t\t", false)
+		logServerFieldIfNotEmpty(details, "dialTimeoutSecs", formatIfPositive(details.DialTimeoutSecs), "Dial timeout (seconds):\t\t", false)
+		logServerFieldIfNotEmpty(details, "requestTimeoutSecs", formatIfPositive(details.RequestTimeoutSecs), "Request timeout (seconds):\t", false)
+		if details.HttpRetries != nil {
+			logServerFieldIfNotEmpty(details, "httpRetries", strconv.Itoa(*details.HttpRetries), "HTTP retries:\t\t\t", false)
+			logServerFieldIfNotEmpty(details, "httpRetryWaitMilliSecs", formatIfPositive(details.HttpRetryWaitMilliSecs), "HTTP retry wait (ms):\t\t", false)
+		}
+		logServerFieldIfNotEmpty(details, "isDefault", strconv.FormatBool(details.IsDefault), "Default:\t\t\t", false)
 		log.Output()
 	}
 }
 
+func formatIfPositive(value int) string {
+	if value <= 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+// Logs the server's field, marking it with the name of the environment variable which set it, if any.
+func logServerFieldIfNotEmpty(details *config.ServerDetails, field, value, prefix string, mask bool) {
+	if value == "" {
+		return
+	}
+	if mask {
+		value = "***"
+	}
+	if envVar := details.GetSourceEnvVar(field); envVar != "" {
+		value += fmt.Sprintf(" (from env: %s)", envVar)
+	}
+	logIfNotEmpty(value, prefix, false, details.IsDefault)
+}
+
 func logIfNotEmpty(value, prefix string, mask, isDefault bool) {
 	if value != "" {
 		if mask {
@@ -710,13 +851,14 @@ func logIfNotEmpty(value, prefix string, mask, isDefault bool) {
 	}
 }
 
-func logAccessTokenIfNotEmpty(token string, isDefault bool) {
+func logAccessTokenIfNotEmpty(details *config.ServerDetails) {
+	token := details.AccessToken
 	if token == "" {
 		return
 	}
 	tokenString := "***"
-	// Extract the token's subject only if it is JWT
-	if strings.Count(token, ".") == 2 {
+	// Extract the token's subject only if it is JWT. The listed servers keep their secrets as references to the secret store.
+	if !config.IsSecretReference(token) && strings.Count(token, ".") == 2 {
 		subject, err := auth.ExtractSubjectFromAccessToken(token)
 		if err != nil {
 			log.Error(err)
@@ -725,7 +867,7 @@ func logAccessTokenIfNotEmpty(token string, isDefault bool) {
 		}
 	}
 
-	logIfNotEmpty(tokenString, "Access token:\t\t\t", false, isDefault)
+	logServerFieldIfNotEmpty(details, "accessToken", tokenString, "Access token:\t\t\t", false)
 }
 
 func (cc *ConfigCommand) delete() error {
@@ -851,7 +993,7 @@ func (cc *ConfigCommand) validateTokenIsNotApiKey() error {
 }
 
 func (cc *ConfigCommand) handleWebLogin() error {
-	token, err := utils.DoWebLogin(cc.details)
+	token, err := utils.DoWebLoginWithOptions(cc.details, cc.webLoginOptions)
 	if err != nil {
 		return err
 	}
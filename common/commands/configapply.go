@@ -0,0 +1,699 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	generic "github.com/jfrog/jfrog-cli-core/v2/general/token"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configApplyCommandName = "config_apply"
+	ServersSpecVersion     = 1
+)
+
+// The authentication methods of a server in the servers spec.
+const (
+	AuthMethodAccessToken = "access-token"
+	AuthMethodBasic       = "basic"
+	AuthMethodSsh         = "ssh"
+	AuthMethodMtls        = "mtls"
+	AuthMethodOidc        = "oidc"
+)
+
+// ServersSpec is the desired set of servers, as declared in the YAML file applied by ConfigApplyCommand.
+// The credentials may reference environment variables, using the ${VAR} syntax, to avoid keeping secrets in the file.
+type ServersSpec struct {
+	Version int           `yaml:"version,omitempty"`
+	Servers []*ServerSpec `yaml:"servers"`
+}
+
+type ServerSpec struct {
+	ServerId          string `yaml:"serverId"`
+	Url               string `yaml:"url,omitempty"`
+	ArtifactoryUrl    string `yaml:"artifactoryUrl,omitempty"`
+	DistributionUrl   string `yaml:"distributionUrl,omitempty"`
+	XrayUrl           string `yaml:"xrayUrl,omitempty"`
+	MissionControlUrl string `yaml:"missionControlUrl,omitempty"`
+	PipelinesUrl      string `yaml:"pipelinesUrl,omitempty"`
+	AccessUrl         string `yaml:"accessUrl,omitempty"`
+	// At most one server can be declared as the default. If no server is declared as the default, the current default is kept.
+	Default             bool     `yaml:"default,omitempty"`
+	DisableTokenRefresh bool     `yaml:"disableTokenRefresh,omitempty"`
+	Auth                AuthSpec `yaml:"auth,omitempty"`
+	Http                HttpSpec `yaml:"http,omitempty"`
+	// The credentials of specific services, keyed by the service name, such as 'xray'. See config.Services.
+	// If not declared, the current service credentials of the server are kept.
+	ServiceCredentials map[string]*ServiceCredentialsSpec `yaml:"serviceCredentials,omitempty"`
+}
+
+// The credentials used for a specific service instead of the server credentials. May reference environment variables.
+type ServiceCredentialsSpec struct {
+	User        string `yaml:"user,omitempty"`
+	Password    string `yaml:"password,omitempty"`    // #nosec G117 -- config spec for auth
+	AccessToken string `yaml:"accessToken,omitempty"` // #nosec G117 -- config spec for auth
+}
+
+// The HTTP client settings of the server.
+type HttpSpec struct {
+	// May reference environment variables. Must not include credentials, since it is saved as plain text.
+	Proxy              string `yaml:"proxy,omitempty"`
+	CaBundlePath       string `yaml:"caBundlePath,omitempty"`
+	DialTimeoutSecs    int    `yaml:"dialTimeoutSecs,omitempty"`
+	RequestTimeoutSecs int    `yaml:"requestTimeoutSecs,omitempty"`
+	Retries            *int   `yaml:"retries,omitempty"`
+	RetryWaitMilliSecs int    `yaml:"retryWaitMilliSecs,omitempty"`
+}
+
+type AuthSpec struct {
+	// One of the AuthMethod constants. If empty, the method is deduced from the provided credentials.
+	Method                  string    `yaml:"method,omitempty"`
+	User                    string    `yaml:"user,omitempty"`
+	Password                string    `yaml:"password,omitempty"`    // #nosec G117 -- config spec for auth
+	AccessToken             string    `yaml:"accessToken,omitempty"` // #nosec G117 -- config spec for auth
+	SshKeyPath              string    `yaml:"sshKeyPath,omitempty"`
+	SshPassphrase           string    `yaml:"sshPassphrase,omitempty"`
+	ClientCertPath          string    `yaml:"clientCertPath,omitempty"`
+	ClientCertKeyPath       string    `yaml:"clientCertKeyPath,omitempty"`
+	ClientCertKeyPassphrase string    `yaml:"clientCertKeyPassphrase,omitempty"`
+	Oidc                    *OidcSpec `yaml:"oidc,omitempty"`
+}
+
+// If the token ID is empty, the token is acquired from the CI or workload environment of the provider type.
+type OidcSpec struct {
+	ProviderName   string `yaml:"providerName"`
+	ProviderType   string `yaml:"providerType,omitempty"`
+	TokenId        string `yaml:"tokenId,omitempty"`
+	Audience       string `yaml:"audience,omitempty"`
+	ProjectKey     string `yaml:"projectKey,omitempty"`
+	ApplicationKey string `yaml:"applicationKey,omitempty"`
+}
+
+type ServerChangeType string
+
+const (
+	ServerAdded     ServerChangeType = "add"
+	ServerUpdated   ServerChangeType = "update"
+	ServerRemoved   ServerChangeType = "remove"
+	ServerUnchanged ServerChangeType = "unchanged"
+)
+
+// The change applied to a server. Fields lists the names of the updated fields, without their values.
+type ServerChange struct {
+	ServerId string
+	Type     ServerChangeType
+	Fields   []string
+}
+
+// ConfigApplyCommand reconciles the configured servers with the servers declared in a YAML file.
+// Servers which are missing are added, and servers which differ are updated. If prune is set, servers which aren't declared are removed.
+type ConfigApplyCommand struct {
+	filePath string
+	dryRun   bool
+	prune    bool
+	changes  []ServerChange
+}
+
+func NewConfigApplyCommand() *ConfigApplyCommand {
+	return &ConfigApplyCommand{}
+}
+
+func (cac *ConfigApplyCommand) SetFilePath(filePath string) *ConfigApplyCommand {
+	cac.filePath = filePath
+	return cac
+}
+
+func (cac *ConfigApplyCommand) SetDryRun(dryRun bool) *ConfigApplyCommand {
+	cac.dryRun = dryRun
+	return cac
+}
+
+func (cac *ConfigApplyCommand) SetPrune(prune bool) *ConfigApplyCommand {
+	cac.prune = prune
+	return cac
+}
+
+func (cac *ConfigApplyCommand) Changes() []ServerChange {
+	return cac.changes
+}
+
+func (cac *ConfigApplyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (cac *ConfigApplyCommand) CommandName() string {
+	return configApplyCommandName
+}
+
+func (cac *ConfigApplyCommand) Run() (err error) {
+	spec, err := ReadServersSpec(cac.filePath)
+	if err != nil {
+		return err
+	}
+	if !cac.dryRun {
+		if config.IsConfigReadOnly() {
+			return errorutils.CheckError(config.ErrConfigReadOnly)
+		}
+		log.Debug("Locking config file to run config apply command.")
+		var unlockFunc func() error
+		unlockFunc, err = lockConfig()
+		// Defer the lockFile.Unlock() function before throwing a possible error to avoid deadlock situations.
+		defer func() {
+			err = errors.Join(err, unlockFunc())
+		}()
+		if err != nil {
+			return
+		}
+	}
+	current, err := config.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	servers, changes, err := reconcileServers(current, spec, cac.prune)
+	if err != nil {
+		return err
+	}
+	cac.changes = changes
+	printServerChanges(changes, cac.dryRun)
+	if cac.dryRun || !hasServerChanges(changes) {
+		return nil
+	}
+	if err = exchangeOidcTokens(spec, changes, servers); err != nil {
+		return err
+	}
+	return config.SaveServersConf(servers)
+}
+
+// Reads and validates the servers spec YAML file.
+func ReadServersSpec(filePath string) (*ServersSpec, error) {
+	if filePath == "" {
+		return nil, errorutils.CheckErrorf("the servers spec file path must be provided")
+	}
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	spec := new(ServersSpec)
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	// Unknown fields are most likely typos, which would otherwise be silently ignored.
+	decoder.KnownFields(true)
+	if err = decoder.Decode(spec); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the servers spec file '%s': %s", filePath, err.Error())
+	}
+	return spec, spec.validate()
+}
+
+func (spec *ServersSpec) validate() error {
+	if spec.Version > ServersSpecVersion {
+		return errorutils.CheckErrorf("unsupported servers spec version %d. The latest supported version is %d", spec.Version, ServersSpecVersion)
+	}
+	serverIds := make(map[string]bool)
+	defaults := 0
+	for _, server := range spec.Servers {
+		if server.ServerId == "" {
+			return errorutils.CheckErrorf("a server in the servers spec is missing a 'serverId'")
+		}
+		if serverIds[server.ServerId] {
+			return errorutils.CheckErrorf("server ID '%s' is declared more than once in the servers spec", server.ServerId)
+		}
+		serverIds[server.ServerId] = true
+		if server.Url == "" && server.ArtifactoryUrl == "" {
+			return errorutils.CheckErrorf("server ID '%s' must have either a 'url' or an 'artifactoryUrl'", server.ServerId)
+		}
+		if server.Url == "" && server.Auth.Oidc != nil {
+			return errorutils.CheckErrorf("server ID '%s' uses the OIDC auth method, and must have a 'url'", server.ServerId)
+		}
+		if server.Default {
+			defaults++
+		}
+		if err := server.Auth.validate(server.ServerId); err != nil {
+			return err
+		}
+		if err := server.validateServiceCredentials(); err != nil {
+			return err
+		}
+		server.Http.Proxy = os.ExpandEnv(server.Http.Proxy)
+	}
+	if defaults > 1 {
+		return errorutils.CheckErrorf("only one server can be declared as the default in the servers spec")
+	}
+	return nil
+}
+
+func (authSpec *AuthSpec) validate(serverId string) error {
+	authSpec.expandEnv()
+	deduced := authSpec.deduceMethod()
+	if authSpec.Method == "" {
+		authSpec.Method = deduced
+	}
+	switch authSpec.Method {
+	case "", AuthMethodAccessToken, AuthMethodBasic, AuthMethodSsh, AuthMethodMtls, AuthMethodOidc:
+	default:
+		return errorutils.CheckErrorf("server ID '%s' has an unsupported auth method '%s'. The supported methods are: %s", serverId, authSpec.Method,
+			strings.Join([]string{AuthMethodAccessToken, AuthMethodBasic, AuthMethodSsh, AuthMethodMtls, AuthMethodOidc}, ", "))
+	}
+	methods := coreutils.SumTrueValues([]bool{authSpec.AccessToken != "", authSpec.Password != "", authSpec.SshKeyPath != "", authSpec.Oidc != nil})
+	if methods > 1 || (deduced != "" && deduced != authSpec.Method) {
+		return errorutils.CheckErrorf("server ID '%s' must have the credentials of a single auth method, matching its 'method'", serverId)
+	}
+	if authSpec.Password == "" && (authSpec.Method == AuthMethodBasic || authSpec.Method == "" && authSpec.User != "") {
+		return errorutils.CheckErrorf("server ID '%s' uses the basic auth method, and must have a 'password'. The password may reference an environment variable, such as ${MY_PASSWORD}, which must be set", serverId)
+	}
+	if authSpec.Method == AuthMethodOidc {
+		if authSpec.Oidc == nil || authSpec.Oidc.ProviderName == "" {
+			return errorutils.CheckErrorf("server ID '%s' uses the OIDC auth method, and must have the 'oidc.providerName' field", serverId)
+		}
+		// The provider type is normalized, so that it's compared with the provider type of the configured server.
+		providerType, err := generic.OidcProviderTypeFromString(authSpec.Oidc.ProviderType)
+		if err != nil {
+			return errorutils.CheckErrorf("server ID '%s': %s", serverId, err.Error())
+		}
+		authSpec.Oidc.ProviderType = providerType.String()
+	}
+	return nil
+}
+
+func (server *ServerSpec) validateServiceCredentials() error {
+	for service, credentials := range server.ServiceCredentials {
+		if !slices.Contains(config.Services, service) {
+			return errorutils.CheckErrorf("server ID '%s' has credentials for an unsupported service '%s'. The supported services are: %s", server.ServerId, service, strings.Join(config.Services, ", "))
+		}
+		if credentials == nil {
+			continue
+		}
+		for _, value := range []*string{&credentials.User, &credentials.Password, &credentials.AccessToken} {
+			*value = os.ExpandEnv(*value)
+		}
+		if credentials.AccessToken != "" && credentials.Password != "" || credentials.AccessToken == "" && (credentials.User == "" || credentials.Password == "") {
+			return errorutils.CheckErrorf("the '%s' service credentials of server ID '%s' must have either an 'accessToken', or a 'user' and a 'password'", service, server.ServerId)
+		}
+	}
+	return nil
+}
+
+func (authSpec *AuthSpec) deduceMethod() string {
+	switch {
+	case authSpec.Oidc != nil:
+		return AuthMethodOidc
+	case authSpec.SshKeyPath != "":
+		return AuthMethodSsh
+	case authSpec.Password != "":
+		return AuthMethodBasic
+	case authSpec.AccessToken != "":
+		return AuthMethodAccessToken
+	case authSpec.ClientCertPath != "":
+		return AuthMethodMtls
+	default:
+		return ""
+	}
+}
+
+func (authSpec *AuthSpec) expandEnv() {
+	for _, value := range []*string{&authSpec.User, &authSpec.Password, &authSpec.AccessToken, &authSpec.SshPassphrase, &authSpec.ClientCertKeyPassphrase} {
+		*value = os.ExpandEnv(*value)
+	}
+	if authSpec.Oidc != nil {
+		authSpec.Oidc.TokenId = os.ExpandEnv(authSpec.Oidc.TokenId)
+	}
+}
+
+// Returns the server details declared by the spec. The access token of OIDC servers is exchanged only when the server is applied.
+func (server *ServerSpec) toServerDetails() *config.ServerDetails {
+	details := &config.ServerDetails{
+		ServerId:                server.ServerId,
+		Url:                     server.Url,
+		ArtifactoryUrl:          server.ArtifactoryUrl,
+		DistributionUrl:         server.DistributionUrl,
+		XrayUrl:                 server.XrayUrl,
+		MissionControlUrl:       server.MissionControlUrl,
+		PipelinesUrl:            server.PipelinesUrl,
+		AccessUrl:               server.AccessUrl,
+		DisableTokenRefresh:     server.DisableTokenRefresh,
+		User:                    strings.ToLower(server.Auth.User),
+		Password:                server.Auth.Password,
+		AccessToken:             server.Auth.AccessToken,
+		SshKeyPath:              server.Auth.SshKeyPath,
+		SshPassphrase:           server.Auth.SshPassphrase,
+		ClientCertPath:          server.Auth.ClientCertPath,
+		ClientCertKeyPath:       server.Auth.ClientCertKeyPath,
+		ClientCertKeyPassphrase: server.Auth.ClientCertKeyPassphrase,
+		Proxy:                   server.Http.Proxy,
+		CaBundlePath:            server.Http.CaBundlePath,
+		DialTimeoutSecs:         server.Http.DialTimeoutSecs,
+		RequestTimeoutSecs:      server.Http.RequestTimeoutSecs,
+		HttpRetries:             server.Http.Retries,
+	}
+	if server.Http.Retries != nil {
+		details.HttpRetryWaitMilliSecs = server.Http.RetryWaitMilliSecs
+	}
+	if server.ServiceCredentials != nil {
+		// Distinguishes declaring no service credentials from not managing them.
+		details.ServiceCredentials = make(map[string]*config.ServiceCredentials)
+	}
+	for service, credentials := range server.ServiceCredentials {
+		if credentials != nil {
+			details.SetServiceCredentials(service, &config.ServiceCredentials{User: strings.ToLower(credentials.User), Password: credentials.Password, AccessToken: credentials.AccessToken})
+		}
+	}
+	if details.Url != "" && !fileutils.IsSshUrl(details.Url) {
+		details.Url = clientUtils.AddTrailingSlashIfNeeded(details.Url)
+		// Derive JFrog services URLs from platform URL
+		coreutils.SetIfEmpty(&details.ArtifactoryUrl, details.Url+"artifactory/")
+		coreutils.SetIfEmpty(&details.DistributionUrl, details.Url+"distribution/")
+		coreutils.SetIfEmpty(&details.XrayUrl, details.Url+"xray/")
+		coreutils.SetIfEmpty(&details.MissionControlUrl, details.Url+"mc/")
+		coreutils.SetIfEmpty(&details.PipelinesUrl, details.Url+"pipelines/")
+	}
+	for _, serviceUrl := range []*string{&details.ArtifactoryUrl, &details.DistributionUrl, &details.XrayUrl, &details.MissionControlUrl, &details.PipelinesUrl} {
+		*serviceUrl = clientUtils.AddTrailingSlashIfNeeded(*serviceUrl)
+	}
+	if server.Auth.Method == AuthMethodAccessToken && details.User == "" {
+		details.User = auth.ExtractUsernameFromAccessToken(details.AccessToken)
+	}
+	if server.Auth.Method == AuthMethodBasic {
+		// Set the default interval for the refreshable tokens to be initialized in the next CLI run, as done by 'jf config add'.
+		details.ArtifactoryTokenRefreshInterval = coreutils.TokenRefreshDefaultInterval
+	}
+	return details
+}
+
+// Returns the servers after applying the spec over the current servers, and the changes made to each server.
+func reconcileServers(current []*config.ServerDetails, spec *ServersSpec, prune bool) ([]*config.ServerDetails, []ServerChange, error) {
+	currentById := make(map[string]*config.ServerDetails, len(current))
+	wasDefault := make(map[string]bool, len(current))
+	for _, details := range current {
+		currentById[details.ServerId] = details
+		wasDefault[details.ServerId] = details.IsDefault
+	}
+	var servers []*config.ServerDetails
+	updatedFields := make(map[string][]string)
+	declaredDefault := ""
+	for _, server := range spec.Servers {
+		if server.Default {
+			declaredDefault = server.ServerId
+		}
+		desired := server.toServerDetails()
+		if err := assertSingleAuthMethod(desired); err != nil {
+			return nil, nil, errorutils.CheckErrorf("server ID '%s': %s", server.ServerId, err.Error())
+		}
+		if err := desired.ValidateHttpClientSettings(); err != nil {
+			return nil, nil, err
+		}
+		currentDetails, exists := currentById[server.ServerId]
+		if !exists {
+			servers = append(servers, desired)
+			continue
+		}
+		delete(currentById, server.ServerId)
+		// The current secrets are compared to the declared ones.
+		if err := config.ResolveServerSecrets(currentDetails); err != nil {
+			return nil, nil, err
+		}
+		fields := getChangedServerFields(currentDetails, desired, &server.Auth)
+		if len(fields) == 0 {
+			// Keep the current details, including the tokens which were created for the server.
+			servers = append(servers, currentDetails)
+			continue
+		}
+		updatedFields[server.ServerId] = fields
+		servers = append(servers, mergeDeclaredServerFields(currentDetails, desired, fields))
+	}
+	// Keep the servers which aren't declared, in their original order, unless pruning.
+	if !prune {
+		for _, details := range current {
+			if _, undeclared := currentById[details.ServerId]; undeclared {
+				servers = append(servers, details)
+			}
+		}
+	}
+	setDefaultServer(servers, declaredDefault)
+
+	changes := make([]ServerChange, 0, len(servers)+len(currentById))
+	for _, details := range servers {
+		isDefault, exists := wasDefault[details.ServerId]
+		fields := updatedFields[details.ServerId]
+		if exists && isDefault != details.IsDefault {
+			fields = append(fields, "default")
+		}
+		switch {
+		case !exists:
+			changes = append(changes, ServerChange{ServerId: details.ServerId, Type: ServerAdded})
+		case len(fields) > 0:
+			changes = append(changes, ServerChange{ServerId: details.ServerId, Type: ServerUpdated, Fields: fields})
+		default:
+			changes = append(changes, ServerChange{ServerId: details.ServerId, Type: ServerUnchanged})
+		}
+	}
+	if prune {
+		for _, details := range current {
+			if _, undeclared := currentById[details.ServerId]; undeclared {
+				changes = append(changes, ServerChange{ServerId: details.ServerId, Type: ServerRemoved})
+			}
+		}
+	}
+	return servers, changes, nil
+}
+
+// Makes the declared server the default. If no server is declared as the default, the current default is kept.
+// If no server is the default, the first server becomes the default.
+func setDefaultServer(servers []*config.ServerDetails, declaredDefault string) {
+	if declaredDefault != "" {
+		for _, details := range servers {
+			details.IsDefault = details.ServerId == declaredDefault
+		}
+		return
+	}
+	for _, details := range servers {
+		if details.IsDefault {
+			return
+		}
+	}
+	if len(servers) > 0 {
+		servers[0].IsDefault = true
+	}
+}
+
+// Returns the names of the fields managed by the spec, which differ between the current and the desired server.
+// Credentials which aren't declared by the spec, such as tokens created for basic authentication, are ignored.
+func getChangedServerFields(current, desired *config.ServerDetails, authSpec *AuthSpec) []string {
+	authMethod := authSpec.Method
+	type managedField struct {
+		name            string
+		current, wanted string
+	}
+	fields := []managedField{
+		{"url", current.Url, desired.Url},
+		{"artifactoryUrl", current.ArtifactoryUrl, desired.ArtifactoryUrl},
+		{"distributionUrl", current.DistributionUrl, desired.DistributionUrl},
+		{"xrayUrl", current.XrayUrl, desired.XrayUrl},
+		{"missionControlUrl", current.MissionControlUrl, desired.MissionControlUrl},
+		{"pipelinesUrl", current.PipelinesUrl, desired.PipelinesUrl},
+		{"accessUrl", current.AccessUrl, desired.AccessUrl},
+		{"clientCertPath", current.ClientCertPath, desired.ClientCertPath},
+		{"clientCertKeyPath", current.ClientCertKeyPath, desired.ClientCertKeyPath},
+		{"disableTokenRefresh", fmt.Sprint(current.DisableTokenRefresh), fmt.Sprint(desired.DisableTokenRefresh)},
+		{"proxy", current.Proxy, desired.Proxy},
+		{"caBundlePath", current.CaBundlePath, desired.CaBundlePath},
+		{"dialTimeoutSecs", fmt.Sprint(current.DialTimeoutSecs), fmt.Sprint(desired.DialTimeoutSecs)},
+		{"requestTimeoutSecs", fmt.Sprint(current.RequestTimeoutSecs), fmt.Sprint(desired.RequestTimeoutSecs)},
+		{"httpRetries", formatHttpRetries(current.HttpRetries), formatHttpRetries(desired.HttpRetries)},
+		{"httpRetryWaitMilliSecs", fmt.Sprint(current.HttpRetryWaitMilliSecs), fmt.Sprint(desired.HttpRetryWaitMilliSecs)},
+		{"authMethod", getCurrentAuthMethod(current), authMethod},
+	}
+	switch authMethod {
+	case AuthMethodBasic:
+		fields = append(fields, managedField{"user", current.User, desired.User}, managedField{"password", current.Password, desired.Password})
+	case AuthMethodAccessToken:
+		fields = append(fields, managedField{"accessToken", current.AccessToken, desired.AccessToken})
+	case AuthMethodSsh:
+		fields = append(fields, managedField{"sshKeyPath", current.SshKeyPath, desired.SshKeyPath}, managedField{"sshPassphrase", current.SshPassphrase, desired.SshPassphrase})
+	case AuthMethodMtls:
+		fields = append(fields, managedField{"clientCertKeyPassphrase", current.ClientCertKeyPassphrase, desired.ClientCertKeyPassphrase})
+	case AuthMethodOidc:
+		currentOidc := config.OidcExchangeParams{}
+		if current.OidcExchangeParams != nil {
+			currentOidc = *current.OidcExchangeParams
+		}
+		fields = append(fields,
+			managedField{"oidc.providerName", currentOidc.ProviderName, authSpec.Oidc.ProviderName},
+			managedField{"oidc.providerType", currentOidc.ProviderType, authSpec.Oidc.ProviderType},
+			managedField{"oidc.audience", currentOidc.Audience, authSpec.Oidc.Audience},
+			managedField{"oidc.projectKey", currentOidc.ProjectKey, authSpec.Oidc.ProjectKey},
+			managedField{"oidc.applicationKey", currentOidc.ApplicationKey, authSpec.Oidc.ApplicationKey})
+	}
+	var changed []string
+	for _, field := range fields {
+		if field.current != field.wanted {
+			changed = append(changed, field.name)
+		}
+	}
+	if desired.ServiceCredentials != nil {
+		for _, service := range config.Services {
+			if !isSameServiceCredentials(current.GetServiceCredentials(service), desired.GetServiceCredentials(service)) {
+				changed = append(changed, "serviceCredentials."+service)
+			}
+		}
+	}
+	return changed
+}
+
+func isSameServiceCredentials(current, desired *config.ServiceCredentials) bool {
+	if current == nil || desired == nil {
+		return current == desired
+	}
+	return *current == *desired
+}
+
+// The fields which change the credentials of the server.
+var credentialFields = []string{"authMethod", "user", "password", "accessToken", "sshKeyPath", "sshPassphrase",
+	"oidc.providerName", "oidc.providerType", "oidc.audience", "oidc.projectKey", "oidc.applicationKey"}
+
+// Returns a copy of the current server, with the fields managed by the spec set to their declared values.
+// The fields which the spec doesn't manage, such as the refresh tokens and the undeclared service credentials, are kept.
+// The tokens and the token refresh settings are reset only if the credentials changed, since they were issued for the previous credentials.
+func mergeDeclaredServerFields(current, desired *config.ServerDetails, changedFields []string) *config.ServerDetails {
+	merged := *current
+	merged.Url = desired.Url
+	merged.ArtifactoryUrl = desired.ArtifactoryUrl
+	merged.DistributionUrl = desired.DistributionUrl
+	merged.XrayUrl = desired.XrayUrl
+	merged.MissionControlUrl = desired.MissionControlUrl
+	merged.PipelinesUrl = desired.PipelinesUrl
+	merged.AccessUrl = desired.AccessUrl
+	merged.DisableTokenRefresh = desired.DisableTokenRefresh
+	merged.ClientCertPath = desired.ClientCertPath
+	merged.ClientCertKeyPath = desired.ClientCertKeyPath
+	merged.ClientCertKeyPassphrase = desired.ClientCertKeyPassphrase
+	merged.Proxy = desired.Proxy
+	merged.CaBundlePath = desired.CaBundlePath
+	merged.DialTimeoutSecs = desired.DialTimeoutSecs
+	merged.RequestTimeoutSecs = desired.RequestTimeoutSecs
+	merged.HttpRetries = desired.HttpRetries
+	merged.HttpRetryWaitMilliSecs = desired.HttpRetryWaitMilliSecs
+	if desired.ServiceCredentials != nil {
+		merged.ServiceCredentials = desired.ServiceCredentials
+	}
+	for _, field := range changedFields {
+		if !slices.Contains(credentialFields, field) {
+			continue
+		}
+		merged.User = desired.User
+		merged.Password = desired.Password
+		merged.AccessToken = desired.AccessToken
+		merged.SshKeyPath = desired.SshKeyPath
+		merged.SshPassphrase = desired.SshPassphrase
+		merged.RefreshToken = ""
+		merged.ArtifactoryRefreshToken = ""
+		merged.ArtifactoryTokenRefreshInterval = desired.ArtifactoryTokenRefreshInterval
+		merged.WebLogin = false
+		merged.OidcExchangeParams = nil
+		break
+	}
+	return &merged
+}
+
+func formatHttpRetries(retries *int) string {
+	if retries == nil {
+		return ""
+	}
+	return fmt.Sprint(*retries)
+}
+
+// Deduces the auth method of a configured server.
+// The access token of an OIDC server is distinguished from any other access token by the OIDC exchange params, saved when the token was exchanged.
+func getCurrentAuthMethod(details *config.ServerDetails) string {
+	switch {
+	case details.SshKeyPath != "":
+		return AuthMethodSsh
+	case details.User != "" && details.Password != "":
+		return AuthMethodBasic
+	case details.AccessToken != "" && details.OidcExchangeParams != nil:
+		return AuthMethodOidc
+	case details.AccessToken != "":
+		return AuthMethodAccessToken
+	case details.ClientCertPath != "":
+		return AuthMethodMtls
+	default:
+		return ""
+	}
+}
+
+func hasServerChanges(changes []ServerChange) bool {
+	for _, change := range changes {
+		if change.Type != ServerUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+func printServerChanges(changes []ServerChange, dryRun bool) {
+	if dryRun {
+		log.Output("Dry run - the config is not modified. The following changes would be applied:")
+	}
+	for _, change := range changes {
+		switch change.Type {
+		case ServerAdded:
+			log.Output("+ " + change.ServerId)
+		case ServerUpdated:
+			log.Output(fmt.Sprintf("~ %s (%s)", change.ServerId, strings.Join(change.Fields, ", ")))
+		case ServerRemoved:
+			log.Output("- " + change.ServerId)
+		default:
+			log.Output("  " + change.ServerId + " (unchanged)")
+		}
+	}
+}
+
+// Exchanges the OIDC tokens of the added and updated servers, which use the OIDC auth method.
+func exchangeOidcTokens(spec *ServersSpec, changes []ServerChange, servers []*config.ServerDetails) error {
+	changed := make(map[string]bool)
+	for _, change := range changes {
+		if change.Type == ServerAdded || change.Type == ServerUpdated {
+			changed[change.ServerId] = true
+		}
+	}
+	for _, server := range spec.Servers {
+		if server.Auth.Method != AuthMethodOidc || !changed[server.ServerId] {
+			continue
+		}
+		var details *config.ServerDetails
+		for _, serverDetails := range servers {
+			if serverDetails.ServerId == server.ServerId {
+				details = serverDetails
+			}
+		}
+		providerType, err := generic.OidcProviderTypeFromString(server.Auth.Oidc.ProviderType)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		cc := &ConfigCommand{
+			details: details,
+			oidcSetupParams: &generic.OidcParams{
+				ProviderName:   server.Auth.Oidc.ProviderName,
+				ProviderType:   providerType,
+				TokenId:        server.Auth.Oidc.TokenId,
+				Audience:       server.Auth.Oidc.Audience,
+				ProjectKey:     server.Auth.Oidc.ProjectKey,
+				ApplicationKey: server.Auth.Oidc.ApplicationKey,
+			},
+		}
+		if err = exchangeOidcTokenAndSetAccessToken(cc); err != nil {
+			return err
+		}
+		cc.tryExtractingUsernameFromAccessToken()
+	}
+	return nil
+}
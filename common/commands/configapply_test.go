@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	utilsTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testServersSpec = `version: 1
+servers:
+  - serverId: prod
+    url: https://prod.jfrog.io
+    default: true
+    auth:
+      accessToken: ${TEST_CONFIG_APPLY_TOKEN}
+    http:
+      proxy: http://proxy.example.com:8080
+      retries: 0
+  - serverId: dev
+    url: https://dev.jfrog.io
+    auth:
+      user: Admin
+      password: password
+`
+
+func writeServersSpec(t *testing.T, content string) string {
+	specPath := filepath.Join(t.TempDir(), "servers.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(content), 0600))
+	return specPath
+}
+
+func getServerChangesByServerId(changes []ServerChange) map[string]ServerChange {
+	changesById := make(map[string]ServerChange, len(changes))
+	for _, change := range changes {
+		changesById[change.ServerId] = change
+	}
+	return changesById
+}
+
+func TestConfigApply(t *testing.T) {
+	cleanUpJfrogHome, err := utilsTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	t.Setenv("TEST_CONFIG_APPLY_TOKEN", "prod-token")
+
+	doConfig(t, "legacy", &config.ServerDetails{Url: "https://legacy.jfrog.io", AccessToken: "legacy-token"}, false, false, true)
+	specPath := writeServersSpec(t, testServersSpec)
+
+	// Add the declared servers, and keep the undeclared server.
+	applyCmd := NewConfigApplyCommand().SetFilePath(specPath)
+	require.NoError(t, applyCmd.Run())
+	changes := getServerChangesByServerId(applyCmd.Changes())
+	assert.Equal(t, ServerAdded, changes["prod"].Type)
+	assert.Equal(t, ServerAdded, changes["dev"].Type)
+	assert.Equal(t, ServerUpdated, changes["legacy"].Type)
+	assert.Equal(t, []string{"default"}, changes["legacy"].Fields)
+
+	prod, err := config.GetSpecificConfig("prod", false, false)
+	require.NoError(t, err)
+	assert.True(t, prod.IsDefault)
+	assert.Equal(t, "prod-token", prod.AccessToken)
+	assert.Equal(t, "https://prod.jfrog.io/artifactory/", prod.ArtifactoryUrl)
+	assert.Equal(t, "http://proxy.example.com:8080", prod.Proxy)
+	require.NotNil(t, prod.HttpRetries)
+	assert.Zero(t, *prod.HttpRetries)
+	dev, err := config.GetSpecificConfig("dev", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", dev.User)
+	_, err = config.GetSpecificConfig("legacy", false, false)
+	assert.NoError(t, err)
+
+	// Tokens created for a server with basic authentication are kept, since they aren't managed by the spec.
+	dev.AccessToken = "generated-token"
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{prod, dev}))
+	applyCmd = NewConfigApplyCommand().SetFilePath(specPath)
+	require.NoError(t, applyCmd.Run())
+	for _, change := range applyCmd.Changes() {
+		assert.Equal(t, ServerUnchanged, change.Type, change.ServerId)
+	}
+	dev, err = config.GetSpecificConfig("dev", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "generated-token", dev.AccessToken)
+
+	// Update a changed server.
+	t.Setenv("TEST_CONFIG_APPLY_TOKEN", "new-prod-token")
+	applyCmd = NewConfigApplyCommand().SetFilePath(specPath)
+	require.NoError(t, applyCmd.Run())
+	changes = getServerChangesByServerId(applyCmd.Changes())
+	assert.Equal(t, ServerUpdated, changes["prod"].Type)
+	assert.Equal(t, []string{"accessToken"}, changes["prod"].Fields)
+	prod, err = config.GetSpecificConfig("prod", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "new-prod-token", prod.AccessToken)
+}
+
+func TestConfigApplyKeepsUnmanagedFields(t *testing.T) {
+	cleanUpJfrogHome, err := utilsTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	t.Setenv("TEST_CONFIG_APPLY_TOKEN", "prod-token")
+	specPath := writeServersSpec(t, testServersSpec)
+	require.NoError(t, NewConfigApplyCommand().SetFilePath(specPath).Run())
+
+	// Tokens refreshed for the server, and fields which aren't in the spec.
+	prod, err := config.GetSpecificConfig("prod", false, false)
+	require.NoError(t, err)
+	prod.RefreshToken = "refresh-token"
+	prod.ServiceCredentials = map[string]*config.ServiceCredentials{config.XrayService: {AccessToken: "xray-token"}}
+	require.NoError(t, config.SaveServersConf([]*config.ServerDetails{prod}))
+
+	// Updating fields which aren't credentials keeps the other fields.
+	specPath = writeServersSpec(t, strings.Replace(testServersSpec, "http://proxy.example.com:8080", "http://new-proxy.example.com:8080", 1))
+	applyCmd := NewConfigApplyCommand().SetFilePath(specPath)
+	require.NoError(t, applyCmd.Run())
+	assert.Equal(t, []string{"proxy"}, getServerChangesByServerId(applyCmd.Changes())["prod"].Fields)
+	prod, err = config.GetSpecificConfig("prod", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "http://new-proxy.example.com:8080", prod.Proxy)
+	assert.Equal(t, "refresh-token", prod.RefreshToken)
+	require.Contains(t, prod.ServiceCredentials, config.XrayService)
+	assert.True(t, prod.IsDefault)
+
+	// Changing the credentials resets the refresh token, which was issued for the previous credentials.
+	t.Setenv("TEST_CONFIG_APPLY_TOKEN", "new-prod-token")
+	require.NoError(t, NewConfigApplyCommand().SetFilePath(specPath).Run())
+	prod, err = config.GetSpecificConfig("prod", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "new-prod-token", prod.AccessToken)
+	assert.Empty(t, prod.RefreshToken)
+	assert.Contains(t, prod.ServiceCredentials, config.XrayService)
+}
+
+func TestConfigApplyServiceCredentials(t *testing.T) {
+	cleanUpJfrogHome, err := utilsTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	t.Setenv("TEST_CONFIG_APPLY_TOKEN", "prod-token")
+	t.Setenv("TEST_CONFIG_APPLY_XRAY_TOKEN", "xray-token")
+	serviceCredentialsSpec := testServersSpec + "    serviceCredentials:\n      xray:\n        accessToken: ${TEST_CONFIG_APPLY_XRAY_TOKEN}\n"
+	require.NoError(t, NewConfigApplyCommand().SetFilePath(writeServersSpec(t, serviceCredentialsSpec)).Run())
+	dev, err := config.GetSpecificConfig("dev", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, &config.ServiceCredentials{AccessToken: "xray-token"}, dev.GetServiceCredentials(config.XrayService))
+	assert.Equal(t, "password", dev.Password)
+
+	// Changing the service credentials doesn't change the server credentials.
+	t.Setenv("TEST_CONFIG_APPLY_XRAY_TOKEN", "new-xray-token")
+	applyCmd := NewConfigApplyCommand().SetFilePath(writeServersSpec(t, serviceCredentialsSpec))
+	require.NoError(t, applyCmd.Run())
+	assert.Equal(t, []string{"serviceCredentials.xray"}, getServerChangesByServerId(applyCmd.Changes())["dev"].Fields)
+	dev, err = config.GetSpecificConfig("dev", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "new-xray-token", dev.GetServiceCredentials(config.XrayService).AccessToken)
+
+	// Undeclared service credentials are kept, and declaring no service credentials removes them.
+	applyCmd = NewConfigApplyCommand().SetFilePath(writeServersSpec(t, testServersSpec))
+	require.NoError(t, applyCmd.Run())
+	assert.Equal(t, ServerUnchanged, getServerChangesByServerId(applyCmd.Changes())["dev"].Type)
+	require.NoError(t, NewConfigApplyCommand().SetFilePath(writeServersSpec(t, testServersSpec+"    serviceCredentials: {}\n")).Run())
+	dev, err = config.GetSpecificConfig("dev", false, false)
+	require.NoError(t, err)
+	assert.Nil(t, dev.GetServiceCredentials(config.XrayService))
+}
+
+func TestConfigApplyPrune(t *testing.T) {
+	cleanUpJfrogHome, err := utilsTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUpJfrogHome()
+	t.Setenv("TEST_CONFIG_APPLY_TOKEN", "prod-token")
+
+	doConfig(t, "legacy", &config.ServerDetails{Url: "https://legacy.jfrog.io", AccessToken: "legacy-token"}, false, false, true)
+	specPath := writeServersSpec(t, testServersSpec)
+
+	// A dry run doesn't modify the config.
+	applyCmd := NewConfigApplyCommand().SetFilePath(specPath).SetPrune(true).SetDryRun(true)
+	require.NoError(t, applyCmd.Run())
+	assert.Equal(t, ServerRemoved, getServerChangesByServerId(applyCmd.Changes())["legacy"].Type)
+	assert.Equal(t, []string{"legacy"}, GetAllServerIds())
+
+	applyCmd = NewConfigApplyCommand().SetFilePath(specPath).SetPrune(true)
+	require.NoError(t, applyCmd.Run())
+	assert.ElementsMatch(t, []string{"prod", "dev"}, GetAllServerIds())
+}
+
+func TestReadServersSpecValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          string
+		expectedError string
+	}{
+		{"unknownField", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    urll: https://b.jfrog.io\n", "field urll not found"},
+		{"unsupportedVersion", "version: 2\nservers: []\n", "unsupported servers spec version"},
+		{"missingServerId", "servers:\n  - url: https://a.jfrog.io\n", "missing a 'serverId'"},
+		{"missingUrl", "servers:\n  - serverId: a\n", "must have either a 'url'"},
+		{"duplicateServerId", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n  - serverId: a\n    url: https://b.jfrog.io\n", "declared more than once"},
+		{"multipleDefaults", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    default: true\n  - serverId: b\n    url: https://b.jfrog.io\n    default: true\n", "only one server"},
+		{"unsupportedMethod", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    auth:\n      method: kerberos\n", "unsupported auth method"},
+		{"multipleMethods", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    auth:\n      accessToken: token\n      password: password\n", "single auth method"},
+		{"methodMismatch", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    auth:\n      method: ssh\n      accessToken: token\n", "single auth method"},
+		{"incompleteOidc", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    auth:\n      oidc:\n        tokenId: token\n", "'oidc.providerName' field"},
+		{"unsupportedOidcProviderType", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    auth:\n      oidc:\n        providerName: provider\n        providerType: unknown\n", "unsupported oidc provider type"},
+		{"userWithoutPassword", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    auth:\n      user: admin\n", "must have a 'password'"},
+		{"unsupportedService", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    serviceCredentials:\n      unknown:\n        accessToken: token\n", "unsupported service 'unknown'"},
+		{"incompleteServiceCredentials", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    serviceCredentials:\n      xray:\n        user: admin\n", "must have either an 'accessToken'"},
+		{"basicWithUnsetPasswordEnv", "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    auth:\n      method: basic\n      user: admin\n      password: ${TEST_CONFIG_APPLY_UNSET_PASSWORD}\n", "must have a 'password'"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := ReadServersSpec(writeServersSpec(t, testCase.spec))
+			assert.ErrorContains(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestGetChangedServerFieldsOidc(t *testing.T) {
+	spec, err := ReadServersSpec(writeServersSpec(t, "servers:\n  - serverId: a\n    url: https://a.jfrog.io\n    auth:\n      oidc:\n        providerName: provider\n        audience: audience\n"))
+	require.NoError(t, err)
+	server := spec.Servers[0]
+	desired := server.toServerDetails()
+
+	// A server whose token was exchanged with the same params is unchanged.
+	current := *desired
+	current.AccessToken = "exchanged-token"
+	current.OidcExchangeParams = &config.OidcExchangeParams{ProviderName: "provider", ProviderType: "GitHub", Audience: "audience"}
+	assert.Empty(t, getChangedServerFields(&current, desired, &server.Auth))
+
+	// Changing the exchange params requires a new token.
+	current.OidcExchangeParams = &config.OidcExchangeParams{ProviderName: "provider", ProviderType: "GitHub", Audience: "other-audience", ProjectKey: "proj"}
+	assert.Equal(t, []string{"oidc.audience", "oidc.projectKey"}, getChangedServerFields(&current, desired, &server.Auth))
+
+	// An access token which wasn't exchanged with OIDC isn't an OIDC token.
+	current.OidcExchangeParams = nil
+	assert.Contains(t, getChangedServerFields(&current, desired, &server.Auth), "authMethod")
+}
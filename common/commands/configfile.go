@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
+	"golang.org/x/exp/slices"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"gopkg.in/yaml.v3"
@@ -55,9 +57,45 @@ const (
 	// Nuget flags
 	nugetV2 = "nuget-v2"
 
+	// Conan flags
+	resolutionRemoteName = "remote-name-resolve"
+	deploymentRemoteName = "remote-name-deploy"
+
+	// Helm flags
+	resolutionOci = "oci-resolve"
+	deploymentOci = "oci-deploy"
+
+	// Apt and Apk flags
+	distribution = "distribution"
+	component    = "component"
+	architecture = "architecture"
+	branch       = "branch"
+
+	// Poetry flags
+	sourceName = "source-name"
+
+	// UV flags
+	resolutionIndexName = "index-name-resolve"
+	deploymentIndexName = "index-name-deploy"
+
+	// CocoaPods flags
+	specsRepoName = "specs-repo-name"
+
+	// Swift flags
+	resolutionScope = "scope-resolve"
+	deploymentScope = "scope-deploy"
+
+	// Terraform flags
+	namespace = "namespace"
+	provider  = "provider"
+
 	// Default values
 	defaultIvyDescPattern      = "[organization]/[module]/ivy-[revision].xml"
 	defaultIvyArtifactsPattern = "[organization]/[module]/[revision]/[artifact]-[revision](-[classifier]).[ext]"
+	defaultAptComponent        = "main"
+	defaultAptArchitecture     = "amd64"
+	defaultApkBranch           = "edge"
+	defaultApkArchitecture     = "x86_64"
 
 	// Errors
 	resolutionErrorPrefix      = "[Resolution]: "
@@ -65,6 +103,30 @@ const (
 	setServerIdError           = "server ID must be set. Use the --server-id-resolve/deploy flag or configure a default server using 'jfrog c add' and 'jfrog c use' commands. "
 	setRepositoryError         = "repository/ies must be set. "
 	setSnapshotAndReleaseError = "snapshot and release repositories must be set. "
+	invalidConanRemoteError    = "invalid Conan remote name '%s'. The name may contain only letters, digits, '_', '.' and '-'. "
+	setAptDeploymentError      = "distribution, component and architecture must be set for deploying Debian packages. "
+	setApkDeploymentError      = "branch and architecture must be set for deploying Alpine packages. "
+	invalidApkBranchError      = "invalid Alpine branch '%s'. The branch should be 'edge', or a version such as 'v3.20'. "
+
+	// Type-specific errors
+	invalidAptArchitectureError = "unsupported Debian architecture '%s'. The supported architectures are: %s. "
+	invalidApkArchitectureError = "unsupported Alpine architecture '%s'. The supported architectures are: %s. "
+	invalidPoetrySourceError    = "invalid Poetry source name '%s'. The name may contain only letters, digits, '_', '.' and '-'. "
+	invalidUvIndexError         = "invalid UV index name '%s'. The name may contain only letters, digits, '_', '.' and '-'. "
+	invalidSpecsRepoNameError   = "invalid CocoaPods specs repository name '%s'. The name may contain only letters, digits, '_', '.' and '-'. "
+	invalidSwiftScopeError      = "invalid Swift scope '%s'. The scope may contain only letters, digits and '-', and may be up to 39 characters long. "
+	invalidTerraformNameError   = "invalid Terraform %s '%s'. The %s may contain only letters, digits, '_' and '-'. "
+	setTerraformDeploymentError = "namespace and provider must be set together for deploying Terraform modules. "
+	unsupportedResolverError    = "%s projects don't resolve dependencies from Artifactory. "
+	unsupportedDeployerError    = "%s projects don't deploy artifacts to Artifactory. "
+)
+
+var (
+	conanRemoteNameRegexp = regexp.MustCompile(project.ConanRemoteNamePattern)
+	apkBranchRegexp       = regexp.MustCompile(project.ApkBranchPattern)
+	repoNameRegexp        = regexp.MustCompile(project.PackageManagerRepoNamePattern)
+	swiftScopeRegexp      = regexp.MustCompile(project.SwiftScopePattern)
+	terraformNameRegexp   = regexp.MustCompile(project.TerraformNamePattern)
 )
 
 type ConfigFile struct {
@@ -117,6 +179,24 @@ func NewConfigFile(confType project.ProjectType, c *cli.Context) *ConfigFile {
 		configFile.populateGradleConfigFromFlags(c)
 	case project.Nuget, project.Dotnet:
 		configFile.populateNugetConfigFromFlags(c)
+	case project.Conan:
+		configFile.populateConanConfigFromFlags(c)
+	case project.Helm:
+		configFile.populateHelmConfigFromFlags(c)
+	case project.Apt:
+		configFile.populateAptConfigFromFlags(c)
+	case project.Apk:
+		configFile.populateApkConfigFromFlags(c)
+	case project.Poetry:
+		configFile.populatePoetryConfigFromFlags(c)
+	case project.UV:
+		configFile.populateUvConfigFromFlags(c)
+	case project.Cocoapods:
+		configFile.populateCocoapodsConfigFromFlags(c)
+	case project.Swift:
+		configFile.populateSwiftConfigFromFlags(c)
+	case project.Terraform:
+		configFile.populateTerraformConfigFromFlags(c)
 	}
 	return configFile
 }
@@ -135,20 +215,36 @@ func createBuildConfig(global bool, confType project.ProjectType, configFile *Co
 	if err != nil {
 		return err
 	}
-	if err = fileutils.CreateDirIfNotExist(projectDir); err != nil {
-		return err
+	// If the project has a unified project config file, the technology's section is written to it, instead of to a per-type file.
+	unifiedConfigFilePath := filepath.Join(filepath.Dir(projectDir), project.UnifiedProjectConfigFileName)
+	useUnifiedConfig := false
+	if !global {
+		if useUnifiedConfig, err = fileutils.IsFileExists(unifiedConfigFilePath, false); err != nil {
+			return err
+		}
 	}
-	// Populate, validate and write the config file
 	configFilePath := filepath.Join(projectDir, confType.String()+".yaml")
-	if err = configFile.VerifyConfigFile(configFilePath); err != nil {
-		return err
+	if !useUnifiedConfig {
+		if err = fileutils.CreateDirIfNotExist(projectDir); err != nil {
+			return err
+		}
+		if err = configFile.VerifyConfigFile(configFilePath); err != nil {
+			return err
+		}
 	}
+	// Populate, validate and write the config file
 	if err = handleInteractiveConfigCreation(configFile, confType); err != nil {
 		return err
 	}
+	if err = configFile.setReposFromActiveContext(); err != nil {
+		return err
+	}
 	if err = configFile.validateConfig(); err != nil {
 		return err
 	}
+	if useUnifiedConfig {
+		return writeUnifiedConfigSection(configFile, confType, unifiedConfigFilePath)
+	}
 	return writeConfigFile(configFile, configFilePath)
 }
 
@@ -160,13 +256,13 @@ func handleInteractiveConfigCreation(configFile *ConfigFile, confType project.Pr
 	case project.Ruby:
 		return configFile.setDeployerResolver()
 	case project.Conan:
-		return configFile.setDeployerResolver()
+		return configFile.configConan()
 	case project.Go:
 		return configFile.setDeployerResolver()
 	case project.Pip, project.Pipenv:
 		return configFile.setDeployerResolver()
 	case project.Poetry:
-		return configFile.setResolver(false)
+		return configFile.configPoetry()
 	case project.Yarn:
 		return configFile.setResolver(false)
 	case project.Npm:
@@ -178,11 +274,23 @@ func handleInteractiveConfigCreation(configFile *ConfigFile, confType project.Pr
 	case project.Gradle:
 		return configFile.configGradle()
 	case project.Terraform:
-		return configFile.setDeployer(false)
+		return configFile.configTerraform()
 	case project.Cocoapods:
-		return configFile.setDeployerResolver()
+		return configFile.configCocoapods()
 	case project.Swift:
+		return configFile.configSwift()
+	case project.UV:
+		return configFile.configUv()
+	case project.Pnpm, project.Docker, project.Podman:
 		return configFile.setDeployerResolver()
+	case project.Twine:
+		return configFile.setDeployer(false)
+	case project.Helm:
+		return configFile.configHelm()
+	case project.Apt:
+		return configFile.configApt()
+	case project.Apk:
+		return configFile.configApk()
 	}
 	return
 }
@@ -199,6 +307,27 @@ func writeConfigFile(configFile *ConfigFile, destination string) (err error) {
 	return
 }
 
+func writeUnifiedConfigSection(configFile *ConfigFile, confType project.ProjectType, unifiedConfigFilePath string) error {
+	unifiedConfig, err := project.ReadUnifiedProjectConfig(unifiedConfigFilePath)
+	if err != nil {
+		return err
+	}
+	resBytes, err := yaml.Marshal(&configFile)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var section map[string]any
+	if err = yaml.Unmarshal(resBytes, &section); err != nil {
+		return errorutils.CheckError(err)
+	}
+	unifiedConfig.SetTechnology(confType, section)
+	if err = project.WriteUnifiedProjectConfig(unifiedConfigFilePath, unifiedConfig); err != nil {
+		return err
+	}
+	log.Info(configFile.ConfigType + " build config successfully written to " + unifiedConfigFilePath + ".")
+	return nil
+}
+
 func isCI() bool {
 	return strings.ToLower(os.Getenv(coreutils.CI)) == "true"
 }
@@ -378,6 +507,191 @@ func WithResolverNugetV2(nugetV2 bool) ConfigOption {
 	}
 }
 
+// Populate Conan related configuration from cli flags
+func (configFile *ConfigFile) populateConanConfigFromFlags(c *cli.Context) {
+	configFile.Resolver.RemoteName = c.String(resolutionRemoteName)
+	configFile.Deployer.RemoteName = c.String(deploymentRemoteName)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, resolutionRemoteName, deploymentRemoteName)
+}
+
+func WithResolverConanRemoteName(remoteName string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Resolver.RemoteName = remoteName
+		c.Interactive = false
+	}
+}
+
+func WithDeployerConanRemoteName(remoteName string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.RemoteName = remoteName
+		c.Interactive = false
+	}
+}
+
+// Populate Helm related configuration from cli flags
+func (configFile *ConfigFile) populateHelmConfigFromFlags(c *cli.Context) {
+	configFile.Resolver.Oci = c.Bool(resolutionOci)
+	configFile.Deployer.Oci = c.Bool(deploymentOci)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, resolutionOci, deploymentOci)
+}
+
+// Set whether the Helm resolution repository is an OCI repository, rather than a classic Helm repository.
+func WithResolverHelmOci(oci bool) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Resolver.Oci = oci
+		c.Interactive = false
+	}
+}
+
+// Set whether the Helm deployment repository is an OCI repository, rather than a classic Helm repository.
+func WithDeployerHelmOci(oci bool) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.Oci = oci
+		c.Interactive = false
+	}
+}
+
+// Populate Apt related configuration from cli flags
+func (configFile *ConfigFile) populateAptConfigFromFlags(c *cli.Context) {
+	configFile.Deployer.Distribution = c.String(distribution)
+	configFile.Deployer.Component = c.String(component)
+	configFile.Deployer.Architecture = c.String(architecture)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, distribution, component, architecture)
+}
+
+func WithAptDeploymentDistribution(distribution string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.Distribution = distribution
+		c.Interactive = false
+	}
+}
+
+func WithAptDeploymentComponent(component string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.Component = component
+		c.Interactive = false
+	}
+}
+
+// Set the Debian architecture of the deployed packages, such as 'amd64'.
+func WithAptDeploymentArchitecture(architecture string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.Architecture = architecture
+		c.Interactive = false
+	}
+}
+
+// Populate Apk related configuration from cli flags
+func (configFile *ConfigFile) populateApkConfigFromFlags(c *cli.Context) {
+	configFile.Deployer.Branch = c.String(branch)
+	configFile.Deployer.Architecture = c.String(architecture)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, branch, architecture)
+}
+
+func WithApkDeploymentBranch(branch string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.Branch = branch
+		c.Interactive = false
+	}
+}
+
+// Set the Alpine architecture of the deployed packages, such as 'x86_64'.
+func WithApkDeploymentArchitecture(architecture string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.Architecture = architecture
+		c.Interactive = false
+	}
+}
+
+// Populate Poetry related configuration from cli flags
+func (configFile *ConfigFile) populatePoetryConfigFromFlags(c *cli.Context) {
+	configFile.Resolver.SourceName = c.String(sourceName)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, sourceName)
+}
+
+func WithResolverPoetrySourceName(sourceName string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Resolver.SourceName = sourceName
+		c.Interactive = false
+	}
+}
+
+// Populate UV related configuration from cli flags
+func (configFile *ConfigFile) populateUvConfigFromFlags(c *cli.Context) {
+	configFile.Resolver.IndexName = c.String(resolutionIndexName)
+	configFile.Deployer.IndexName = c.String(deploymentIndexName)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, resolutionIndexName, deploymentIndexName)
+}
+
+func WithResolverUvIndexName(indexName string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Resolver.IndexName = indexName
+		c.Interactive = false
+	}
+}
+
+func WithDeployerUvIndexName(indexName string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.IndexName = indexName
+		c.Interactive = false
+	}
+}
+
+// Populate CocoaPods related configuration from cli flags
+func (configFile *ConfigFile) populateCocoapodsConfigFromFlags(c *cli.Context) {
+	configFile.Resolver.SpecsRepoName = c.String(specsRepoName)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, specsRepoName)
+}
+
+func WithResolverCocoapodsSpecsRepoName(specsRepoName string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Resolver.SpecsRepoName = specsRepoName
+		c.Interactive = false
+	}
+}
+
+// Populate Swift related configuration from cli flags
+func (configFile *ConfigFile) populateSwiftConfigFromFlags(c *cli.Context) {
+	configFile.Resolver.Scope = c.String(resolutionScope)
+	configFile.Deployer.Scope = c.String(deploymentScope)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, resolutionScope, deploymentScope)
+}
+
+func WithResolverSwiftScope(scope string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Resolver.Scope = scope
+		c.Interactive = false
+	}
+}
+
+func WithDeployerSwiftScope(scope string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.Scope = scope
+		c.Interactive = false
+	}
+}
+
+// Populate Terraform related configuration from cli flags
+func (configFile *ConfigFile) populateTerraformConfigFromFlags(c *cli.Context) {
+	configFile.Deployer.Namespace = c.String(namespace)
+	configFile.Deployer.Provider = c.String(provider)
+	configFile.Interactive = configFile.Interactive && !isAnyFlagSet(c, namespace, provider)
+}
+
+func WithTerraformDeploymentNamespace(namespace string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.Namespace = namespace
+		c.Interactive = false
+	}
+}
+
+func WithTerraformDeploymentProvider(provider string) ConfigOption {
+	return func(c *ConfigFile) {
+		c.Deployer.Provider = provider
+		c.Interactive = false
+	}
+}
+
 // Verify config file doesn't exist or prompt to override it
 func (configFile *ConfigFile) VerifyConfigFile(configFilePath string) error {
 	exists, err := fileutils.IsFileExists(configFilePath, false)
@@ -418,6 +732,114 @@ func (configFile *ConfigFile) configDotnet() error {
 	return nil
 }
 
+func (configFile *ConfigFile) configConan() error {
+	if err := configFile.setDeployerResolver(); err != nil {
+		return err
+	}
+	if configFile.Resolver.Repo != "" {
+		configFile.Resolver.RemoteName = ioutils.AskStringWithDefault("", "Set the Conan remote name of the resolution repository", configFile.Resolver.Repo)
+	}
+	if configFile.Deployer.Repo != "" {
+		configFile.Deployer.RemoteName = ioutils.AskStringWithDefault("", "Set the Conan remote name of the deployment repository", configFile.Deployer.Repo)
+	}
+	return nil
+}
+
+func (configFile *ConfigFile) configHelm() error {
+	if err := configFile.setDeployerResolver(); err != nil {
+		return err
+	}
+	if configFile.Resolver.Repo != "" {
+		configFile.Resolver.Oci = coreutils.AskYesNo("Is the Helm resolution repository an OCI repository?", true)
+	}
+	if configFile.Deployer.Repo != "" {
+		configFile.Deployer.Oci = coreutils.AskYesNo("Is the Helm deployment repository an OCI repository?", true)
+	}
+	return nil
+}
+
+func (configFile *ConfigFile) configApt() error {
+	if err := configFile.setDeployerResolver(); err != nil {
+		return err
+	}
+	if configFile.Deployer.ServerId != "" {
+		configFile.Deployer.Distribution = ioutils.AskString("", "Set the distribution of the deployed packages, such as 'focal'", false, false)
+		configFile.Deployer.Component = ioutils.AskStringWithDefault("", "Set the component of the deployed packages", defaultAptComponent)
+		configFile.Deployer.Architecture = ioutils.AskStringWithDefault("", "Set the architecture of the deployed packages", defaultAptArchitecture)
+	}
+	return nil
+}
+
+func (configFile *ConfigFile) configApk() error {
+	if err := configFile.setDeployerResolver(); err != nil {
+		return err
+	}
+	if configFile.Deployer.ServerId != "" {
+		configFile.Deployer.Branch = ioutils.AskStringWithDefault("", "Set the Alpine branch of the deployed packages, such as 'v3.20'", defaultApkBranch)
+		configFile.Deployer.Architecture = ioutils.AskStringWithDefault("", "Set the architecture of the deployed packages", defaultApkArchitecture)
+	}
+	return nil
+}
+
+func (configFile *ConfigFile) configPoetry() error {
+	if err := configFile.setResolver(false); err != nil {
+		return err
+	}
+	if configFile.Resolver.Repo != "" {
+		configFile.Resolver.SourceName = ioutils.AskStringWithDefault("", "Set the Poetry source name of the resolution repository", configFile.Resolver.Repo)
+	}
+	return nil
+}
+
+func (configFile *ConfigFile) configUv() error {
+	if err := configFile.setDeployerResolver(); err != nil {
+		return err
+	}
+	if configFile.Resolver.Repo != "" {
+		configFile.Resolver.IndexName = ioutils.AskStringWithDefault("", "Set the UV index name of the resolution repository", configFile.Resolver.Repo)
+	}
+	if configFile.Deployer.Repo != "" {
+		configFile.Deployer.IndexName = ioutils.AskStringWithDefault("", "Set the UV index name of the deployment repository", configFile.Deployer.Repo)
+	}
+	return nil
+}
+
+func (configFile *ConfigFile) configCocoapods() error {
+	if err := configFile.setDeployerResolver(); err != nil {
+		return err
+	}
+	if configFile.Resolver.Repo != "" {
+		configFile.Resolver.SpecsRepoName = ioutils.AskStringWithDefault("", "Set the CocoaPods specs repository name of the resolution repository", configFile.Resolver.Repo)
+	}
+	return nil
+}
+
+func (configFile *ConfigFile) configSwift() error {
+	if err := configFile.setDeployerResolver(); err != nil {
+		return err
+	}
+	if configFile.Resolver.Repo != "" {
+		configFile.Resolver.Scope = ioutils.AskString("", "Set the scope of the packages resolved from the registry (leave empty for all the scopes)", true, false)
+	}
+	if configFile.Deployer.Repo != "" {
+		configFile.Deployer.Scope = ioutils.AskString("", "Set the scope of the deployed packages", true, false)
+	}
+	return nil
+}
+
+func (configFile *ConfigFile) configTerraform() error {
+	if err := configFile.setDeployer(false); err != nil {
+		return err
+	}
+	if configFile.Deployer.Repo != "" {
+		configFile.Deployer.Namespace = ioutils.AskString("", "Set the namespace of the deployed modules (leave empty to set it when publishing)", true, false)
+		if configFile.Deployer.Namespace != "" {
+			configFile.Deployer.Provider = ioutils.AskString("", "Set the provider of the deployed modules, such as 'aws'", false, false)
+		}
+	}
+	return nil
+}
+
 func (configFile *ConfigFile) configMaven() error {
 	if err := configFile.setDeployerResolverWithSnapshot(); err != nil {
 		return err
@@ -618,13 +1040,139 @@ func validateRepositoryConfig(repository *project.Repository, errorPrefix string
 	return nil
 }
 
+// In non-interactive mode, use the repositories of the active config context for the resolver and deployer,
+// if they were not configured otherwise.
+func (configFile *ConfigFile) setReposFromActiveContext() error {
+	if configFile.Interactive {
+		return nil
+	}
+	context, err := config.GetActiveContext()
+	if err != nil || context == nil {
+		return err
+	}
+	withSnapshot := configFile.ConfigType == project.Maven.String()
+	// Only the repositories supported by the technology are set.
+	hasResolver, hasDeployer := project.HasRepositories(project.FromString(configFile.ConfigType))
+	if hasResolver {
+		setRepoIfNotConfigured(&configFile.Resolver, context.ResolveRepo, withSnapshot)
+	}
+	if hasDeployer {
+		setRepoIfNotConfigured(&configFile.Deployer, context.DeployRepo, withSnapshot)
+	}
+	return nil
+}
+
+func setRepoIfNotConfigured(repository *project.Repository, repo string, withSnapshot bool) {
+	if repo == "" || repository.ServerId != "" || repository.Repo != "" || repository.ReleaseRepo != "" || repository.SnapshotRepo != "" {
+		return
+	}
+	if withSnapshot {
+		repository.ReleaseRepo = repo
+		repository.SnapshotRepo = repo
+		return
+	}
+	repository.Repo = repo
+}
+
 // Validate spec file configuration
 func (configFile *ConfigFile) validateConfig() error {
 	err := validateRepositoryConfig(&configFile.Resolver, resolutionErrorPrefix)
 	if err != nil {
 		return err
 	}
-	return validateRepositoryConfig(&configFile.Deployer, deploymentErrorPrefix)
+	if err = validateRepositoryConfig(&configFile.Deployer, deploymentErrorPrefix); err != nil {
+		return err
+	}
+	return configFile.validateTypeSpecificConfig()
+}
+
+// Validate the fields which are specific to the config type.
+func (configFile *ConfigFile) validateTypeSpecificConfig() error {
+	projectType := project.FromString(configFile.ConfigType)
+	if err := configFile.validateSupportedRepositories(projectType); err != nil {
+		return err
+	}
+	switch projectType {
+	case project.Conan:
+		if err := validatePattern(configFile.Resolver.RemoteName, conanRemoteNameRegexp, resolutionErrorPrefix+invalidConanRemoteError); err != nil {
+			return err
+		}
+		return validatePattern(configFile.Deployer.RemoteName, conanRemoteNameRegexp, deploymentErrorPrefix+invalidConanRemoteError)
+	case project.Apt:
+		if configFile.Deployer.Repo != "" && (configFile.Deployer.Distribution == "" || configFile.Deployer.Component == "" || configFile.Deployer.Architecture == "") {
+			return errors.New(deploymentErrorPrefix + setAptDeploymentError)
+		}
+		return validateArchitecture(configFile.Deployer.Architecture, project.AptArchitectures, invalidAptArchitectureError)
+	case project.Apk:
+		if configFile.Deployer.Repo != "" && (configFile.Deployer.Branch == "" || configFile.Deployer.Architecture == "") {
+			return errors.New(deploymentErrorPrefix + setApkDeploymentError)
+		}
+		if configFile.Deployer.Branch != "" && !apkBranchRegexp.MatchString(configFile.Deployer.Branch) {
+			return fmt.Errorf(deploymentErrorPrefix+invalidApkBranchError, configFile.Deployer.Branch)
+		}
+		return validateArchitecture(configFile.Deployer.Architecture, project.ApkArchitectures, invalidApkArchitectureError)
+	case project.Poetry:
+		return validatePattern(configFile.Resolver.SourceName, repoNameRegexp, resolutionErrorPrefix+invalidPoetrySourceError)
+	case project.UV:
+		if err := validatePattern(configFile.Resolver.IndexName, repoNameRegexp, resolutionErrorPrefix+invalidUvIndexError); err != nil {
+			return err
+		}
+		return validatePattern(configFile.Deployer.IndexName, repoNameRegexp, deploymentErrorPrefix+invalidUvIndexError)
+	case project.Cocoapods:
+		return validatePattern(configFile.Resolver.SpecsRepoName, repoNameRegexp, resolutionErrorPrefix+invalidSpecsRepoNameError)
+	case project.Swift:
+		if err := validatePattern(configFile.Resolver.Scope, swiftScopeRegexp, resolutionErrorPrefix+invalidSwiftScopeError); err != nil {
+			return err
+		}
+		return validatePattern(configFile.Deployer.Scope, swiftScopeRegexp, deploymentErrorPrefix+invalidSwiftScopeError)
+	case project.Terraform:
+		return validateTerraformDeployment(&configFile.Deployer)
+	}
+	return nil
+}
+
+// Validate that the config doesn't set a resolver or a deployer which the technology doesn't have.
+func (configFile *ConfigFile) validateSupportedRepositories(projectType project.ProjectType) error {
+	if projectType == project.Build {
+		// The build config has no repositories.
+		return nil
+	}
+	hasResolver, hasDeployer := project.HasRepositories(projectType)
+	if !hasResolver && configFile.Resolver != (project.Repository{}) {
+		return fmt.Errorf(resolutionErrorPrefix+unsupportedResolverError, configFile.ConfigType)
+	}
+	if !hasDeployer && configFile.Deployer != (project.Repository{}) {
+		return fmt.Errorf(deploymentErrorPrefix+unsupportedDeployerError, configFile.ConfigType)
+	}
+	return nil
+}
+
+func validateTerraformDeployment(deployer *project.Repository) error {
+	if (deployer.Namespace == "") != (deployer.Provider == "") {
+		return errors.New(deploymentErrorPrefix + setTerraformDeploymentError)
+	}
+	if deployer.Namespace != "" && !terraformNameRegexp.MatchString(deployer.Namespace) {
+		return fmt.Errorf(deploymentErrorPrefix+invalidTerraformNameError, namespace, deployer.Namespace, namespace)
+	}
+	if deployer.Provider != "" && !terraformNameRegexp.MatchString(deployer.Provider) {
+		return fmt.Errorf(deploymentErrorPrefix+invalidTerraformNameError, provider, deployer.Provider, provider)
+	}
+	return nil
+}
+
+// Validate that the value, if set, matches the pattern. The error format receives the value.
+func validatePattern(value string, pattern *regexp.Regexp, errorFormat string) error {
+	if value != "" && !pattern.MatchString(value) {
+		return fmt.Errorf(errorFormat, value)
+	}
+	return nil
+}
+
+func validateArchitecture(architecture string, supportedArchitectures []string, errorFormat string) error {
+	if architecture != "" && !slices.Contains(supportedArchitectures, architecture) {
+		return fmt.Errorf(deploymentErrorPrefix+errorFormat, architecture, strings.Join(supportedArchitectures, ", "))
+	}
+	return nil
 }
 
 // Get Artifactory serverId from the user. If useArtifactoryQuestion is not empty, ask first whether to use artifactory.
@@ -662,6 +1210,14 @@ func getServersIdAndDefault() ([]string, string, error) {
 		}
 		serversId = append(serversId, v.ServerId)
 	}
+	// Prefer the server of the active config context over the global default.
+	context, err := config.GetActiveContext()
+	if err != nil {
+		return nil, "", err
+	}
+	if context != nil && context.ServerId != "" {
+		defaultVal = context.ServerId
+	}
 	return serversId, defaultVal, nil
 }
 
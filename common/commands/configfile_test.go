@@ -453,6 +453,187 @@ func TestValidateConfigDeployer(t *testing.T) {
 	assert.EqualError(t, err, deploymentErrorPrefix+setServerIdError)
 }
 
+func TestConanConfigFileWithRemoteNames(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	context := createContext(t, resolutionServerId+"=relServer", resolutionRepo+"=repo", resolutionRemoteName+"=conan-remote",
+		deploymentServerId+"=depServer", deploymentRepo+"=repo-local", deploymentRemoteName+"=conan-local")
+	assert.NoError(t, CreateBuildConfig(context, project.Conan))
+
+	config := checkCommonAndGetConfiguration(t, project.Conan.String(), tempDirPath)
+	assert.Equal(t, "conan-remote", config.GetString("resolver.remoteName"))
+	assert.Equal(t, "conan-local", config.GetString("deployer.remoteName"))
+
+	// Invalid remote names are rejected.
+	context = createContext(t, resolutionServerId+"=relServer", resolutionRepo+"=repo", resolutionRemoteName+"=conan remote")
+	assert.EqualError(t, CreateBuildConfig(context, project.Conan), resolutionErrorPrefix+"invalid Conan remote name 'conan remote'. The name may contain only letters, digits, '_', '.' and '-'. ")
+}
+
+func TestHelmConfigFile(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	err := CreateBuildConfigWithOptions(true, project.Helm, WithResolverServerId("relServer"), WithResolverRepo("helm-virtual"),
+		WithDeployerServerId("depServer"), WithDeployerRepo("helm-local"), WithResolverHelmOci(false), WithDeployerHelmOci(true))
+	assert.NoError(t, err)
+
+	config := checkCommonAndGetConfiguration(t, project.Helm.String(), tempDirPath)
+	assert.Equal(t, "helm-virtual", config.GetString("resolver.repo"))
+	assert.False(t, config.GetBool("resolver.oci"))
+	assert.Equal(t, "helm-local", config.GetString("deployer.repo"))
+	assert.True(t, config.GetBool("deployer.oci"))
+
+	// The resolver and the deployer have their own OCI flags.
+	flagSet := flag.NewFlagSet("TestFlagSet", flag.ContinueOnError)
+	flags := setBoolFlags(flagSet, global, resolutionOci)
+	flags = append(flags, setStringFlags(flagSet, resolutionServerId+"=relServer", resolutionRepo+"=helm-oci", deploymentServerId+"=depServer", deploymentRepo+"=helm-local")...)
+	assert.NoError(t, flagSet.Parse(flags))
+	assert.NoError(t, CreateBuildConfig(cli.NewContext(nil, flagSet, nil), project.Helm))
+	config = checkCommonAndGetConfiguration(t, project.Helm.String(), tempDirPath)
+	assert.True(t, config.GetBool("resolver.oci"))
+	assert.False(t, config.GetBool("deployer.oci"))
+}
+
+func TestAptConfigFile(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	context := createContext(t, deploymentServerId+"=depServer", deploymentRepo+"=debian-local", distribution+"=focal", component+"=main", architecture+"=amd64")
+	assert.NoError(t, CreateBuildConfig(context, project.Apt))
+
+	config := checkCommonAndGetConfiguration(t, project.Apt.String(), tempDirPath)
+	assert.Equal(t, "debian-local", config.GetString("deployer.repo"))
+	assert.Equal(t, "focal", config.GetString("deployer.distribution"))
+	assert.Equal(t, "main", config.GetString("deployer.component"))
+	assert.Equal(t, "amd64", config.GetString("deployer.architecture"))
+
+	// The distribution, component and architecture are required for deployment.
+	err := CreateBuildConfigWithOptions(true, project.Apt, WithDeployerServerId("depServer"), WithDeployerRepo("debian-local"), WithAptDeploymentDistribution("focal"))
+	assert.EqualError(t, err, deploymentErrorPrefix+setAptDeploymentError)
+	// Alpine architectures aren't Debian architectures.
+	err = CreateBuildConfigWithOptions(true, project.Apt, WithDeployerServerId("depServer"), WithDeployerRepo("debian-local"), WithAptDeploymentDistribution("focal"),
+		WithAptDeploymentComponent("main"), WithAptDeploymentArchitecture("x86_64"))
+	assert.ErrorContains(t, err, deploymentErrorPrefix+"unsupported Debian architecture 'x86_64'")
+}
+
+func TestApkConfigFile(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	err := CreateBuildConfigWithOptions(true, project.Apk, WithDeployerServerId("depServer"), WithDeployerRepo("alpine-local"),
+		WithApkDeploymentBranch("v3.20"), WithApkDeploymentArchitecture("x86_64"))
+	assert.NoError(t, err)
+
+	config := checkCommonAndGetConfiguration(t, project.Apk.String(), tempDirPath)
+	assert.Equal(t, "alpine-local", config.GetString("deployer.repo"))
+	assert.Equal(t, "v3.20", config.GetString("deployer.branch"))
+	assert.Equal(t, "x86_64", config.GetString("deployer.architecture"))
+
+	err = CreateBuildConfigWithOptions(true, project.Apk, WithDeployerServerId("depServer"), WithDeployerRepo("alpine-local"))
+	assert.EqualError(t, err, deploymentErrorPrefix+setApkDeploymentError)
+	err = CreateBuildConfigWithOptions(true, project.Apk, WithDeployerServerId("depServer"), WithDeployerRepo("alpine-local"),
+		WithApkDeploymentBranch("3.20"), WithApkDeploymentArchitecture("x86_64"))
+	assert.EqualError(t, err, deploymentErrorPrefix+"invalid Alpine branch '3.20'. The branch should be 'edge', or a version such as 'v3.20'. ")
+	// Debian architectures aren't Alpine architectures.
+	err = CreateBuildConfigWithOptions(true, project.Apk, WithDeployerServerId("depServer"), WithDeployerRepo("alpine-local"),
+		WithApkDeploymentBranch("v3.20"), WithApkDeploymentArchitecture("amd64"))
+	assert.ErrorContains(t, err, deploymentErrorPrefix+"unsupported Alpine architecture 'amd64'")
+}
+
+func TestPoetryConfigFile(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	context := createContext(t, resolutionServerId+"=relServer", resolutionRepo+"=pypi-virtual", sourceName+"=artifactory")
+	assert.NoError(t, CreateBuildConfig(context, project.Poetry))
+	config := checkCommonAndGetConfiguration(t, project.Poetry.String(), tempDirPath)
+	assert.Equal(t, "pypi-virtual", config.GetString("resolver.repo"))
+	assert.Equal(t, "artifactory", config.GetString("resolver.sourceName"))
+
+	err := CreateBuildConfigWithOptions(true, project.Poetry, WithResolverServerId("relServer"), WithResolverRepo("pypi-virtual"), WithResolverPoetrySourceName("my source"))
+	assert.EqualError(t, err, resolutionErrorPrefix+"invalid Poetry source name 'my source'. The name may contain only letters, digits, '_', '.' and '-'. ")
+	// Poetry resolves only.
+	err = CreateBuildConfigWithOptions(true, project.Poetry, WithResolverServerId("relServer"), WithResolverRepo("pypi-virtual"), WithDeployerServerId("depServer"), WithDeployerRepo("pypi-local"))
+	assert.EqualError(t, err, deploymentErrorPrefix+"poetry projects don't deploy artifacts to Artifactory. ")
+}
+
+func TestUvConfigFile(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	context := createContext(t, resolutionServerId+"=relServer", resolutionRepo+"=pypi-virtual", resolutionIndexName+"=artifactory",
+		deploymentServerId+"=depServer", deploymentRepo+"=pypi-local", deploymentIndexName+"=artifactory-local")
+	assert.NoError(t, CreateBuildConfig(context, project.UV))
+	config := checkCommonAndGetConfiguration(t, project.UV.String(), tempDirPath)
+	assert.Equal(t, "artifactory", config.GetString("resolver.indexName"))
+	assert.Equal(t, "artifactory-local", config.GetString("deployer.indexName"))
+
+	err := CreateBuildConfigWithOptions(true, project.UV, WithDeployerServerId("depServer"), WithDeployerRepo("pypi-local"), WithDeployerUvIndexName("-local"))
+	assert.EqualError(t, err, deploymentErrorPrefix+"invalid UV index name '-local'. The name may contain only letters, digits, '_', '.' and '-'. ")
+}
+
+func TestCocoapodsConfigFile(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	err := CreateBuildConfigWithOptions(true, project.Cocoapods, WithResolverServerId("relServer"), WithResolverRepo("cocoapods-remote"), WithResolverCocoapodsSpecsRepoName("artifactory-specs"))
+	assert.NoError(t, err)
+	config := checkCommonAndGetConfiguration(t, project.Cocoapods.String(), tempDirPath)
+	assert.Equal(t, "artifactory-specs", config.GetString("resolver.specsRepoName"))
+
+	err = CreateBuildConfigWithOptions(true, project.Cocoapods, WithResolverServerId("relServer"), WithResolverRepo("cocoapods-remote"), WithResolverCocoapodsSpecsRepoName("specs/repo"))
+	assert.ErrorContains(t, err, resolutionErrorPrefix+"invalid CocoaPods specs repository name 'specs/repo'")
+}
+
+func TestSwiftConfigFile(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	context := createContext(t, resolutionServerId+"=relServer", resolutionRepo+"=swift-virtual", deploymentServerId+"=depServer", deploymentRepo+"=swift-local", deploymentScope+"=acme")
+	assert.NoError(t, CreateBuildConfig(context, project.Swift))
+	config := checkCommonAndGetConfiguration(t, project.Swift.String(), tempDirPath)
+	// Without a scope, the registry is used for all the scopes.
+	assert.Empty(t, config.GetString("resolver.scope"))
+	assert.Equal(t, "acme", config.GetString("deployer.scope"))
+
+	err := CreateBuildConfigWithOptions(true, project.Swift, WithResolverServerId("relServer"), WithResolverRepo("swift-virtual"), WithResolverSwiftScope("acme.packages"))
+	assert.ErrorContains(t, err, resolutionErrorPrefix+"invalid Swift scope 'acme.packages'")
+}
+
+func TestTerraformConfigFile(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	context := createContext(t, deploymentServerId+"=depServer", deploymentRepo+"=terraform-local", namespace+"=acme", provider+"=aws")
+	assert.NoError(t, CreateBuildConfig(context, project.Terraform))
+	config := checkCommonAndGetConfiguration(t, project.Terraform.String(), tempDirPath)
+	assert.Equal(t, "acme", config.GetString("deployer.namespace"))
+	assert.Equal(t, "aws", config.GetString("deployer.provider"))
+
+	err := CreateBuildConfigWithOptions(true, project.Terraform, WithDeployerServerId("depServer"), WithDeployerRepo("terraform-local"), WithTerraformDeploymentNamespace("acme"))
+	assert.EqualError(t, err, deploymentErrorPrefix+setTerraformDeploymentError)
+	err = CreateBuildConfigWithOptions(true, project.Terraform, WithDeployerServerId("depServer"), WithDeployerRepo("terraform-local"),
+		WithTerraformDeploymentNamespace("acme"), WithTerraformDeploymentProvider("aws/v1"))
+	assert.EqualError(t, err, deploymentErrorPrefix+"invalid Terraform provider 'aws/v1'. The provider may contain only letters, digits, '_' and '-'. ")
+	// Terraform deploys only.
+	err = CreateBuildConfigWithOptions(true, project.Terraform, WithResolverServerId("relServer"), WithResolverRepo("terraform-remote"))
+	assert.EqualError(t, err, resolutionErrorPrefix+"terraform projects don't resolve dependencies from Artifactory. ")
+}
+
+func TestTwineConfigFile(t *testing.T) {
+	tempDirPath := createTempEnv(t)
+	defer testsutils.RemoveAllAndAssert(t, tempDirPath)
+
+	assert.NoError(t, CreateBuildConfigWithOptions(true, project.Twine, WithDeployerServerId("depServer"), WithDeployerRepo("pypi-local")))
+	config := checkCommonAndGetConfiguration(t, project.Twine.String(), tempDirPath)
+	assert.Equal(t, "pypi-local", config.GetString("deployer.repo"))
+
+	// Twine deploys only.
+	err := CreateBuildConfigWithOptions(true, project.Twine, WithResolverServerId("relServer"), WithResolverRepo("pypi-virtual"))
+	assert.EqualError(t, err, resolutionErrorPrefix+"twine projects don't resolve dependencies from Artifactory. ")
+}
+
 // Set JFROG_CLI_HOME_DIR environment variable to be a new temp directory
 func createTempEnv(t *testing.T) string {
 	tmpDir, err := fileutils.CreateTempDir()
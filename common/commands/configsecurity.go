@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const rotateKeyCommandName = "config_security_rotate_key"
+
+// RotateKeyCommand rotates the master key used to encrypt the secrets in the config file.
+type RotateKeyCommand struct{}
+
+func NewRotateKeyCommand() *RotateKeyCommand {
+	return &RotateKeyCommand{}
+}
+
+func (rkc *RotateKeyCommand) Run() (err error) {
+	log.Debug("Locking config file to rotate the master key.")
+	unlockFunc, err := lockConfig()
+	// Defer the lockFile.Unlock() function before throwing a possible error to avoid deadlock situations.
+	defer func() {
+		err = errors.Join(err, unlockFunc())
+	}()
+	if err != nil {
+		return
+	}
+	return config.RotateMasterKey()
+}
+
+func (rkc *RotateKeyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (rkc *RotateKeyCommand) CommandName() string {
+	return rotateKeyCommandName
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const contextCommandName = "config_context"
+
+// ContextCommand manages the named config contexts.
+// A context selects a configured server, together with a default project, resolution and deployment repositories
+// and output format. A context can be used per directory, without changing the global default server.
+type ContextCommand struct {
+	cmdType ConfigAction
+	context *config.Context
+	// The directory in which the context is used. Defaults to the working directory.
+	dir string
+}
+
+func NewContextCommand(cmdType ConfigAction, name string) *ContextCommand {
+	return &ContextCommand{cmdType: cmdType, context: &config.Context{Name: name}}
+}
+
+func (cc *ContextCommand) SetContext(context *config.Context) *ContextCommand {
+	cc.context = context
+	return cc
+}
+
+func (cc *ContextCommand) SetDir(dir string) *ContextCommand {
+	cc.dir = dir
+	return cc
+}
+
+func (cc *ContextCommand) Run() (err error) {
+	log.Debug("Locking config file to run config context " + cc.cmdType + " command.")
+	unlockFunc, err := lockConfig()
+	// Defer the lockFile.Unlock() function before throwing a possible error to avoid deadlock situations.
+	defer func() {
+		err = errors.Join(err, unlockFunc())
+	}()
+	if err != nil {
+		return
+	}
+
+	switch cc.cmdType {
+	case AddOrEdit:
+		err = cc.addOrEdit()
+	case Delete:
+		err = cc.delete()
+	case Use:
+		err = cc.use()
+	default:
+		err = fmt.Errorf("not supported config context command type: %s", string(cc.cmdType))
+	}
+	return
+}
+
+func (cc *ContextCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (cc *ContextCommand) CommandName() string {
+	return contextCommandName
+}
+
+func (cc *ContextCommand) addOrEdit() error {
+	if err := cc.validate(); err != nil {
+		return err
+	}
+	contexts, err := config.GetAllContexts()
+	if err != nil {
+		return err
+	}
+	_, contexts = config.GetAndRemoveContext(cc.context.Name, contexts)
+	contexts = append(contexts, cc.context)
+	if err = config.SaveContexts(contexts); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Context '%s' saved.", cc.context.Name))
+	return nil
+}
+
+func (cc *ContextCommand) validate() error {
+	if cc.context.Name == "" {
+		return errorutils.CheckErrorf("a context name must be provided")
+	}
+	if cc.context.ServerId != "" {
+		if _, err := config.GetSpecificConfig(cc.context.ServerId, false, false); err != nil {
+			return err
+		}
+	}
+	if cc.context.OutputFormat != "" {
+		if _, err := format.ParseOutputFormat(cc.context.OutputFormat, format.All); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cc *ContextCommand) delete() error {
+	contexts, err := config.GetAllContexts()
+	if err != nil {
+		return err
+	}
+	removed, contexts := config.GetAndRemoveContext(cc.context.Name, contexts)
+	if removed == nil {
+		log.Info("\"" + cc.context.Name + "\" context could not be found.\n")
+		return nil
+	}
+	return config.SaveContexts(contexts)
+}
+
+// Use the context in the directory, by writing a .jfrog/context.yaml file referencing it.
+func (cc *ContextCommand) use() (err error) {
+	dir := cc.dir
+	if dir == "" {
+		if dir, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	contextFilePath, err := config.UseContextInDir(cc.context.Name, dir)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Using context '%s' in %s", cc.context.Name, dir))
+	log.Debug("Context file written to " + contextFilePath)
+	if envContext := os.Getenv(coreutils.ContextName); envContext != "" && envContext != cc.context.Name {
+		log.Warn(fmt.Sprintf("The %s environment variable is set to '%s' and takes precedence over the context used in the directory.", coreutils.ContextName, envContext))
+	}
+	return nil
+}
+
+// Prints the context with the provided name, or all the contexts if the name is empty.
+// The active context is printed in bold.
+func ShowContexts(name string) error {
+	contexts, err := config.GetAllContexts()
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		context, err := config.GetContext(name)
+		if err != nil {
+			return err
+		}
+		contexts = []*config.Context{context}
+	}
+	activeContext, err := config.GetActiveContext()
+	if err != nil {
+		return err
+	}
+	for _, context := range contexts {
+		isActive := activeContext != nil && activeContext.Name == context.Name
+		logIfNotEmpty(context.Name, "Context:\t\t\t", false, isActive)
+		logIfNotEmpty(context.ServerId, "Server ID:\t\t\t", false, isActive)
+		logIfNotEmpty(context.Project, "Project:\t\t\t", false, isActive)
+		logIfNotEmpty(context.ResolveRepo, "Resolution repository:\t\t", false, isActive)
+		logIfNotEmpty(context.DeployRepo, "Deployment repository:\t\t", false, isActive)
+		logIfNotEmpty(context.OutputFormat, "Output format:\t\t\t", false, isActive)
+		log.Output()
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const DefaultCredentialExpiryWindow = 7 * 24 * time.Hour
+
+// CredentialExpiryReporter is implemented by commands with a structured output, such as JSON,
+// which include the expiring credentials of their server in their output, instead of a warning being logged.
+type CredentialExpiryReporter interface {
+	// Returns false if the credentials aren't included in the output of the command, such as a table, so a warning is logged instead.
+	ReportCredentialExpiry(expiringCredentials []config.CredentialExpiry) bool
+}
+
+// Warns about the credentials of the command's server, which expire within the window set by the JFROG_CLI_CREDENTIAL_EXPIRY_WINDOW environment variable.
+// If JFROG_CLI_CREDENTIAL_EXPIRY_FAIL is true, an error is returned instead.
+// The credentials are decoded locally, without sending any request, so that every command can afford the check.
+func checkCredentialExpiry(command Command) error {
+	serverDetails, err := command.ServerDetails()
+	if err != nil || serverDetails == nil || !hasExpiringCredentialTypes(serverDetails) {
+		// The command reports its own errors when it runs.
+		return nil
+	}
+	window := getCredentialExpiryWindow()
+	if window <= 0 {
+		return nil
+	}
+	expiringCredentials := GetExpiringCredentials(serverDetails, window, time.Now())
+	if len(expiringCredentials) == 0 {
+		return nil
+	}
+	failOnExpiry, err := clientutils.GetBoolEnvValue(coreutils.CredentialExpiryFail, false)
+	if err != nil {
+		return err
+	}
+	message := formatCredentialExpiryMessage(expiringCredentials)
+	if failOnExpiry {
+		return errorutils.CheckErrorf("%s The command was not run, since %s is set", message, coreutils.CredentialExpiryFail)
+	}
+	if reporter, ok := command.(CredentialExpiryReporter); ok && reporter.ReportCredentialExpiry(expiringCredentials) {
+		return nil
+	}
+	log.Warn(message)
+	return nil
+}
+
+// Returns true if the server has credentials whose expiry can be checked.
+func hasExpiringCredentialTypes(serverDetails *config.ServerDetails) bool {
+	return serverDetails.ClientCertPath != "" || isExpiringAccessToken(serverDetails)
+}
+
+// Returns true if the access token of the server is a JWT, which isn't refreshed or re-exchanged automatically.
+// Reference tokens are not JWTs, so their expiry can't be decoded.
+func isExpiringAccessToken(serverDetails *config.ServerDetails) bool {
+	return serverDetails.AccessToken != "" && strings.Count(serverDetails.AccessToken, ".") == 2 && !IsAccessTokenRenewable(serverDetails)
+}
+
+// Returns the credentials of the server which expire within the window from now.
+// Access tokens which are refreshed or re-exchanged automatically are ignored, and so are reference tokens, whose expiry can't be decoded.
+func GetExpiringCredentials(serverDetails *config.ServerDetails, window time.Duration, now time.Time) []config.CredentialExpiry {
+	var expiringCredentials []config.CredentialExpiry
+	appendIfExpiring := func(credential config.ExpiringCredential, expiresAt time.Time) {
+		if expiresAt.Before(now.Add(window)) {
+			expiringCredentials = append(expiringCredentials, config.CredentialExpiry{
+				ServerId:   serverDetails.ServerId,
+				Credential: credential,
+				ExpiresAt:  expiresAt.UTC(),
+				Expired:    !expiresAt.After(now),
+			})
+		}
+	}
+	if isExpiringAccessToken(serverDetails) {
+		payload, err := auth.ExtractPayloadFromAccessToken(serverDetails.AccessToken)
+		if err != nil {
+			log.Debug("Couldn't decode the access token to check its expiry: " + err.Error())
+		} else if payload.ExpirationTime != 0 {
+			appendIfExpiring(config.ExpiringAccessToken, time.Unix(int64(payload.ExpirationTime), 0))
+		}
+	}
+	if serverDetails.ClientCertPath != "" {
+		leaf, err := serverDetails.LoadClientCertificateLeaf()
+		if err != nil {
+			log.Debug("Couldn't load the client certificate to check its expiry: " + err.Error())
+		} else if leaf != nil {
+			appendIfExpiring(config.ExpiringClientCertificate, leaf.NotAfter)
+		}
+	}
+	return expiringCredentials
+}
+
+// Returns true if the access token of the server is renewed before it expires, by a refresh token or by re-exchanging its OIDC token.
+func IsAccessTokenRenewable(serverDetails *config.ServerDetails) bool {
+	if serverDetails.ArtifactoryRefreshToken != "" {
+		return true
+	}
+	return !serverDetails.DisableTokenRefresh && (serverDetails.RefreshToken != "" || serverDetails.OidcExchangeParams != nil)
+}
+
+// Formats the warning about the expiring credentials, distinguishing the credentials which already expired from those which expire soon.
+func formatCredentialExpiryMessage(expiringCredentials []config.CredentialExpiry) string {
+	var expired, expiringSoon []string
+	for _, credential := range expiringCredentials {
+		name := "the access token"
+		if credential.Credential == config.ExpiringClientCertificate {
+			name = "the client certificate"
+		}
+		if credential.Expired {
+			expired = append(expired, fmt.Sprintf("%s expired at %s", name, credential.ExpiresAt.Format(time.RFC3339)))
+		} else {
+			expiringSoon = append(expiringSoon, fmt.Sprintf("%s expires at %s", name, credential.ExpiresAt.Format(time.RFC3339)))
+		}
+	}
+	serverId := expiringCredentials[0].ServerId
+	var sentences []string
+	if len(expired) > 0 {
+		sentences = append(sentences, fmt.Sprintf("The credentials of server ID '%s' have expired: %s.", serverId, strings.Join(expired, ", ")))
+	}
+	if len(expiringSoon) > 0 {
+		sentences = append(sentences, fmt.Sprintf("The credentials of server ID '%s' expire soon: %s.", serverId, strings.Join(expiringSoon, ", ")))
+	}
+	return strings.Join(sentences, " ")
+}
+
+// Returns the window set by the JFROG_CLI_CREDENTIAL_EXPIRY_WINDOW environment variable, or the default window if not set or invalid.
+func getCredentialExpiryWindow() time.Duration {
+	value := os.Getenv(coreutils.CredentialExpiryWindow)
+	if value == "" {
+		return DefaultCredentialExpiryWindow
+	}
+	if days, found := strings.CutSuffix(value, "d"); found {
+		if daysCount, err := strconv.Atoi(days); err == nil {
+			return time.Duration(daysCount) * 24 * time.Hour
+		}
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		log.Warn(fmt.Sprintf("Invalid value '%s' of %s, using the default window. The value should be a duration such as '72h', or a number of days such as '7d'.", value, coreutils.CredentialExpiryWindow))
+		return DefaultCredentialExpiryWindow
+	}
+	return window
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type credentialExpiryTestCommand struct {
+	serverDetails *config.ServerDetails
+	executed      bool
+}
+
+func (c *credentialExpiryTestCommand) Run() error {
+	c.executed = true
+	return nil
+}
+
+func (c *credentialExpiryTestCommand) ServerDetails() (*config.ServerDetails, error) {
+	return c.serverDetails, nil
+}
+
+func (c *credentialExpiryTestCommand) CommandName() string {
+	return "credential_expiry_test"
+}
+
+// A command with a structured output, which includes the expiring credentials.
+type credentialExpiryReporterTestCommand struct {
+	credentialExpiryTestCommand
+	structuredOutput    bool
+	expiringCredentials []config.CredentialExpiry
+}
+
+func (c *credentialExpiryReporterTestCommand) ReportCredentialExpiry(expiringCredentials []config.CredentialExpiry) bool {
+	if !c.structuredOutput {
+		return false
+	}
+	c.expiringCredentials = expiringCredentials
+	return true
+}
+
+func buildExpiringAccessToken(t *testing.T, expiresAt time.Time) string {
+	payload, err := json.Marshal(map[string]int64{"exp": expiresAt.Unix()})
+	require.NoError(t, err)
+	return "header." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+// Writes a client certificate and key in a single PEM file, and returns its path.
+func writeExpiringClientCert(t *testing.T, expiresAt time.Time) string {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "jfrog-cli-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     expiresAt,
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	content := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})...)
+	certPath := filepath.Join(t.TempDir(), "client.pem")
+	require.NoError(t, os.WriteFile(certPath, content, 0600))
+	return certPath
+}
+
+func TestGetExpiringCredentials(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	expiringToken := buildExpiringAccessToken(t, now.Add(time.Hour))
+	testCases := []struct {
+		name          string
+		serverDetails *config.ServerDetails
+		expected      []config.CredentialExpiry
+	}{
+		{"noCredentials", &config.ServerDetails{ServerId: "server"}, nil},
+		{"referenceToken", &config.ServerDetails{ServerId: "server", AccessToken: "reference"}, nil},
+		{"validToken", &config.ServerDetails{ServerId: "server", AccessToken: buildExpiringAccessToken(t, now.Add(30*24*time.Hour))}, nil},
+		{"expiringToken", &config.ServerDetails{ServerId: "server", AccessToken: expiringToken},
+			[]config.CredentialExpiry{{ServerId: "server", Credential: config.ExpiringAccessToken, ExpiresAt: now.Add(time.Hour).UTC()}}},
+		{"expiredToken", &config.ServerDetails{ServerId: "server", AccessToken: buildExpiringAccessToken(t, now.Add(-time.Hour))},
+			[]config.CredentialExpiry{{ServerId: "server", Credential: config.ExpiringAccessToken, ExpiresAt: now.Add(-time.Hour).UTC(), Expired: true}}},
+		{"refreshableToken", &config.ServerDetails{ServerId: "server", AccessToken: expiringToken, RefreshToken: "refresh"}, nil},
+		{"refreshDisabled", &config.ServerDetails{ServerId: "server", AccessToken: expiringToken, RefreshToken: "refresh", DisableTokenRefresh: true},
+			[]config.CredentialExpiry{{ServerId: "server", Credential: config.ExpiringAccessToken, ExpiresAt: now.Add(time.Hour).UTC()}}},
+		{"oidcToken", &config.ServerDetails{ServerId: "server", AccessToken: expiringToken, OidcExchangeParams: &config.OidcExchangeParams{ProviderName: "provider"}}, nil},
+		{"validClientCert", &config.ServerDetails{ServerId: "server", ClientCertPath: writeExpiringClientCert(t, now.Add(30*24*time.Hour))}, nil},
+		{"expiringClientCert", &config.ServerDetails{ServerId: "server", ClientCertPath: writeExpiringClientCert(t, now.Add(time.Hour))},
+			[]config.CredentialExpiry{{ServerId: "server", Credential: config.ExpiringClientCertificate, ExpiresAt: now.Add(time.Hour).UTC()}}},
+		// Only the certificate is read, so the key isn't loaded.
+		{"clientCertWithoutKey", &config.ServerDetails{ServerId: "server", ClientCertPath: writeExpiringClientCert(t, now.Add(time.Hour)), ClientCertKeyPath: "missing.key"},
+			[]config.CredentialExpiry{{ServerId: "server", Credential: config.ExpiringClientCertificate, ExpiresAt: now.Add(time.Hour).UTC()}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, GetExpiringCredentials(testCase.serverDetails, DefaultCredentialExpiryWindow, now))
+		})
+	}
+}
+
+func TestGetCredentialExpiryWindow(t *testing.T) {
+	testCases := []struct {
+		value          string
+		expectedWindow time.Duration
+	}{
+		{"", DefaultCredentialExpiryWindow},
+		{"72h", 72 * time.Hour},
+		{"3d", 72 * time.Hour},
+		{"0", 0},
+		// An invalid window falls back to the default window.
+		{"week", DefaultCredentialExpiryWindow},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.value, func(t *testing.T) {
+			defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.CredentialExpiryWindow, testCase.value)()
+			assert.Equal(t, testCase.expectedWindow, getCredentialExpiryWindow())
+		})
+	}
+}
+
+func TestExecWithExpiringCredentials(t *testing.T) {
+	serverDetails := &config.ServerDetails{ServerId: "server", AccessToken: buildExpiringAccessToken(t, time.Now().Add(time.Hour))}
+
+	// By default, the command runs after warning.
+	command := &credentialExpiryTestCommand{serverDetails: serverDetails}
+	assert.NoError(t, Exec(command))
+	assert.True(t, command.executed)
+
+	// The command fails without running, if the credential expiry should fail commands.
+	restoreFailEnv := testsutils.SetEnvWithCallbackAndAssert(t, coreutils.CredentialExpiryFail, "true")
+	defer restoreFailEnv()
+	command = &credentialExpiryTestCommand{serverDetails: serverDetails}
+	assert.ErrorContains(t, Exec(command), "The credentials of server ID 'server' expire soon")
+	assert.False(t, command.executed)
+
+	// The check is disabled with a zero window.
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.CredentialExpiryWindow, "0")()
+	command = &credentialExpiryTestCommand{serverDetails: serverDetails}
+	assert.NoError(t, Exec(command))
+	assert.True(t, command.executed)
+}
+
+func TestExecReportsCredentialExpiry(t *testing.T) {
+	serverDetails := &config.ServerDetails{ServerId: "server", AccessToken: buildExpiringAccessToken(t, time.Now().Add(time.Hour))}
+	command := &credentialExpiryReporterTestCommand{credentialExpiryTestCommand: credentialExpiryTestCommand{serverDetails: serverDetails}, structuredOutput: true}
+	assert.NoError(t, Exec(command))
+	assert.True(t, command.executed)
+	require.Len(t, command.expiringCredentials, 1)
+	assert.Equal(t, config.ExpiringAccessToken, command.expiringCredentials[0].Credential)
+
+	// A command whose output isn't structured doesn't include the credentials.
+	command = &credentialExpiryReporterTestCommand{credentialExpiryTestCommand: credentialExpiryTestCommand{serverDetails: serverDetails}}
+	assert.NoError(t, Exec(command))
+	assert.Empty(t, command.expiringCredentials)
+}
+
+func TestFormatCredentialExpiryMessage(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	expired := config.CredentialExpiry{ServerId: "server", Credential: config.ExpiringAccessToken, ExpiresAt: expiresAt, Expired: true}
+	expiringSoon := config.CredentialExpiry{ServerId: "server", Credential: config.ExpiringClientCertificate, ExpiresAt: expiresAt}
+	assert.Equal(t, "The credentials of server ID 'server' have expired: the access token expired at 2030-01-02T03:04:05Z.",
+		formatCredentialExpiryMessage([]config.CredentialExpiry{expired}))
+	assert.Equal(t, "The credentials of server ID 'server' expire soon: the client certificate expires at 2030-01-02T03:04:05Z.",
+		formatCredentialExpiryMessage([]config.CredentialExpiry{expiringSoon}))
+	assert.Equal(t, "The credentials of server ID 'server' have expired: the access token expired at 2030-01-02T03:04:05Z. "+
+		"The credentials of server ID 'server' expire soon: the client certificate expires at 2030-01-02T03:04:05Z.",
+		formatCredentialExpiryMessage([]config.CredentialExpiry{expired, expiringSoon}))
+}
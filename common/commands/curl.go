@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
@@ -11,6 +14,7 @@ import (
 	gofrogcmd "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
@@ -19,6 +23,8 @@ type CurlCommand struct {
 	executablePath string
 	serverDetails  *config.ServerDetails
 	url            string
+	// A temp dir holding the curl config file with the client certificate passphrase, removed after the command runs.
+	passConfigDir string
 }
 
 func NewCurlCommand() *CurlCommand {
@@ -45,7 +51,7 @@ func (curlCmd *CurlCommand) SetUrl(url string) *CurlCommand {
 	return curlCmd
 }
 
-func (curlCmd *CurlCommand) Run() error {
+func (curlCmd *CurlCommand) Run() (err error) {
 	// Get curl execution path.
 	execPath, err := exec.LookPath("curl")
 	if err != nil {
@@ -60,7 +66,7 @@ func (curlCmd *CurlCommand) Run() error {
 
 	// If the command already includes certificates flag, return an error.
 	if curlCmd.serverDetails.ClientCertPath != "" && curlCmd.isCertificateFlagExists() {
-		return errorutils.CheckErrorf("Curl command must not include certificate flag (--cert or --key).")
+		return errorutils.CheckErrorf("Curl command must not include certificate flag (--cert, --key, --cert-type or --pass).")
 	}
 
 	// Get target url for the curl command.
@@ -74,21 +80,29 @@ func (curlCmd *CurlCommand) Run() error {
 
 	cmdWithoutCreds := strings.Join(curlCmd.arguments, " ")
 	// Add credentials to curl command.
-	credentialsMessage := curlCmd.addCommandCredentials()
+	credentialsMessage, err := curlCmd.addCommandCredentials()
+	defer func() {
+		if curlCmd.passConfigDir != "" {
+			err = errors.Join(err, fileutils.RemoveTempDir(curlCmd.passConfigDir))
+		}
+	}()
+	if err != nil {
+		return err
+	}
 
 	// Run curl.
 	log.Debug(fmt.Sprintf("Executing curl command: '%s %s'", cmdWithoutCreds, credentialsMessage))
 	return gofrogcmd.RunCmd(curlCmd)
 }
 
-func (curlCmd *CurlCommand) addCommandCredentials() string {
+func (curlCmd *CurlCommand) addCommandCredentials() (string, error) {
 	certificateHelpPrefix := ""
 
 	if curlCmd.serverDetails.ClientCertPath != "" {
-		curlCmd.arguments = append(curlCmd.arguments,
-			"--cert", curlCmd.serverDetails.ClientCertPath,
-			"--key", curlCmd.serverDetails.ClientCertKeyPath)
-		certificateHelpPrefix = "--cert *** --key *** "
+		var err error
+		if certificateHelpPrefix, err = curlCmd.addClientCertificate(); err != nil {
+			return "", err
+		}
 	}
 
 	if curlCmd.serverDetails.AccessToken != "" {
@@ -96,14 +110,51 @@ func (curlCmd *CurlCommand) addCommandCredentials() string {
 		tokenHeader := fmt.Sprintf("Authorization: Bearer %s", curlCmd.serverDetails.AccessToken)
 		curlCmd.arguments = append(curlCmd.arguments, "-H", tokenHeader)
 
-		return certificateHelpPrefix + "-H \"Authorization: Bearer ***\""
+		return certificateHelpPrefix + "-H \"Authorization: Bearer ***\"", nil
 	}
 
 	// Add credentials flag to Command. In case of flag duplication, the latter is used by Curl.
 	credFlag := fmt.Sprintf("-u%s:%s", curlCmd.serverDetails.User, curlCmd.serverDetails.Password)
 	curlCmd.arguments = append(curlCmd.arguments, credFlag)
 
-	return certificateHelpPrefix + "-u***:***"
+	return certificateHelpPrefix + "-u***:***", nil
+}
+
+// Adds the client certificate flags to the command, and returns them with the secrets masked.
+// A PKCS#12 bundle includes the private key. The passphrase of a PKCS#12 bundle or an encrypted key is passed in a curl config file,
+// rather than using the '--pass' flag, to keep it out of the process arguments.
+func (curlCmd *CurlCommand) addClientCertificate() (string, error) {
+	details := curlCmd.serverDetails
+	curlCmd.arguments = append(curlCmd.arguments, "--cert", details.ClientCertPath)
+	certificateHelp := "--cert *** "
+	if config.IsPkcs12ClientCert(details.ClientCertPath) {
+		curlCmd.arguments = append(curlCmd.arguments, "--cert-type", "P12")
+		certificateHelp += "--cert-type P12 "
+	} else if details.ClientCertKeyPath != "" {
+		curlCmd.arguments = append(curlCmd.arguments, "--key", details.ClientCertKeyPath)
+		certificateHelp += "--key *** "
+	}
+	if details.ClientCertKeyPassphrase != "" {
+		passConfigPath, err := curlCmd.writePassConfigFile(details.ClientCertKeyPassphrase)
+		if err != nil {
+			return "", err
+		}
+		curlCmd.arguments = append(curlCmd.arguments, "--config", passConfigPath)
+		certificateHelp += "--config *** "
+	}
+	return certificateHelp, nil
+}
+
+// Writes a curl config file, which sets the passphrase of the client certificate, to a new temp dir accessible only by the current user.
+func (curlCmd *CurlCommand) writePassConfigFile(passphrase string) (string, error) {
+	passConfigDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return "", err
+	}
+	curlCmd.passConfigDir = passConfigDir
+	escapedPassphrase := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(passphrase)
+	passConfigPath := filepath.Join(passConfigDir, "curl-pass.conf")
+	return passConfigPath, errorutils.CheckError(os.WriteFile(passConfigPath, []byte(fmt.Sprintf("pass = \"%s\"\n", escapedPassphrase)), 0600))
 }
 
 func (curlCmd *CurlCommand) buildCommandUrl(url string) (uriIndex int, uriValue string, err error) {
@@ -266,7 +317,7 @@ func (curlCmd *CurlCommand) isCredentialsFlagExists() bool {
 // The searched flags are not CLI flags.
 func (curlCmd *CurlCommand) isCertificateFlagExists() bool {
 	for _, arg := range curlCmd.arguments {
-		if arg == "--cert" || arg == "--key" {
+		if arg == "--cert" || arg == "--key" || arg == "--cert-type" || arg == "--pass" {
 			return true
 		}
 	}
@@ -1,7 +1,13 @@
 package commands
 
 import (
+	"os"
 	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFindNextArg(t *testing.T) {
@@ -345,3 +351,62 @@ func TestFindUriWithBooleanFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestAddClientCertificate(t *testing.T) {
+	tests := []struct {
+		name                 string
+		serverDetails        *config.ServerDetails
+		expectedArguments    []string
+		expectedMaskedOutput string
+		expectedPassConfig   string
+	}{
+		{
+			name:                 "pem",
+			serverDetails:        &config.ServerDetails{ClientCertPath: "client.pem", ClientCertKeyPath: "client.key"},
+			expectedArguments:    []string{"--cert", "client.pem", "--key", "client.key"},
+			expectedMaskedOutput: "--cert *** --key *** ",
+		},
+		{
+			name:                 "encryptedKey",
+			serverDetails:        &config.ServerDetails{ClientCertPath: "client.pem", ClientCertKeyPath: "client.key", ClientCertKeyPassphrase: `se"cr\et`},
+			expectedArguments:    []string{"--cert", "client.pem", "--key", "client.key", "--config"},
+			expectedMaskedOutput: "--cert *** --key *** --config *** ",
+			expectedPassConfig:   `pass = "se\"cr\\et"` + "\n",
+		},
+		{
+			name:                 "keyInCertificateFile",
+			serverDetails:        &config.ServerDetails{ClientCertPath: "client.pem"},
+			expectedArguments:    []string{"--cert", "client.pem"},
+			expectedMaskedOutput: "--cert *** ",
+		},
+		{
+			name:                 "pkcs12",
+			serverDetails:        &config.ServerDetails{ClientCertPath: "client.P12", ClientCertKeyPassphrase: "secret"},
+			expectedArguments:    []string{"--cert", "client.P12", "--cert-type", "P12", "--config"},
+			expectedMaskedOutput: "--cert *** --cert-type P12 --config *** ",
+			expectedPassConfig:   "pass = \"secret\"\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			command := &CurlCommand{serverDetails: test.serverDetails}
+			maskedOutput, err := command.addClientCertificate()
+			require.NoError(t, err)
+			arguments := command.arguments
+			if test.expectedPassConfig != "" {
+				defer func() {
+					assert.NoError(t, fileutils.RemoveTempDir(command.passConfigDir))
+				}()
+				// The passphrase is never passed as an argument.
+				require.NotEmpty(t, arguments)
+				passConfig, err := os.ReadFile(arguments[len(arguments)-1])
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedPassConfig, string(passConfig))
+				arguments = arguments[:len(arguments)-1]
+			}
+			assert.Equal(t, test.expectedArguments, arguments)
+			assert.Equal(t, test.expectedMaskedOutput, maskedOutput)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigMigrateCommand merges the legacy .jfrog/projects/<type>.yaml files of the project into the unified .jfrog/project.yaml file.
+// Technologies which are already configured by the unified file are kept as is.
+// The legacy files are kept, since consumers which read them directly don't read the unified file.
+type ProjectConfigMigrateCommand struct {
+	migratedTechnologies  []string
+	unifiedConfigFilePath string
+}
+
+func NewProjectConfigMigrateCommand() *ProjectConfigMigrateCommand {
+	return &ProjectConfigMigrateCommand{}
+}
+
+// Returns the technologies merged into the unified file by the last run.
+func (pcm *ProjectConfigMigrateCommand) MigratedTechnologies() []string {
+	return pcm.migratedTechnologies
+}
+
+func (pcm *ProjectConfigMigrateCommand) UnifiedConfigFilePath() string {
+	return pcm.unifiedConfigFilePath
+}
+
+func (pcm *ProjectConfigMigrateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (pcm *ProjectConfigMigrateCommand) CommandName() string {
+	return "project_config_migrate"
+}
+
+func (pcm *ProjectConfigMigrateCommand) Run() error {
+	projectDir, exists, err := fileutils.FindUpstream(".jfrog", fileutils.Dir)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errorutils.CheckErrorf("no .jfrog directory was found in the working directory or in its parent directories")
+	}
+	jfrogDir := filepath.Join(projectDir, ".jfrog")
+	pcm.unifiedConfigFilePath = filepath.Join(jfrogDir, project.UnifiedProjectConfigFileName)
+	unifiedConfig := project.NewUnifiedProjectConfig()
+	if exists, err = fileutils.IsFileExists(pcm.unifiedConfigFilePath, false); err != nil {
+		return err
+	}
+	if exists {
+		if unifiedConfig, err = project.ReadUnifiedProjectConfig(pcm.unifiedConfigFilePath); err != nil {
+			return err
+		}
+	}
+
+	pcm.migratedTechnologies = nil
+	for i := range project.ProjectTypes {
+		projectType := project.ProjectType(i)
+		legacyFilePath := filepath.Join(jfrogDir, "projects", projectType.String()+".yaml")
+		if exists, err = fileutils.IsFileExists(legacyFilePath, false); err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if unifiedConfig.HasTechnology(projectType) {
+			log.Warn("Skipping " + legacyFilePath + ", since " + projectType.String() + " is already configured in " + pcm.unifiedConfigFilePath + ".")
+			continue
+		}
+		if err = mergeLegacyProjectConfig(unifiedConfig, projectType, legacyFilePath); err != nil {
+			return err
+		}
+		pcm.migratedTechnologies = append(pcm.migratedTechnologies, projectType.String())
+	}
+	if len(pcm.migratedTechnologies) == 0 {
+		log.Info("No project config files were found to migrate.")
+		return nil
+	}
+	hoistDefaultServerId(unifiedConfig)
+	if err = project.WriteUnifiedProjectConfig(pcm.unifiedConfigFilePath, unifiedConfig); err != nil {
+		return err
+	}
+	log.Info("Migrated the project config of " + strings.Join(pcm.migratedTechnologies, ", ") + " to " + pcm.unifiedConfigFilePath + ".")
+	return nil
+}
+
+func mergeLegacyProjectConfig(unifiedConfig *project.UnifiedProjectConfig, projectType project.ProjectType, legacyFilePath string) error {
+	content, err := os.ReadFile(legacyFilePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	section := map[string]any{}
+	if err = yaml.Unmarshal(content, &section); err != nil {
+		return errorutils.CheckErrorf("failed parsing the project config file %s: %s", legacyFilePath, err.Error())
+	}
+	if projectType == project.Build {
+		// The build name of the build config is the build name of the whole project.
+		if buildName, ok := section["buildName"].(string); ok && (unifiedConfig.BuildName == "" || unifiedConfig.BuildName == buildName) {
+			unifiedConfig.BuildName = buildName
+			delete(section, "buildName")
+		}
+		delete(section, "version")
+		delete(section, "type")
+		if len(section) == 0 {
+			return nil
+		}
+	}
+	unifiedConfig.SetTechnology(projectType, section)
+	return nil
+}
+
+// If all the resolvers and deployers use the same server, it's set as the default server of the unified config instead.
+func hoistDefaultServerId(unifiedConfig *project.UnifiedProjectConfig) {
+	if unifiedConfig.ServerId != "" {
+		return
+	}
+	var repositories []map[string]any
+	serverId := ""
+	for _, section := range unifiedConfig.Technologies {
+		for _, prefix := range []string{project.ProjectConfigResolverPrefix, project.ProjectConfigDeployerPrefix} {
+			repository, ok := section[prefix].(map[string]any)
+			if !ok {
+				continue
+			}
+			repositoryServerId, _ := repository[project.ProjectConfigServerId].(string)
+			if repositoryServerId == "" || serverId != "" && repositoryServerId != serverId {
+				return
+			}
+			serverId = repositoryServerId
+			repositories = append(repositories, repository)
+		}
+	}
+	if serverId == "" {
+		return
+	}
+	unifiedConfig.ServerId = serverId
+	for _, repository := range repositories {
+		delete(repository, project.ProjectConfigServerId)
+	}
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLegacyProjectConfig(t *testing.T, projectDir, projectType, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".jfrog", "projects"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".jfrog", "projects", projectType+".yaml"), []byte(content), 0644))
+}
+
+func TestProjectConfigMigrateCommand(t *testing.T) {
+	projectDir := t.TempDir()
+	writeLegacyProjectConfig(t, projectDir, "npm", "version: 1\ntype: npm\nresolver:\n  serverId: server\n  repo: npm-virtual\ndeployer:\n  serverId: server\n  repo: npm-local\n")
+	writeLegacyProjectConfig(t, projectDir, "go", "version: 1\ntype: go\nresolver:\n  serverId: server\n  repo: go-virtual\n")
+	writeLegacyProjectConfig(t, projectDir, "build", "version: 1\ntype: build\nbuildName: my-build\n")
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	defer testsutils.ChangeDirWithCallback(t, wd, projectDir)()
+
+	migrateCmd := NewProjectConfigMigrateCommand()
+	require.NoError(t, migrateCmd.Run())
+	assert.ElementsMatch(t, []string{"go", "npm", "build"}, migrateCmd.MigratedTechnologies())
+
+	unifiedConfig, err := project.ReadUnifiedProjectConfig(migrateCmd.UnifiedConfigFilePath())
+	require.NoError(t, err)
+	// The server shared by all the repositories becomes the default server.
+	assert.Equal(t, "server", unifiedConfig.ServerId)
+	assert.Equal(t, "my-build", unifiedConfig.BuildName)
+	assert.Equal(t, map[string]map[string]any{
+		"npm": {"resolver": map[string]any{"repo": "npm-virtual"}, "deployer": map[string]any{"repo": "npm-local"}},
+		"go":  {"resolver": map[string]any{"repo": "go-virtual"}},
+	}, unifiedConfig.Technologies)
+	// The legacy files are kept.
+	assert.FileExists(t, filepath.Join(projectDir, ".jfrog", "projects", "npm.yaml"))
+
+	// The migrated config is read the same way as the legacy files.
+	vConfig, _, err := project.GetProjectConfig(project.Npm)
+	require.NoError(t, err)
+	require.NotNil(t, vConfig)
+	assert.Equal(t, "server", vConfig.GetString("deployer.serverId"))
+	assert.Equal(t, "npm-local", vConfig.GetString("deployer.repo"))
+
+	// Technologies already in the unified config aren't overridden.
+	writeLegacyProjectConfig(t, projectDir, "npm", "version: 1\ntype: npm\nresolver:\n  serverId: other-server\n  repo: other-repo\n")
+	writeLegacyProjectConfig(t, projectDir, "pip", "version: 1\ntype: pip\nresolver:\n  serverId: other-server\n  repo: pip-virtual\n")
+	migrateCmd = NewProjectConfigMigrateCommand()
+	require.NoError(t, migrateCmd.Run())
+	assert.Equal(t, []string{"pip"}, migrateCmd.MigratedTechnologies())
+	unifiedConfig, err = project.ReadUnifiedProjectConfig(migrateCmd.UnifiedConfigFilePath())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"repo": "npm-virtual"}, unifiedConfig.Technologies["npm"]["resolver"])
+	assert.Equal(t, map[string]any{"serverId": "other-server", "repo": "pip-virtual"}, unifiedConfig.Technologies["pip"]["resolver"])
+	assert.FileExists(t, filepath.Join(projectDir, ".jfrog", "projects", "pip.yaml"))
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ProjectConfigSchemaCommand prints the JSON Schema of a technology's per-type config file,
+// or of the unified project config file if no technology is set.
+type ProjectConfigSchemaCommand struct {
+	technology string
+}
+
+func NewProjectConfigSchemaCommand() *ProjectConfigSchemaCommand {
+	return &ProjectConfigSchemaCommand{}
+}
+
+func (pcs *ProjectConfigSchemaCommand) SetTechnology(technology string) *ProjectConfigSchemaCommand {
+	pcs.technology = technology
+	return pcs
+}
+
+func (pcs *ProjectConfigSchemaCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (pcs *ProjectConfigSchemaCommand) CommandName() string {
+	return "project_config_schema"
+}
+
+func (pcs *ProjectConfigSchemaCommand) Run() error {
+	schema, err := pcs.getSchema()
+	if err != nil {
+		return err
+	}
+	content, err := schema.Json()
+	if err != nil {
+		return err
+	}
+	log.Output(content)
+	return nil
+}
+
+func (pcs *ProjectConfigSchemaCommand) getSchema() (*project.ConfigSchema, error) {
+	if pcs.technology == "" {
+		return project.GetUnifiedProjectConfigSchema(), nil
+	}
+	projectType := project.FromString(pcs.technology)
+	if projectType < 0 {
+		return nil, errorutils.CheckErrorf("unsupported technology '%s'. The supported technologies are: %s", pcs.technology, strings.Join(project.ProjectTypes, ", "))
+	}
+	return project.GetProjectConfigSchema(projectType), nil
+}
@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectConfigSchemaCommand(t *testing.T) {
+	// Without a technology, the schema of the unified config file is printed.
+	schemaCmd := NewProjectConfigSchemaCommand()
+	schema, err := schemaCmd.getSchema()
+	require.NoError(t, err)
+	assert.Equal(t, project.GetUnifiedProjectConfigSchema(), schema)
+	assert.NoError(t, schemaCmd.Run())
+
+	schema, err = schemaCmd.SetTechnology("maven").getSchema()
+	require.NoError(t, err)
+	assert.Equal(t, project.GetProjectConfigSchema(project.Maven), schema)
+
+	assert.ErrorContains(t, schemaCmd.SetTechnology("cobol").Run(), "unsupported technology 'cobol'")
+}
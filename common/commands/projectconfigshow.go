@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ProjectConfigShowCommand prints the effective project config of a technology in the working dir,
+// merged from the .jfrog directories of the working dir and its parent dirs, and the config file which set each value.
+// The parent dirs are merged up to the root of the git repository. Outside a git repository, only the nearest .jfrog directory is used.
+type ProjectConfigShowCommand struct {
+	projectType  project.ProjectType
+	outputFormat format.OutputFormat
+}
+
+type effectiveConfigValueRow struct {
+	Key    string `col-name:"Key" json:"key"`
+	Value  string `col-name:"Value" json:"value"`
+	Source string `col-name:"Source" json:"source"`
+}
+
+func NewProjectConfigShowCommand() *ProjectConfigShowCommand {
+	return &ProjectConfigShowCommand{outputFormat: format.Table}
+}
+
+func (pcs *ProjectConfigShowCommand) SetProjectType(projectType project.ProjectType) *ProjectConfigShowCommand {
+	pcs.projectType = projectType
+	return pcs
+}
+
+func (pcs *ProjectConfigShowCommand) SetOutputFormat(outputFormat format.OutputFormat) *ProjectConfigShowCommand {
+	pcs.outputFormat = outputFormat
+	return pcs
+}
+
+func (pcs *ProjectConfigShowCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (pcs *ProjectConfigShowCommand) CommandName() string {
+	return "project_config_show"
+}
+
+func (pcs *ProjectConfigShowCommand) Run() error {
+	effectiveConfig, err := project.GetEffectiveProjectConfig(pcs.projectType)
+	if err != nil {
+		return err
+	}
+	if effectiveConfig == nil {
+		return errorutils.CheckErrorf("%s project configuration does not exist", pcs.projectType.String())
+	}
+	var rows []effectiveConfigValueRow
+	for _, key := range effectiveConfig.Keys() {
+		rows = append(rows, effectiveConfigValueRow{Key: key, Value: fmt.Sprint(effectiveConfig.Get(key)), Source: effectiveConfig.Sources[key]})
+	}
+	switch pcs.outputFormat {
+	case format.Json:
+		jsonContent, err := coreutils.GetJsonIndent(rows)
+		if err != nil {
+			return err
+		}
+		log.Output(jsonContent)
+		return nil
+	case format.Table, format.None:
+		return coreutils.PrintTable(rows, "Effective "+pcs.projectType.String()+" configuration", "", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. The supported formats are: %s", pcs.outputFormat, format.Join([]format.OutputFormat{format.Table, format.Json}))
+	}
+}
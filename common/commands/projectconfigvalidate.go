@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/slices"
+)
+
+// ProjectConfigValidateCommand validates project config files against their JSON Schema, and validates the server IDs they use.
+// If checking the repositories, the existence of the repositories is validated on the servers.
+type ProjectConfigValidateCommand struct {
+	configFilePaths  []string
+	checkRepos       bool
+	outputFormat     format.OutputFormat
+	validationErrors []project.ConfigValidationError
+}
+
+func NewProjectConfigValidateCommand() *ProjectConfigValidateCommand {
+	return &ProjectConfigValidateCommand{outputFormat: format.Table}
+}
+
+// The config files to validate. If not set, the project config files of the working dir and its parent dirs are validated.
+func (pcv *ProjectConfigValidateCommand) SetConfigFilePaths(configFilePaths []string) *ProjectConfigValidateCommand {
+	pcv.configFilePaths = configFilePaths
+	return pcv
+}
+
+func (pcv *ProjectConfigValidateCommand) SetCheckRepos(checkRepos bool) *ProjectConfigValidateCommand {
+	pcv.checkRepos = checkRepos
+	return pcv
+}
+
+func (pcv *ProjectConfigValidateCommand) SetOutputFormat(outputFormat format.OutputFormat) *ProjectConfigValidateCommand {
+	pcv.outputFormat = outputFormat
+	return pcv
+}
+
+// Returns the problems found by the last run.
+func (pcv *ProjectConfigValidateCommand) ValidationErrors() []project.ConfigValidationError {
+	return pcv.validationErrors
+}
+
+func (pcv *ProjectConfigValidateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (pcv *ProjectConfigValidateCommand) CommandName() string {
+	return "project_config_validate"
+}
+
+func (pcv *ProjectConfigValidateCommand) Run() (err error) {
+	configFilePaths := pcv.configFilePaths
+	if len(configFilePaths) == 0 {
+		if configFilePaths, err = project.GetProjectConfigFilePaths(); err != nil {
+			return
+		}
+		if len(configFilePaths) == 0 {
+			return errorutils.CheckErrorf("no project config files were found in the .jfrog directory of the working dir or its parent dirs")
+		}
+	}
+	serverValidator, err := newConfigServerValidator(pcv.checkRepos)
+	if err != nil {
+		return
+	}
+	pcv.validationErrors = nil
+	for _, configFilePath := range configFilePaths {
+		var result *project.ConfigValidationResult
+		if result, err = project.ValidateProjectConfigFile(configFilePath); err != nil {
+			return
+		}
+		pcv.validationErrors = append(pcv.validationErrors, result.Errors...)
+		pcv.validationErrors = append(pcv.validationErrors, serverValidator.validate(result.Repositories)...)
+	}
+	if err = pcv.printValidationErrors(); err != nil {
+		return
+	}
+	if len(pcv.validationErrors) > 0 {
+		return errorutils.CheckErrorf("found %d problems in the project config files", len(pcv.validationErrors))
+	}
+	log.Info("The project config files are valid.")
+	return
+}
+
+func (pcv *ProjectConfigValidateCommand) printValidationErrors() error {
+	switch pcv.outputFormat {
+	case format.Json:
+		validationErrors := pcv.validationErrors
+		if validationErrors == nil {
+			validationErrors = []project.ConfigValidationError{}
+		}
+		jsonContent, err := coreutils.GetJsonIndent(validationErrors)
+		if err != nil {
+			return err
+		}
+		log.Output(jsonContent)
+		return nil
+	case format.Table, format.None:
+		for _, validationError := range pcv.validationErrors {
+			log.Error(validationError.String())
+		}
+		return nil
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. The supported formats are: %s", pcv.outputFormat, format.Join([]format.OutputFormat{format.Table, format.Json}))
+	}
+}
+
+// Validates the servers of the repositories in the project config files, and optionally the existence of the repositories.
+// Like when resolving the repositories, a repository without a server ID uses the default server of the project's unified config file.
+type configServerValidator struct {
+	serverIds  []string
+	checkRepos bool
+	// The services managers of the servers, created when checking their first repository. A nil manager means the server can't be reached.
+	servicesManagers map[string]artifactory.ArtifactoryServicesManager
+}
+
+func newConfigServerValidator(checkRepos bool) (*configServerValidator, error) {
+	serverIds, _, err := getServersIdAndDefault()
+	if err != nil {
+		return nil, err
+	}
+	return &configServerValidator{serverIds: serverIds, checkRepos: checkRepos, servicesManagers: map[string]artifactory.ArtifactoryServicesManager{}}, nil
+}
+
+func (validator *configServerValidator) validate(repositories []project.ConfigRepositoryReference) []project.ConfigValidationError {
+	var validationErrors []project.ConfigValidationError
+	reportedServerIds := map[string]bool{}
+	for _, repository := range repositories {
+		serverId, serverIdKey, serverIdLine, serverIdColumn := repository.ServerId, repository.ServerIdKey, repository.ServerIdLine, repository.ServerIdColumn
+		if serverId == "" {
+			// Like when resolving the repository, fall back to the default server of the project's unified config file.
+			var err error
+			if serverId, err = project.GetProjectDefaultServerId(repository.FilePath); err != nil {
+				validationErrors = append(validationErrors, project.ConfigValidationError{FilePath: repository.FilePath, Line: repository.Line, Column: repository.Column, Key: repository.Key,
+					Message: fmt.Sprintf("failed reading the default server ID of the project: %s", err.Error())})
+				continue
+			}
+			if serverId == "" {
+				validationErrors = append(validationErrors, project.ConfigValidationError{FilePath: repository.FilePath, Line: repository.Line, Column: repository.Column, Key: repository.Key,
+					Message: fmt.Sprintf("the repository has no server ID, and the project config has no default %s", project.ProjectConfigServerId)})
+				continue
+			}
+			serverIdKey, serverIdLine, serverIdColumn = repository.Key, repository.Line, repository.Column
+		}
+		if !slices.Contains(validator.serverIds, serverId) {
+			// The server ID is reported once, even if used by several repositories.
+			location := fmt.Sprintf("%s:%d:%d", repository.FilePath, serverIdLine, serverIdColumn)
+			if !reportedServerIds[location] {
+				reportedServerIds[location] = true
+				validationErrors = append(validationErrors, project.ConfigValidationError{FilePath: repository.FilePath, Line: serverIdLine, Column: serverIdColumn, Key: serverIdKey,
+					Message: fmt.Sprintf("server ID '%s' is not configured. The configured server IDs are: %s", serverId, strings.Join(validator.serverIds, ", "))})
+			}
+			continue
+		}
+		if validator.checkRepos {
+			if message := validator.checkRepoExists(serverId, repository.Repo); message != "" {
+				validationErrors = append(validationErrors, project.ConfigValidationError{FilePath: repository.FilePath, Line: repository.Line, Column: repository.Column, Key: repository.Key, Message: message})
+			}
+		}
+	}
+	return validationErrors
+}
+
+// Returns a message describing the problem, if the repository doesn't exist on the server or its existence can't be checked.
+func (validator *configServerValidator) checkRepoExists(serverId, repo string) string {
+	servicesManager, exists := validator.servicesManagers[serverId]
+	if !exists {
+		serverDetails, err := config.GetSpecificConfig(serverId, false, true)
+		if err == nil {
+			servicesManager, err = utils.CreateServiceManager(serverDetails, 3, 0, false)
+		}
+		if err != nil {
+			log.Debug(fmt.Sprintf("Failed creating a services manager for server ID '%s': %s", serverId, err.Error()))
+		}
+		validator.servicesManagers[serverId] = servicesManager
+	}
+	if servicesManager == nil {
+		return fmt.Sprintf("couldn't check whether repository '%s' exists, since server ID '%s' can't be used", repo, serverId)
+	}
+	repoExists, err := servicesManager.IsRepoExists(repo)
+	if err != nil {
+		return fmt.Sprintf("failed checking whether repository '%s' exists on server ID '%s': %s", repo, serverId, err.Error())
+	}
+	if !repoExists {
+		return fmt.Sprintf("repository '%s' doesn't exist on server ID '%s'", repo, serverId)
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectConfigValidateCommand(t *testing.T) {
+	inputDetails := tests.CreateTestServerDetails()
+	inputDetails.AccessToken = "token"
+	doConfig(t, testServerId, inputDetails, false, false, false)
+	defer func() {
+		assert.NoError(t, NewConfigCommand(Delete, testServerId).Run())
+	}()
+
+	confFilePath := filepath.Join(t.TempDir(), "npm.yaml")
+	content := "version: 1\ntype: npm\nresolver:\n  serverId: " + testServerId + "\n  repo: npm-virtual\ndeployer:\n  serverId: missing\n  repo: npm-local\n  repoo: npm-local\n"
+	require.NoError(t, os.WriteFile(confFilePath, []byte(content), 0644))
+
+	validateCmd := NewProjectConfigValidateCommand().SetConfigFilePaths([]string{confFilePath})
+	assert.ErrorContains(t, validateCmd.Run(), "found 2 problems")
+	validationErrors := validateCmd.ValidationErrors()
+	require.Len(t, validationErrors, 2)
+	assert.Equal(t, project.ConfigValidationError{FilePath: confFilePath, Line: 9, Column: 3, Key: "deployer.repoo", Message: "unknown key. Did you mean 'repo'?"}, validationErrors[0])
+	assert.Equal(t, 7, validationErrors[1].Line)
+	assert.Equal(t, "deployer.serverId", validationErrors[1].Key)
+	assert.Contains(t, validationErrors[1].Message, "server ID 'missing' is not configured")
+
+	// A valid config file.
+	content = "version: 1\ntype: npm\nresolver:\n  serverId: " + testServerId + "\n  repo: npm-virtual\n"
+	require.NoError(t, os.WriteFile(confFilePath, []byte(content), 0644))
+	assert.NoError(t, validateCmd.Run())
+	assert.Empty(t, validateCmd.ValidationErrors())
+}
+
+func TestProjectConfigValidateCommandProjectDefaultServer(t *testing.T) {
+	inputDetails := tests.CreateTestServerDetails()
+	inputDetails.AccessToken = "token"
+	doConfig(t, testServerId, inputDetails, false, false, false)
+	defer func() {
+		assert.NoError(t, NewConfigCommand(Delete, testServerId).Run())
+	}()
+
+	jfrogDir := filepath.Join(t.TempDir(), ".jfrog")
+	require.NoError(t, os.MkdirAll(filepath.Join(jfrogDir, "projects"), 0755))
+	confFilePath := filepath.Join(jfrogDir, "projects", "npm.yaml")
+	require.NoError(t, os.WriteFile(confFilePath, []byte("version: 1\ntype: npm\nresolver:\n  repo: npm-virtual\n"), 0644))
+
+	// The default server of the CLI isn't used by the repositories without a server ID.
+	validateCmd := NewProjectConfigValidateCommand().SetConfigFilePaths([]string{confFilePath})
+	assert.ErrorContains(t, validateCmd.Run(), "found 1 problems")
+	validationErrors := validateCmd.ValidationErrors()
+	require.Len(t, validationErrors, 1)
+	assert.Equal(t, "resolver.repo", validationErrors[0].Key)
+	assert.Contains(t, validationErrors[0].Message, "the project config has no default serverId")
+
+	// The default server of the unified config file of the project is used.
+	unifiedFilePath := filepath.Join(jfrogDir, project.UnifiedProjectConfigFileName)
+	require.NoError(t, os.WriteFile(unifiedFilePath, []byte("version: 1\nserverId: "+testServerId+"\n"), 0644))
+	assert.NoError(t, validateCmd.Run())
+	assert.Empty(t, validateCmd.ValidationErrors())
+
+	require.NoError(t, os.WriteFile(unifiedFilePath, []byte("version: 1\nserverId: missing\n"), 0644))
+	assert.ErrorContains(t, validateCmd.Run(), "found 1 problems")
+	validationErrors = validateCmd.ValidationErrors()
+	require.Len(t, validationErrors, 1)
+	assert.Equal(t, "resolver.repo", validationErrors[0].Key)
+	assert.Contains(t, validationErrors[0].Message, "server ID 'missing' is not configured")
+}
@@ -0,0 +1,237 @@
+package project
+
+import (
+	"sort"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+const projectConfigSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	// The pattern of the names which package managers give to repositories, such as Conan remotes, Poetry sources, UV indexes and CocoaPods specs repositories.
+	PackageManagerRepoNamePattern = `^[A-Za-z0-9][A-Za-z0-9_.-]*$`
+	ConanRemoteNamePattern        = PackageManagerRepoNamePattern
+	ApkBranchPattern              = `^(v[0-9]+\.[0-9]+|edge)$`
+	SwiftScopePattern             = `^[A-Za-z0-9][A-Za-z0-9-]{0,38}$`
+	TerraformNamePattern          = `^[A-Za-z0-9][A-Za-z0-9_-]*$`
+)
+
+var (
+	// The Debian architectures of the deployed Apt packages.
+	AptArchitectures = []string{"all", "amd64", "arm64", "armel", "armhf", "i386", "mips64el", "mipsel", "ppc64el", "riscv64", "s390x"}
+	// The Alpine architectures of the deployed Apk packages.
+	ApkArchitectures = []string{"aarch64", "armhf", "armv7", "loongarch64", "ppc64le", "riscv64", "s390x", "x86", "x86_64"}
+)
+
+// ConfigSchema is a JSON Schema describing a project config file, or a value of the file.
+// Only the keywords used by the project config files are supported.
+type ConfigSchema struct {
+	Schema               string                   `json:"$schema,omitempty"`
+	Title                string                   `json:"title,omitempty"`
+	Description          string                   `json:"description,omitempty"`
+	Type                 string                   `json:"type,omitempty"`
+	Properties           map[string]*ConfigSchema `json:"properties,omitempty"`
+	Required             []string                 `json:"required,omitempty"`
+	AdditionalProperties *bool                    `json:"additionalProperties,omitempty"`
+	Enum                 []any                    `json:"enum,omitempty"`
+	Const                any                      `json:"const,omitempty"`
+	Pattern              string                   `json:"pattern,omitempty"`
+}
+
+// The keys of the project config files, which are validated by the schemas.
+const (
+	projectConfigIncludePatterns       = "includePatterns"
+	projectConfigExcludePatterns       = "excludePatterns"
+	projectConfigSnapshotRepo          = "snapshotRepo"
+	projectConfigDisableSnapshots      = "disableSnapshots"
+	projectConfigSnapshotsUpdatePolicy = "snapshotsUpdatePolicy"
+	projectConfigDeployMavenDesc       = "deployMavenDescriptors"
+	projectConfigDeployIvyDesc         = "deployIvyDescriptors"
+	projectConfigIvyPattern            = "ivyPattern"
+	projectConfigArtifactPattern       = "artifactPattern"
+	projectConfigNugetV2               = "nugetV2"
+	projectConfigUsePlugin             = "usePlugin"
+	projectConfigUseWrapper            = "useWrapper"
+	projectConfigRemoteName            = "remoteName"
+	projectConfigOci                   = "oci"
+	projectConfigDistribution          = "distribution"
+	projectConfigComponent             = "component"
+	projectConfigArchitecture          = "architecture"
+	projectConfigBranch                = "branch"
+	projectConfigSourceName            = "sourceName"
+	projectConfigIndexName             = "indexName"
+	projectConfigSpecsRepoName         = "specsRepoName"
+	projectConfigScope                 = "scope"
+	projectConfigNamespace             = "namespace"
+	projectConfigProvider              = "provider"
+)
+
+var repositoryPropertySchemas = map[string]*ConfigSchema{
+	ProjectConfigServerId:              {Type: "string", Description: "The ID of the JFrog server, as configured by 'jf config add'."},
+	ProjectConfigRepo:                  {Type: "string", Description: "The repository."},
+	ProjectConfigReleaseRepo:           {Type: "string", Description: "The repository of the release artifacts."},
+	projectConfigSnapshotRepo:          {Type: "string", Description: "The repository of the snapshot artifacts."},
+	projectConfigDisableSnapshots:      {Type: "boolean", Description: "Disables the resolution of snapshot artifacts."},
+	projectConfigSnapshotsUpdatePolicy: {Type: "string", Description: "The update policy of the snapshot artifacts.", Pattern: `^(always|daily|never|interval:[0-9]+)$`},
+	projectConfigIncludePatterns:       {Type: "string", Description: "Semicolon separated wildcard patterns of the artifacts to deploy."},
+	projectConfigExcludePatterns:       {Type: "string", Description: "Semicolon separated wildcard patterns of the artifacts not to deploy."},
+	projectConfigDeployMavenDesc:       {Type: "boolean", Description: "Deploys the Maven descriptors (pom.xml) of the artifacts."},
+	projectConfigDeployIvyDesc:         {Type: "boolean", Description: "Deploys the Ivy descriptors (ivy.xml) of the artifacts."},
+	projectConfigIvyPattern:            {Type: "string", Description: "The deployment path pattern of the Ivy descriptors."},
+	projectConfigArtifactPattern:       {Type: "string", Description: "The deployment path pattern of the Ivy artifacts."},
+	projectConfigNugetV2:               {Type: "boolean", Description: "Resolves using the NuGet V2 protocol."},
+	projectConfigRemoteName:            {Type: "string", Description: "The name of the Conan remote of the repository. Defaults to the repository name.", Pattern: ConanRemoteNamePattern},
+	projectConfigOci:                   {Type: "boolean", Description: "The repository is an OCI Helm repository, rather than a classic Helm repository."},
+	projectConfigDistribution:          {Type: "string", Description: "The Debian distribution of the deployed packages, such as 'focal'."},
+	projectConfigComponent:             {Type: "string", Description: "The Debian component of the deployed packages, such as 'main'."},
+	projectConfigArchitecture:          {Type: "string", Description: "The architecture of the deployed packages, such as 'amd64' or 'x86_64'."},
+	projectConfigBranch:                {Type: "string", Description: "The Alpine branch of the deployed packages, such as 'v3.20' or 'edge'.", Pattern: ApkBranchPattern},
+	projectConfigSourceName:            {Type: "string", Description: "The name of the Poetry source of the repository. Defaults to the repository name.", Pattern: PackageManagerRepoNamePattern},
+	projectConfigIndexName:             {Type: "string", Description: "The name of the UV index of the repository. Defaults to the repository name.", Pattern: PackageManagerRepoNamePattern},
+	projectConfigSpecsRepoName:         {Type: "string", Description: "The name of the CocoaPods specs repository added for the repository. Defaults to the repository name.", Pattern: PackageManagerRepoNamePattern},
+	projectConfigScope:                 {Type: "string", Description: "The scope of the Swift packages of the registry.", Pattern: SwiftScopePattern},
+	projectConfigNamespace:             {Type: "string", Description: "The namespace of the deployed Terraform modules.", Pattern: TerraformNamePattern},
+	projectConfigProvider:              {Type: "string", Description: "The provider of the deployed Terraform modules, such as 'aws'.", Pattern: TerraformNamePattern},
+}
+
+// Returns the keys of the resolver and deployer of the technology.
+func getRepositoryKeys(projectType ProjectType) (resolverKeys, deployerKeys []string) {
+	switch projectType {
+	case Build:
+		return nil, nil
+	case Maven:
+		return []string{ProjectConfigServerId, ProjectConfigReleaseRepo, projectConfigSnapshotRepo, projectConfigDisableSnapshots, projectConfigSnapshotsUpdatePolicy},
+			[]string{ProjectConfigServerId, ProjectConfigReleaseRepo, projectConfigSnapshotRepo, projectConfigIncludePatterns, projectConfigExcludePatterns}
+	case Gradle:
+		return []string{ProjectConfigServerId, ProjectConfigRepo},
+			[]string{ProjectConfigServerId, ProjectConfigRepo, projectConfigDeployMavenDesc, projectConfigDeployIvyDesc, projectConfigIvyPattern, projectConfigArtifactPattern}
+	case Nuget, Dotnet:
+		return []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigNugetV2}, []string{ProjectConfigServerId, ProjectConfigRepo}
+	case Conan:
+		return []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigRemoteName}, []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigRemoteName}
+	case Helm:
+		return []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigOci}, []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigOci}
+	case Apt:
+		return []string{ProjectConfigServerId, ProjectConfigRepo},
+			[]string{ProjectConfigServerId, ProjectConfigRepo, projectConfigDistribution, projectConfigComponent, projectConfigArchitecture}
+	case Apk:
+		return []string{ProjectConfigServerId, ProjectConfigRepo}, []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigBranch, projectConfigArchitecture}
+	case Poetry:
+		return []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigSourceName}, nil
+	case UV:
+		return []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigIndexName}, []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigIndexName}
+	case Cocoapods:
+		return []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigSpecsRepoName}, []string{ProjectConfigServerId, ProjectConfigRepo}
+	case Swift:
+		return []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigScope}, []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigScope}
+	case Terraform:
+		return nil, []string{ProjectConfigServerId, ProjectConfigRepo, projectConfigNamespace, projectConfigProvider}
+	case Twine:
+		return nil, []string{ProjectConfigServerId, ProjectConfigRepo}
+	default:
+		// Npm, Pnpm, Yarn, Go, Pip, Pipenv, Ruby, Docker and Podman have no type-specific repository fields.
+		return []string{ProjectConfigServerId, ProjectConfigRepo}, []string{ProjectConfigServerId, ProjectConfigRepo}
+	}
+}
+
+// Returns whether the config of the technology has a resolver and a deployer.
+func HasRepositories(projectType ProjectType) (hasResolver, hasDeployer bool) {
+	resolverKeys, deployerKeys := getRepositoryKeys(projectType)
+	return len(resolverKeys) > 0, len(deployerKeys) > 0
+}
+
+// Returns the JSON Schema of the per-type config file of the technology, .jfrog/projects/<type>.yaml.
+func GetProjectConfigSchema(projectType ProjectType) *ConfigSchema {
+	schema := getTechnologySchema(projectType)
+	schema.Schema = projectConfigSchemaDraft
+	schema.Title = "JFrog CLI " + projectType.String() + " project configuration"
+	schema.Properties[projectConfigVersionKey] = &ConfigSchema{Type: "integer", Description: "The version of the config file.", Enum: []any{projectConfigVersion}}
+	schema.Properties[projectConfigTypeKey] = &ConfigSchema{Type: "string", Description: "The technology of the config file.", Const: projectType.String()}
+	schema.Required = []string{projectConfigVersionKey, projectConfigTypeKey}
+	return schema
+}
+
+// Returns the JSON Schema of the unified project config file, .jfrog/project.yaml.
+func GetUnifiedProjectConfigSchema() *ConfigSchema {
+	technologies := map[string]*ConfigSchema{}
+	for i := range ProjectTypes {
+		technologies[ProjectTypes[i]] = getTechnologySchema(ProjectType(i))
+	}
+	return &ConfigSchema{
+		Schema: projectConfigSchemaDraft,
+		Title:  "JFrog CLI unified project configuration",
+		Type:   "object",
+		Properties: map[string]*ConfigSchema{
+			projectConfigVersionKey:   {Type: "integer", Description: "The version of the config file.", Enum: []any{UnifiedProjectConfigVersion}},
+			ProjectConfigServerId:     {Type: "string", Description: "The default server of the resolvers and deployers which don't set a server ID."},
+			"project":                 {Type: "string", Description: "The JFrog project key."},
+			projectConfigBuildNameKey: {Type: "string", Description: "The build name of the project."},
+			"technologies":            {Type: "object", Description: "The config of each technology of the project.", Properties: technologies, AdditionalProperties: newFalse()},
+		},
+		AdditionalProperties: newFalse(),
+	}
+}
+
+// Returns the schema of the technology's config, without the version and type.
+func getTechnologySchema(projectType ProjectType) *ConfigSchema {
+	schema := &ConfigSchema{Type: "object", Properties: map[string]*ConfigSchema{}, AdditionalProperties: newFalse()}
+	resolverKeys, deployerKeys := getRepositoryKeys(projectType)
+	if len(resolverKeys) > 0 {
+		schema.Properties[ProjectConfigResolverPrefix] = getRepositorySchema("The repository to resolve the dependencies from.", resolverKeys)
+	}
+	if len(deployerKeys) > 0 {
+		schema.Properties[ProjectConfigDeployerPrefix] = getRepositorySchema("The repository to deploy the artifacts to.", deployerKeys)
+	}
+	switch projectType {
+	case Build:
+		schema.Properties[projectConfigBuildNameKey] = &ConfigSchema{Type: "string", Description: "The build name of the project."}
+	case Maven:
+		schema.Properties[projectConfigUseWrapper] = &ConfigSchema{Type: "boolean", Description: "Runs the Maven wrapper."}
+	case Gradle:
+		schema.Properties[projectConfigUsePlugin] = &ConfigSchema{Type: "boolean", Description: "Uses the Gradle Artifactory plugin applied by the build script."}
+		schema.Properties[projectConfigUseWrapper] = &ConfigSchema{Type: "boolean", Description: "Runs the Gradle wrapper."}
+	case Apt:
+		setDeployerArchitectures(schema, AptArchitectures)
+	case Apk:
+		setDeployerArchitectures(schema, ApkArchitectures)
+	}
+	return schema
+}
+
+// Restricts the architecture of the deployer to the architectures of the technology.
+func setDeployerArchitectures(schema *ConfigSchema, architectures []string) {
+	architectureSchema := *repositoryPropertySchemas[projectConfigArchitecture]
+	for _, architecture := range architectures {
+		architectureSchema.Enum = append(architectureSchema.Enum, architecture)
+	}
+	schema.Properties[ProjectConfigDeployerPrefix].Properties[projectConfigArchitecture] = &architectureSchema
+}
+
+func getRepositorySchema(description string, keys []string) *ConfigSchema {
+	properties := make(map[string]*ConfigSchema, len(keys))
+	for _, key := range keys {
+		properties[key] = repositoryPropertySchemas[key]
+	}
+	return &ConfigSchema{Type: "object", Description: description, Properties: properties, AdditionalProperties: newFalse()}
+}
+
+// Returns the indented JSON of the schema, as printed by the project config schema command and saved in the schemas directory.
+func (schema *ConfigSchema) Json() (string, error) {
+	return coreutils.GetJsonIndent(schema)
+}
+
+// Returns the keys of the properties of the schema, sorted.
+func (schema *ConfigSchema) propertyKeys() []string {
+	keys := make([]string, 0, len(schema.Properties))
+	for key := range schema.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func newFalse() *bool {
+	value := false
+	return &value
+}
@@ -0,0 +1,71 @@
+package project
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Run the tests with -update-schemas to regenerate the schema files after changing the schemas.
+var updateSchemas = flag.Bool("update-schemas", false, "Regenerate the JSON Schema files of the project config files")
+
+const schemasDir = "schemas"
+
+// The schema files are generated from the schemas, and must be regenerated when the schemas change.
+func TestProjectConfigSchemaFiles(t *testing.T) {
+	expectedFiles := map[string]*ConfigSchema{strings.TrimSuffix(UnifiedProjectConfigFileName, ".yaml") + ".schema.json": GetUnifiedProjectConfigSchema()}
+	for i, projectType := range ProjectTypes {
+		expectedFiles[projectType+".schema.json"] = GetProjectConfigSchema(ProjectType(i))
+	}
+	if *updateSchemas {
+		require.NoError(t, os.MkdirAll(schemasDir, 0755))
+	}
+	for fileName, schema := range expectedFiles {
+		content, err := schema.Json()
+		require.NoError(t, err)
+		filePath := filepath.Join(schemasDir, fileName)
+		if *updateSchemas {
+			require.NoError(t, os.WriteFile(filePath, []byte(content+"\n"), 0644))
+			continue
+		}
+		fileContent, err := os.ReadFile(filePath)
+		require.NoError(t, err, "run the tests with -update-schemas to generate the schema files")
+		assert.Equal(t, content+"\n", string(fileContent), "the schema file %s is outdated. Run the tests with -update-schemas to regenerate it", fileName)
+	}
+
+	// No schema files are left for removed technologies.
+	entries, err := os.ReadDir(schemasDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.Contains(t, expectedFiles, entry.Name())
+	}
+}
+
+// Every field of the Repository struct has a schema of the same type, and every repository key in the schemas is a field of the struct.
+func TestRepositorySchemaMatchesStruct(t *testing.T) {
+	structKeys := map[string]bool{}
+	repositoryType := reflect.TypeOf(Repository{})
+	for i := 0; i < repositoryType.NumField(); i++ {
+		field := repositoryType.Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		structKeys[key] = true
+		schema, exists := repositoryPropertySchemas[key]
+		if !assert.True(t, exists, "the repository field %s has no schema", field.Name) {
+			continue
+		}
+		expectedType := "string"
+		if field.Type.Kind() == reflect.Bool {
+			expectedType = "boolean"
+		}
+		assert.Equal(t, expectedType, schema.Type, "the schema type of the repository field %s", field.Name)
+	}
+	for key := range repositoryPropertySchemas {
+		assert.True(t, structKeys[key], "the repository schema key %s isn't a field of the Repository struct", key)
+	}
+}
@@ -0,0 +1,315 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigValidationError is a problem in a project config file, located by its line and column.
+type ConfigValidationError struct {
+	FilePath string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	// The dotted path of the invalid value, such as "resolver.repo".
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
+func (validationError ConfigValidationError) String() string {
+	location := validationError.FilePath
+	if validationError.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", location, validationError.Line, validationError.Column)
+	}
+	if validationError.Key == "" {
+		return location + ": " + validationError.Message
+	}
+	return location + ": " + validationError.Key + ": " + validationError.Message
+}
+
+// ConfigRepositoryReference is a repository of a resolver or a deployer in a project config file, and the server it belongs to.
+type ConfigRepositoryReference struct {
+	FilePath string
+	// The dotted path of the repository, such as "resolver.releaseRepo".
+	Key    string
+	Repo   string
+	Line   int
+	Column int
+	// The server ID of the resolver or the deployer, or the default server of the unified project config file.
+	// Empty if no server ID is set.
+	ServerId       string
+	ServerIdKey    string
+	ServerIdLine   int
+	ServerIdColumn int
+}
+
+// ConfigValidationResult is the result of validating a project config file against its schema.
+type ConfigValidationResult struct {
+	FilePath     string
+	Errors       []ConfigValidationError
+	Repositories []ConfigRepositoryReference
+}
+
+// Validates the project config file against its JSON Schema. The file is either a per-type config file, or a unified project config file.
+// Unknown keys, values of the wrong type and invalid values are reported, with suggestions for misspelled keys.
+// YAML syntax errors are reported as validation errors, while errors reading the file are returned.
+func ValidateProjectConfigFile(confFilePath string) (*ConfigValidationResult, error) {
+	content, err := os.ReadFile(confFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	result := &ConfigValidationResult{FilePath: confFilePath}
+	var document yaml.Node
+	if err = yaml.Unmarshal(content, &document); err != nil {
+		var line int
+		if _, scanErr := fmt.Sscanf(err.Error(), "yaml: line %d:", &line); scanErr != nil {
+			line = 0
+		}
+		result.Errors = append(result.Errors, ConfigValidationError{FilePath: confFilePath, Line: line, Message: err.Error()})
+		return result, nil
+	}
+	if len(document.Content) == 0 {
+		result.Errors = append(result.Errors, ConfigValidationError{FilePath: confFilePath, Message: "the config file is empty"})
+		return result, nil
+	}
+	root := document.Content[0]
+	validator := &configValidator{result: result}
+	if filepath.Base(confFilePath) == UnifiedProjectConfigFileName {
+		validator.validateNode(root, GetUnifiedProjectConfigSchema(), "")
+		validator.collectUnifiedConfigRepositories(root)
+		return result, nil
+	}
+	projectType := getPerTypeConfigProjectType(confFilePath, root)
+	if projectType < 0 {
+		result.Errors = append(result.Errors, ConfigValidationError{FilePath: confFilePath, Line: root.Line, Column: root.Column,
+			Message: fmt.Sprintf("unknown project type. The config file should be named <type>.yaml, with one of the types: %s", strings.Join(ProjectTypes, ", "))})
+		return result, nil
+	}
+	validator.validateNode(root, GetProjectConfigSchema(projectType), "")
+	validator.collectRepositories(root, "", nil)
+	return result, nil
+}
+
+// Returns the paths of the project config files of the working dir, in the .jfrog directories of the working dir and its parent dirs.
+// The parent dirs are searched up to the root of the git repository, or up to the root dir if the working dir isn't in a git repository.
+func GetProjectConfigFilePaths() ([]string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	jfrogDirs, _, err := getProjectJfrogDirs(wd)
+	if err != nil {
+		return nil, err
+	}
+	var confFilePaths []string
+	for _, jfrogDir := range jfrogDirs {
+		unifiedFilePath := filepath.Join(jfrogDir, UnifiedProjectConfigFileName)
+		if _, err = os.Stat(unifiedFilePath); err == nil {
+			confFilePaths = append(confFilePaths, unifiedFilePath)
+		}
+		perTypeFilePaths, err := filepath.Glob(filepath.Join(jfrogDir, "projects", "*.yaml"))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		confFilePaths = append(confFilePaths, perTypeFilePaths...)
+	}
+	return confFilePaths, nil
+}
+
+// Returns the project type of a per-type config file by its type, or by its name if the type isn't set.
+func getPerTypeConfigProjectType(confFilePath string, root *yaml.Node) ProjectType {
+	if typeNode := getMappingValue(root, projectConfigTypeKey); typeNode != nil && typeNode.Kind == yaml.ScalarNode {
+		if projectType := FromString(typeNode.Value); projectType >= 0 {
+			return projectType
+		}
+	}
+	return FromString(strings.TrimSuffix(filepath.Base(confFilePath), filepath.Ext(confFilePath)))
+}
+
+type configValidator struct {
+	result *ConfigValidationResult
+}
+
+func (validator *configValidator) addError(node *yaml.Node, key, message string) {
+	validator.result.Errors = append(validator.result.Errors, ConfigValidationError{FilePath: validator.result.FilePath, Line: node.Line, Column: node.Column, Key: key, Message: message})
+}
+
+func (validator *configValidator) validateNode(node *yaml.Node, schema *ConfigSchema, key string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			validator.addError(node, key, "expected an object")
+			return
+		}
+		validator.validateMapping(node, schema, key)
+		return
+	case "string", "integer", "boolean":
+		if node.Kind != yaml.ScalarNode || node.ShortTag() != getYamlTag(schema.Type) {
+			validator.addError(node, key, fmt.Sprintf("expected a %s, got '%s'", schema.Type, getNodeDescription(node)))
+			return
+		}
+	}
+	if schema.Const != nil && node.Value != fmt.Sprint(schema.Const) {
+		validator.addError(node, key, fmt.Sprintf("expected '%v', got '%s'", schema.Const, node.Value))
+	}
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(value any) bool { return fmt.Sprint(value) == node.Value }) {
+		validator.addError(node, key, fmt.Sprintf("unsupported value '%s'. The supported values are: %s", node.Value, formatEnum(schema.Enum)))
+	}
+	if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(node.Value) {
+		validator.addError(node, key, fmt.Sprintf("invalid value '%s', which should match the pattern %s", node.Value, schema.Pattern))
+	}
+}
+
+func (validator *configValidator) validateMapping(node *yaml.Node, schema *ConfigSchema, key string) {
+	keyPrefix := key
+	if keyPrefix != "" {
+		keyPrefix += "."
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		propertySchema, exists := schema.Properties[keyNode.Value]
+		if !exists {
+			if schema.AdditionalProperties == nil || *schema.AdditionalProperties {
+				continue
+			}
+			message := "unknown key"
+			if suggestion := suggestKey(keyNode.Value, schema.propertyKeys()); suggestion != "" {
+				message += fmt.Sprintf(". Did you mean '%s'?", suggestion)
+			} else {
+				message += ". The supported keys are: " + strings.Join(schema.propertyKeys(), ", ")
+			}
+			validator.addError(keyNode, keyPrefix+keyNode.Value, message)
+			continue
+		}
+		validator.validateNode(valueNode, propertySchema, keyPrefix+keyNode.Value)
+	}
+	for _, required := range schema.Required {
+		if getMappingValue(node, required) == nil {
+			validator.addError(node, keyPrefix+required, "missing required key")
+		}
+	}
+}
+
+// Collects the repositories of the resolver and the deployer of a technology's config.
+// The default server is used by resolvers and deployers which don't set a server ID.
+func (validator *configValidator) collectRepositories(technologyNode *yaml.Node, keyPrefix string, defaultServerId *yaml.Node) {
+	for _, prefix := range []string{ProjectConfigResolverPrefix, ProjectConfigDeployerPrefix} {
+		repositoryNode := getMappingValue(technologyNode, prefix)
+		if repositoryNode == nil || repositoryNode.Kind != yaml.MappingNode {
+			continue
+		}
+		serverIdNode, serverIdKey := getMappingValue(repositoryNode, ProjectConfigServerId), keyPrefix+prefix+"."+ProjectConfigServerId
+		if serverIdNode == nil && defaultServerId != nil {
+			serverIdNode, serverIdKey = defaultServerId, ProjectConfigServerId
+		}
+		for _, repoKey := range []string{ProjectConfigRepo, ProjectConfigReleaseRepo, projectConfigSnapshotRepo} {
+			repoNode := getMappingValue(repositoryNode, repoKey)
+			if repoNode == nil || repoNode.Kind != yaml.ScalarNode || repoNode.Value == "" {
+				continue
+			}
+			reference := ConfigRepositoryReference{FilePath: validator.result.FilePath, Key: keyPrefix + prefix + "." + repoKey, Repo: repoNode.Value, Line: repoNode.Line, Column: repoNode.Column}
+			if serverIdNode != nil && serverIdNode.Kind == yaml.ScalarNode {
+				reference.ServerId, reference.ServerIdKey, reference.ServerIdLine, reference.ServerIdColumn = serverIdNode.Value, serverIdKey, serverIdNode.Line, serverIdNode.Column
+			}
+			validator.result.Repositories = append(validator.result.Repositories, reference)
+		}
+	}
+}
+
+func (validator *configValidator) collectUnifiedConfigRepositories(root *yaml.Node) {
+	technologiesNode := getMappingValue(root, "technologies")
+	if technologiesNode == nil || technologiesNode.Kind != yaml.MappingNode {
+		return
+	}
+	defaultServerId := getMappingValue(root, ProjectConfigServerId)
+	for i := 0; i+1 < len(technologiesNode.Content); i += 2 {
+		validator.collectRepositories(technologiesNode.Content[i+1], "technologies."+technologiesNode.Content[i].Value+".", defaultServerId)
+	}
+}
+
+// Returns the value of the key in the mapping node, or nil if the key doesn't exist.
+func getMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func getYamlTag(schemaType string) string {
+	switch schemaType {
+	case "integer":
+		return "!!int"
+	case "boolean":
+		return "!!bool"
+	default:
+		return "!!str"
+	}
+}
+
+func getNodeDescription(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return node.Value
+	}
+}
+
+func formatEnum(enum []any) string {
+	values := make([]string, 0, len(enum))
+	for _, value := range enum {
+		values = append(values, fmt.Sprint(value))
+	}
+	return strings.Join(values, ", ")
+}
+
+// Returns the supported key closest to the unknown key, if it's close enough to be a misspelling of it.
+func suggestKey(unknownKey string, supportedKeys []string) string {
+	suggestion, minDistance := "", len(unknownKey)/3+1
+	for _, supportedKey := range supportedKeys {
+		if strings.EqualFold(unknownKey, supportedKey) {
+			return supportedKey
+		}
+		if distance := getEditDistance(strings.ToLower(unknownKey), strings.ToLower(supportedKey)); distance <= minDistance {
+			suggestion, minDistance = supportedKey, distance-1
+		}
+	}
+	return suggestion
+}
+
+// Returns the Levenshtein distance between the strings.
+func getEditDistance(first, second string) int {
+	previousRow := make([]int, len(second)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+	for i := 1; i <= len(first); i++ {
+		currentRow := make([]int, len(second)+1)
+		currentRow[0] = i
+		for j := 1; j <= len(second); j++ {
+			substitutionCost := 1
+			if first[i-1] == second[j-1] {
+				substitutionCost = 0
+			}
+			currentRow[j] = min(previousRow[j]+1, currentRow[j-1]+1, previousRow[j-1]+substitutionCost)
+		}
+		previousRow = currentRow
+	}
+	return previousRow[len(second)]
+}
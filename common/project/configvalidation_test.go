@@ -0,0 +1,98 @@
+package project
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProjectConfigFile(t *testing.T) {
+	testCases := []struct {
+		name           string
+		fileName       string
+		content        string
+		expectedErrors []ConfigValidationError
+	}{
+		{"validMaven", "maven.yaml", "version: 1\ntype: maven\nresolver:\n  serverId: server\n  releaseRepo: libs-release\n  snapshotRepo: libs-snapshot\n  snapshotsUpdatePolicy: interval:60\ndeployer:\n  serverId: server\n  releaseRepo: libs-release-local\n  snapshotRepo: libs-snapshot-local\n  includePatterns: '*.jar'\nuseWrapper: true\n", nil},
+		{"validGradle", "gradle.yaml", "version: 1\ntype: gradle\nusePlugin: true\ndeployer:\n  serverId: server\n  repo: gradle-local\n  deployIvyDescriptors: false\n  ivyPattern: '[module]/ivy.xml'\n", nil},
+		{"validApt", "apt.yaml", "version: 1\ntype: apt\ndeployer:\n  serverId: server\n  repo: debian-local\n  distribution: focal\n  component: main\n  architecture: amd64\n", nil},
+		{"invalidApkBranch", "apk.yaml", "version: 1\ntype: apk\ndeployer:\n  serverId: server\n  repo: alpine-local\n  branch: '3.20'\n  architecture: x86_64\n", []ConfigValidationError{
+			{Line: 6, Column: 11, Key: "deployer.branch", Message: "invalid value '3.20', which should match the pattern " + ApkBranchPattern},
+		}},
+		{"unsupportedAptArchitecture", "apt.yaml", "version: 1\ntype: apt\ndeployer:\n  serverId: server\n  repo: debian-local\n  distribution: focal\n  component: main\n  architecture: x86_64\n", []ConfigValidationError{
+			{Line: 8, Column: 17, Key: "deployer.architecture", Message: "unsupported value 'x86_64'. The supported values are: " + strings.Join(AptArchitectures, ", ")},
+		}},
+		{"terraformResolver", "terraform.yaml", "version: 1\ntype: terraform\nresolver:\n  serverId: server\n  repo: terraform-remote\n", []ConfigValidationError{
+			{Line: 3, Column: 1, Key: "resolver", Message: "unknown key. The supported keys are: deployer, type, version"},
+		}},
+		{"misspelledKey", "maven.yaml", "version: 1\ntype: maven\nresolver:\n  serverId: server\n  relaseRepo: libs-release\n", []ConfigValidationError{
+			{Line: 5, Column: 3, Key: "resolver.relaseRepo", Message: "unknown key. Did you mean 'releaseRepo'?"},
+		}},
+		{"keyOfAnotherTechnology", "npm.yaml", "version: 1\ntype: npm\nresolver:\n  serverId: server\n  nugetV2: true\n", []ConfigValidationError{
+			{Line: 5, Column: 3, Key: "resolver.nugetV2", Message: "unknown key. The supported keys are: repo, serverId"},
+		}},
+		{"invalidValues", "maven.yaml", "version: 2\ntype: maven\nresolver:\n  disableSnapshots: sometimes\n  snapshotsUpdatePolicy: weekly\ndeployer: libs-release-local\n", []ConfigValidationError{
+			{Line: 1, Column: 10, Key: "version", Message: "unsupported value '2'. The supported values are: 1"},
+			{Line: 4, Column: 21, Key: "resolver.disableSnapshots", Message: "expected a boolean, got 'sometimes'"},
+			{Line: 5, Column: 26, Key: "resolver.snapshotsUpdatePolicy", Message: "invalid value 'weekly', which should match the pattern ^(always|daily|never|interval:[0-9]+)$"},
+			{Line: 6, Column: 11, Key: "deployer", Message: "expected an object"},
+		}},
+		{"missingVersion", "go.yaml", "type: go\n", []ConfigValidationError{
+			{Line: 1, Column: 1, Key: "version", Message: "missing required key"},
+		}},
+		{"syntaxError", "go.yaml", "version: 1\ntype: go\nresolver:\n\trepo: go-virtual\n", []ConfigValidationError{
+			{Line: 4, Message: "yaml: line 4: found character that cannot start any token"},
+		}},
+		{"unified", UnifiedProjectConfigFileName, "version: 1\nserverId: server\ntechnologies:\n  npm:\n    resolver:\n      repo: npm-virtual\n  mavn: {}\n", []ConfigValidationError{
+			{Line: 7, Column: 3, Key: "technologies.mavn", Message: "unknown key. Did you mean 'maven'?"},
+		}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			confFilePath := filepath.Join(t.TempDir(), testCase.fileName)
+			require.NoError(t, os.WriteFile(confFilePath, []byte(testCase.content), 0644))
+			result, err := ValidateProjectConfigFile(confFilePath)
+			require.NoError(t, err)
+			for i := range testCase.expectedErrors {
+				testCase.expectedErrors[i].FilePath = confFilePath
+			}
+			assert.Equal(t, testCase.expectedErrors, result.Errors)
+		})
+	}
+}
+
+func TestValidateProjectConfigFileRepositories(t *testing.T) {
+	confFilePath := filepath.Join(t.TempDir(), UnifiedProjectConfigFileName)
+	content := "version: 1\nserverId: default-server\ntechnologies:\n  npm:\n    resolver:\n      repo: npm-virtual\n    deployer:\n      serverId: npm-server\n      repo: npm-local\n"
+	require.NoError(t, os.WriteFile(confFilePath, []byte(content), 0644))
+	result, err := ValidateProjectConfigFile(confFilePath)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, []ConfigRepositoryReference{
+		{FilePath: confFilePath, Key: "technologies.npm.resolver.repo", Repo: "npm-virtual", Line: 6, Column: 13,
+			ServerId: "default-server", ServerIdKey: "serverId", ServerIdLine: 2, ServerIdColumn: 11},
+		{FilePath: confFilePath, Key: "technologies.npm.deployer.repo", Repo: "npm-local", Line: 9, Column: 13,
+			ServerId: "npm-server", ServerIdKey: "technologies.npm.deployer.serverId", ServerIdLine: 8, ServerIdColumn: 17},
+	}, result.Repositories)
+}
+
+func TestGetProjectConfigSchema(t *testing.T) {
+	content, err := json.Marshal(GetProjectConfigSchema(Nuget))
+	require.NoError(t, err)
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(content, &schema))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	assert.Equal(t, false, schema["additionalProperties"])
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, "nuget", properties["type"].(map[string]any)["const"])
+	resolverProperties := properties["resolver"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "boolean", resolverProperties["nugetV2"].(map[string]any)["type"])
+
+	// Every technology has a schema in the unified config schema.
+	assert.Len(t, GetUnifiedProjectConfigSchema().Properties["technologies"].Properties, len(ProjectTypes))
+}
@@ -0,0 +1,300 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigLayer is a config file of a technology, contributing to the technology's effective config.
+type ProjectConfigLayer struct {
+	File   ProjectConfigFile
+	Config map[string]any
+}
+
+// EffectiveProjectConfig is the config of a technology, merged from the config files of all the .jfrog directories
+// between the working dir and the root of its git repository.
+// The values of a directory override the values of its parent directories, field by field.
+// If the working dir isn't in a git repository, only the nearest .jfrog directory configuring the technology is used.
+// The JFrog home dir is never merged as a parent directory. Its global config files are used only if no project .jfrog directory configures the technology.
+type EffectiveProjectConfig struct {
+	ProjectType ProjectType
+	// The merged config, in the structure of a per-type config file.
+	Config map[string]any
+	// The config file which set each value, keyed by the dotted path of the value, such as "resolver.repo".
+	Sources map[string]string
+	// The config files of the technology, ordered from the nearest to the farthest.
+	Layers []ProjectConfigLayer
+}
+
+// Returns the effective config of the technology in the working dir.
+// If the technology isn't configured by the project, the global config file is used. If there's no config file at all, nil is returned.
+func GetEffectiveProjectConfig(projectType ProjectType) (*EffectiveProjectConfig, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	layers, err := getProjectConfigLayers(wd, projectType)
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) == 0 {
+		var globalLayer *ProjectConfigLayer
+		if globalLayer, err = getGlobalProjectConfigLayer(projectType); err != nil || globalLayer == nil {
+			return nil, err
+		}
+		layers = append(layers, *globalLayer)
+	}
+	return mergeProjectConfigLayers(projectType, layers), nil
+}
+
+// Returns the repository of the effective config by its prefix (resolver or deployer), or nil if it's not configured.
+func (effectiveConfig *EffectiveProjectConfig) Repository(prefix string) (*Repository, error) {
+	repositoryConfig, ok := effectiveConfig.Config[prefix].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	content, err := yaml.Marshal(repositoryConfig)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	repository := &Repository{}
+	return repository, errorutils.CheckError(yaml.Unmarshal(content, repository))
+}
+
+// Returns the dotted paths of the effective config values, sorted.
+func (effectiveConfig *EffectiveProjectConfig) Keys() []string {
+	keys := make([]string, 0, len(effectiveConfig.Sources))
+	for key := range effectiveConfig.Sources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Returns the value of the effective config by its dotted path.
+func (effectiveConfig *EffectiveProjectConfig) Get(key string) any {
+	var value any = effectiveConfig.Config
+	for _, part := range strings.Split(key, ".") {
+		section, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = section[part]
+	}
+	return value
+}
+
+// Returns the config files of the technology in the .jfrog directories of the dir and its parent dirs, ordered from the nearest to the farthest.
+func getProjectConfigLayers(dir string, projectType ProjectType) ([]ProjectConfigLayer, error) {
+	jfrogDirs, inGitRepo, err := getProjectJfrogDirs(dir)
+	if err != nil {
+		return nil, err
+	}
+	var layers []ProjectConfigLayer
+	for _, jfrogDir := range jfrogDirs {
+		layer, err := readProjectConfigLayer(jfrogDir, projectType)
+		if err != nil {
+			return nil, err
+		}
+		if layer == nil {
+			continue
+		}
+		layers = append(layers, *layer)
+		// Without a git repository, the config files of unrelated parent dirs aren't merged.
+		if !inGitRepo {
+			break
+		}
+	}
+	return layers, nil
+}
+
+// Returns the .jfrog directories of the dir and its parent dirs, ordered from the nearest to the farthest, and whether the dir is in a git repository.
+// The parent dirs are searched up to the root of the git repository of the dir, or up to the root dir if the dir isn't in a git repository.
+// The search stops at the JFrog home dir, such as ~/.jfrog, which isn't a project's .jfrog directory.
+func getProjectJfrogDirs(dir string) (jfrogDirs []string, inGitRepo bool, err error) {
+	for {
+		jfrogDir := filepath.Join(dir, ".jfrog")
+		exists, err := fileutils.IsDirExists(jfrogDir, false)
+		if err != nil {
+			return nil, false, err
+		}
+		if exists {
+			isJfrogHome, err := isJfrogHomeDir(jfrogDir)
+			if err != nil {
+				return nil, false, err
+			}
+			if isJfrogHome {
+				return jfrogDirs, false, nil
+			}
+			jfrogDirs = append(jfrogDirs, jfrogDir)
+		}
+		if _, err = os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return jfrogDirs, true, nil
+		}
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			return jfrogDirs, false, nil
+		}
+		dir = parentDir
+	}
+}
+
+// Reads the config of the technology in the .jfrog directory, from the unified project config file or from the per-type file.
+// Returns nil if the directory doesn't configure the technology.
+func readProjectConfigLayer(jfrogDir string, projectType ProjectType) (*ProjectConfigLayer, error) {
+	unifiedFilePath := filepath.Join(jfrogDir, UnifiedProjectConfigFileName)
+	exists, err := fileutils.IsFileExists(unifiedFilePath, false)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		unifiedConfig, err := ReadUnifiedProjectConfig(unifiedFilePath)
+		if err != nil {
+			return nil, err
+		}
+		if unifiedConfig.HasTechnology(projectType) {
+			configFile := ProjectConfigFile{Path: unifiedFilePath, ProjectType: projectType, Unified: true}
+			return &ProjectConfigLayer{File: configFile, Config: unifiedConfig.GetTechnologyConfig(projectType)}, nil
+		}
+	}
+	return readPerTypeConfigLayer(filepath.Join(jfrogDir, "projects", projectType.String()+".yaml"), projectType)
+}
+
+func getGlobalProjectConfigLayer(projectType ProjectType) (*ProjectConfigLayer, error) {
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return readPerTypeConfigLayer(filepath.Join(jfrogHomeDir, "projects", projectType.String()+".yaml"), projectType)
+}
+
+func readPerTypeConfigLayer(confFilePath string, projectType ProjectType) (*ProjectConfigLayer, error) {
+	exists, err := fileutils.IsFileExists(confFilePath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := os.ReadFile(confFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	config := map[string]any{}
+	if err = yaml.Unmarshal(content, &config); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the project config file %s: %s", confFilePath, err.Error())
+	}
+	return &ProjectConfigLayer{File: ProjectConfigFile{Path: confFilePath, ProjectType: projectType}, Config: config}, nil
+}
+
+// Merges the layers, ordered from the nearest to the farthest, so that the nearer layers override the farther ones.
+func mergeProjectConfigLayers(projectType ProjectType, layers []ProjectConfigLayer) *EffectiveProjectConfig {
+	effectiveConfig := &EffectiveProjectConfig{ProjectType: projectType, Config: map[string]any{}, Sources: map[string]string{}, Layers: layers}
+	for i := len(layers) - 1; i >= 0; i-- {
+		mergeProjectConfigSection(effectiveConfig.Config, layers[i].Config, "", layers[i].File.String(), effectiveConfig.Sources)
+	}
+	return effectiveConfig
+}
+
+func mergeProjectConfigSection(target, source map[string]any, keyPrefix, sourcePath string, sources map[string]string) {
+	for key, value := range source {
+		fullKey := keyPrefix + key
+		sourceSection, isSourceSection := value.(map[string]any)
+		targetSection, isTargetSection := target[key].(map[string]any)
+		if isSourceSection && isTargetSection {
+			mergeProjectConfigSection(targetSection, sourceSection, fullKey+".", sourcePath, sources)
+			continue
+		}
+		// The value replaces the whole value of the farther layers.
+		for existingKey := range sources {
+			if existingKey == fullKey || strings.HasPrefix(existingKey, fullKey+".") {
+				delete(sources, existingKey)
+			}
+		}
+		if isSourceSection {
+			// Copied, so that the merged config doesn't change the layers.
+			targetSection = map[string]any{}
+			mergeProjectConfigSection(targetSection, sourceSection, fullKey+".", sourcePath, sources)
+			target[key] = targetSection
+			continue
+		}
+		target[key] = value
+		sources[fullKey] = sourcePath
+	}
+}
+
+// Returns the path of the .jfrog directory containing the per-type project config file, or false if the path isn't of a per-type project config file.
+func getProjectConfigJfrogDir(configPath string) (jfrogDir string, projectType ProjectType, ok bool) {
+	projectsDir := filepath.Dir(configPath)
+	if filepath.Base(projectsDir) != "projects" || filepath.Base(filepath.Dir(projectsDir)) != ".jfrog" {
+		return "", 0, false
+	}
+	projectType = FromString(strings.TrimSuffix(filepath.Base(configPath), ".yaml"))
+	if projectType < 0 {
+		return "", 0, false
+	}
+	return filepath.Dir(projectsDir), projectType, true
+}
+
+// Returns true if the path is of a unified project config file in a .jfrog directory.
+func isUnifiedProjectConfigFilePath(configPath string) bool {
+	return filepath.Base(configPath) == UnifiedProjectConfigFileName && filepath.Base(filepath.Dir(configPath)) == ".jfrog"
+}
+
+// Returns the effective config of a per-type project config file, merged with the config files of its parent dirs.
+// Returns nil if the path isn't of a project config file, or if no parent dir configures the technology.
+func getHierarchicalProjectConfig(configPath string) (*EffectiveProjectConfig, error) {
+	_, projectType, ok := getProjectConfigJfrogDir(configPath)
+	if !ok {
+		return nil, nil
+	}
+	effectiveConfig, err := getProjectConfigOfFile(ProjectConfigFile{Path: configPath, ProjectType: projectType})
+	if err != nil || effectiveConfig == nil || len(effectiveConfig.Layers) == 1 {
+		return nil, err
+	}
+	return effectiveConfig, nil
+}
+
+// Returns the effective config of the technology, starting from the config file and merged with the config files of its parent dirs.
+// Returns nil if the file isn't one of the config files of the technology in its project.
+func getProjectConfigOfFile(configFile ProjectConfigFile) (*EffectiveProjectConfig, error) {
+	absConfigPath, err := filepath.Abs(configFile.Path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	jfrogDir := filepath.Dir(absConfigPath)
+	if !configFile.Unified {
+		jfrogDir = filepath.Dir(jfrogDir)
+	}
+	layers, err := getProjectConfigLayers(filepath.Dir(jfrogDir), configFile.ProjectType)
+	if err != nil {
+		return nil, err
+	}
+	for i, layer := range layers {
+		if layer.File.Unified == configFile.Unified && layer.File.Path == absConfigPath {
+			return mergeProjectConfigLayers(configFile.ProjectType, layers[i:]), nil
+		}
+	}
+	return nil, nil
+}
+
+// Returns true if the .jfrog directory is the JFrog home dir, which holds the global config files rather than a project's config files.
+func isJfrogHomeDir(jfrogDir string) (bool, error) {
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return false, err
+	}
+	absJfrogHomeDir, err := filepath.Abs(jfrogHomeDir)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	absJfrogDir, err := filepath.Abs(jfrogDir)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	return absJfrogDir == absJfrogHomeDir, nil
+}
@@ -0,0 +1,137 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestProjectConfig(t *testing.T, dir, projectType, content string) string {
+	confFilePath := filepath.Join(dir, ".jfrog", "projects", projectType+".yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(confFilePath), 0755))
+	require.NoError(t, os.WriteFile(confFilePath, []byte(content), 0644))
+	return confFilePath
+}
+
+// Creates a monorepo, in which the payments service overrides the npm resolution repository of the root.
+func createMonorepo(t *testing.T, withGit bool) (rootConfigPath, serviceConfigPath, serviceDir string) {
+	rootDir := t.TempDir()
+	if withGit {
+		require.NoError(t, os.Mkdir(filepath.Join(rootDir, ".git"), 0755))
+	}
+	rootConfigPath = writeTestProjectConfig(t, rootDir, "npm", "version: 1\ntype: npm\nresolver:\n  serverId: root-server\n  repo: npm-virtual\ndeployer:\n  serverId: root-server\n  repo: npm-local\n")
+	serviceDir = filepath.Join(rootDir, "services", "payments")
+	serviceConfigPath = writeTestProjectConfig(t, serviceDir, "npm", "version: 1\ntype: npm\nresolver:\n  repo: payments-npm-virtual\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(serviceDir, "src"), 0755))
+	return
+}
+
+func TestGetEffectiveProjectConfig(t *testing.T) {
+	rootConfigPath, serviceConfigPath, serviceDir := createMonorepo(t, true)
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	defer testsutils.ChangeDirWithCallback(t, wd, filepath.Join(serviceDir, "src"))()
+
+	effectiveConfig, err := GetEffectiveProjectConfig(Npm)
+	require.NoError(t, err)
+	require.NotNil(t, effectiveConfig)
+	require.Len(t, effectiveConfig.Layers, 2)
+	assert.Equal(t, map[string]string{
+		"version":           serviceConfigPath,
+		"type":              serviceConfigPath,
+		"resolver.repo":     serviceConfigPath,
+		"resolver.serverId": rootConfigPath,
+		"deployer.repo":     rootConfigPath,
+		"deployer.serverId": rootConfigPath,
+	}, effectiveConfig.Sources)
+
+	resolver, err := effectiveConfig.Repository(ProjectConfigResolverPrefix)
+	require.NoError(t, err)
+	assert.Equal(t, &Repository{Repo: "payments-npm-virtual", ServerId: "root-server"}, resolver)
+	deployer, err := effectiveConfig.Repository(ProjectConfigDeployerPrefix)
+	require.NoError(t, err)
+	assert.Equal(t, &Repository{Repo: "npm-local", ServerId: "root-server"}, deployer)
+
+	// The nearest config file is returned, and is read merged with the config of the root.
+	confFilePath, exists, err := GetProjectConfFilePath(Npm)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, serviceConfigPath, confFilePath)
+	vConfig, err := ReadEffectiveConfigFile(confFilePath, YAML)
+	require.NoError(t, err)
+	assert.Equal(t, "payments-npm-virtual", vConfig.GetString("resolver.repo"))
+	assert.Equal(t, "root-server", vConfig.GetString("resolver.serverId"))
+	assert.Equal(t, "npm-local", vConfig.GetString("deployer.repo"))
+
+	// ReadConfigFile reads only the file itself.
+	vConfig, err = ReadConfigFile(confFilePath, YAML)
+	require.NoError(t, err)
+	assert.Empty(t, vConfig.GetString("resolver.serverId"))
+	assert.False(t, vConfig.IsSet("deployer"))
+
+	// The config file of the root isn't affected by the overrides of the service.
+	vConfig, err = ReadEffectiveConfigFile(rootConfigPath, YAML)
+	require.NoError(t, err)
+	assert.Equal(t, "npm-virtual", vConfig.GetString("resolver.repo"))
+}
+
+func TestGetEffectiveProjectConfigStopsAtJfrogHome(t *testing.T) {
+	rootConfigPath, serviceConfigPath, serviceDir := createMonorepo(t, true)
+	// The .jfrog directory of the root is the JFrog home dir.
+	t.Setenv(coreutils.HomeDir, filepath.Dir(filepath.Dir(rootConfigPath)))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	defer testsutils.ChangeDirWithCallback(t, wd, serviceDir)()
+
+	effectiveConfig, err := GetEffectiveProjectConfig(Npm)
+	require.NoError(t, err)
+	require.NotNil(t, effectiveConfig)
+	require.Len(t, effectiveConfig.Layers, 1)
+	assert.Equal(t, serviceConfigPath, effectiveConfig.Layers[0].File.Path)
+	assert.Nil(t, effectiveConfig.Get("deployer.repo"))
+}
+
+func TestGetEffectiveProjectConfigOutsideGitRepository(t *testing.T) {
+	_, serviceConfigPath, serviceDir := createMonorepo(t, false)
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	defer testsutils.ChangeDirWithCallback(t, wd, serviceDir)()
+
+	// Without a git repository, only the nearest .jfrog directory configuring the technology is used.
+	effectiveConfig, err := GetEffectiveProjectConfig(Npm)
+	require.NoError(t, err)
+	require.NotNil(t, effectiveConfig)
+	require.Len(t, effectiveConfig.Layers, 1)
+	assert.Equal(t, serviceConfigPath, effectiveConfig.Sources["resolver.repo"])
+	assert.Nil(t, effectiveConfig.Get("deployer.repo"))
+}
+
+func TestGetProjectConfFilePathNestedJfrogDirWithoutConfig(t *testing.T) {
+	for _, withGit := range []bool{true, false} {
+		rootConfigPath, _, serviceDir := createMonorepo(t, withGit)
+		// The .jfrog directory of the nested module configures another technology only.
+		moduleDir := filepath.Join(filepath.Dir(filepath.Dir(serviceDir)), "modules", "ui")
+		writeTestProjectConfig(t, moduleDir, "maven", "version: 1\ntype: maven\n")
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		restoreDir := testsutils.ChangeDirWithCallback(t, wd, moduleDir)
+
+		confFilePath, exists, err := GetProjectConfFilePath(Npm)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, rootConfigPath, confFilePath)
+
+		effectiveConfig, err := GetEffectiveProjectConfig(Npm)
+		require.NoError(t, err)
+		require.NotNil(t, effectiveConfig)
+		require.Len(t, effectiveConfig.Layers, 1)
+		assert.Equal(t, rootConfigPath, effectiveConfig.Layers[0].File.Path)
+		assert.Equal(t, "npm-local", effectiveConfig.Get("deployer.repo"))
+		restoreDir()
+	}
+}
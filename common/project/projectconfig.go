@@ -4,13 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
-	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
-	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/spf13/viper"
 )
@@ -123,6 +121,18 @@ type Repository struct {
 	NugetV2               bool   `yaml:"nugetV2,omitempty"`
 	IncludePatterns       string `yaml:"includePatterns,omitempty"`
 	ExcludePatterns       string `yaml:"excludePatterns,omitempty"`
+	RemoteName            string `yaml:"remoteName,omitempty"`
+	Oci                   bool   `yaml:"oci,omitempty"`
+	Distribution          string `yaml:"distribution,omitempty"`
+	Component             string `yaml:"component,omitempty"`
+	Architecture          string `yaml:"architecture,omitempty"`
+	Branch                string `yaml:"branch,omitempty"`
+	SourceName            string `yaml:"sourceName,omitempty"`
+	IndexName             string `yaml:"indexName,omitempty"`
+	SpecsRepoName         string `yaml:"specsRepoName,omitempty"`
+	Scope                 string `yaml:"scope,omitempty"`
+	Namespace             string `yaml:"namespace,omitempty"`
+	Provider              string `yaml:"provider,omitempty"`
 }
 
 type RepositoryConfig struct {
@@ -131,38 +141,51 @@ type RepositoryConfig struct {
 }
 
 // If configuration file exists in the working dir or in one of its parent dirs return its path,
-// otherwise return the global configuration file path
+// otherwise return the global configuration file path.
+// The returned file is the nearest config file configuring the technology, as returned by GetProjectConfig, so it may be the unified project config file.
+// Read the returned file with ReadProjectConfigFile, which reads the technology's section of the unified project config file.
 func GetProjectConfFilePath(projectType ProjectType) (confFilePath string, exists bool, err error) {
-	confFileName := filepath.Join("projects", projectType.String()+".yaml")
-	projectDir, exists, err := fileutils.FindUpstream(".jfrog", fileutils.Dir)
-	if err != nil {
+	_, configFile, err := GetProjectConfig(projectType)
+	if err != nil || configFile == nil {
 		return
 	}
-	if exists {
-		filePath := filepath.Join(projectDir, ".jfrog", confFileName)
-		exists, err = fileutils.IsFileExists(filePath, false)
-		if err != nil {
-			return
-		}
+	return configFile.Path, true, nil
+}
 
-		if exists {
-			confFilePath = filePath
-			return
-		}
+// ProjectConfigFile is a config file configuring a technology, either a legacy per-type config file,
+// or the unified project config file, which configures the technology in its section.
+type ProjectConfigFile struct {
+	Path        string
+	ProjectType ProjectType
+	// True if the file is the unified project config file.
+	Unified bool
+}
+
+// Returns the path of the file. The path of the unified project config file is followed by the technology of its section.
+func (configFile ProjectConfigFile) String() string {
+	if configFile.Unified {
+		return configFile.Path + " (" + configFile.ProjectType.String() + ")"
 	}
-	// If missing in the root project, check in the home dir
-	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
-	if err != nil {
+	return configFile.Path
+}
+
+// Returns the config of the technology in the working dir, in the structure of a legacy per-type config file, and the nearest config file configuring it.
+// The technology's section in the unified project config file takes precedence over the legacy per-type config file of the same .jfrog directory,
+// and the config is merged with the configs of the parent dirs, as described in GetEffectiveProjectConfig.
+// If the technology isn't configured, nil is returned.
+func GetProjectConfig(projectType ProjectType) (vConfig *viper.Viper, configFile *ProjectConfigFile, err error) {
+	effectiveConfig, err := GetEffectiveProjectConfig(projectType)
+	if err != nil || effectiveConfig == nil {
 		return
 	}
-	filePath := filepath.Join(jfrogHomeDir, confFileName)
-	exists, err = fileutils.IsFileExists(filePath, false)
-	if exists {
-		confFilePath = filePath
+	if vConfig, err = newProjectConfigViper(effectiveConfig.Config); err != nil {
+		return nil, nil, err
 	}
-	return
+	return vConfig, &effectiveConfig.Layers[0].File, nil
 }
 
+// Returns the resolver or deployer repository of the config.
+// If the repository of a project's per-type config file doesn't set a server ID, the default server of the project's unified config file is used.
 func GetRepoConfigByPrefix(configFilePath, prefix string, vConfig *viper.Viper) (repoConfig *RepositoryConfig, err error) {
 	defer func() {
 		if err != nil {
@@ -183,6 +206,11 @@ func GetRepoConfigByPrefix(configFilePath, prefix string, vConfig *viper.Viper)
 		}
 	}
 	serverId := vConfig.GetString(prefix + "." + ProjectConfigServerId)
+	if serverId == "" {
+		if serverId, err = GetProjectDefaultServerId(configFilePath); err != nil {
+			return
+		}
+	}
 	if serverId == "" {
 		err = errorutils.CheckErrorf("missing server ID for %s within %s", prefix, configFilePath)
 		return
@@ -221,18 +249,26 @@ func (repo *RepositoryConfig) ServerDetails() (*config.ServerDetails, error) {
 }
 
 func GetResolutionOnlyConfiguration(projectType ProjectType) (*RepositoryConfig, error) {
-	// Get configuration file path.
-	confFilePath, exists, err := GetProjectConfFilePath(projectType)
+	vConfig, configFile, err := GetProjectConfig(projectType)
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
+	if vConfig == nil {
 		return nil, errorutils.CheckErrorf("%s Project configuration does not exist.", projectType.String())
 	}
-	return ReadResolutionOnlyConfiguration(confFilePath)
+	return GetRepoConfigByPrefix(configFile.Path, ProjectConfigResolverPrefix, vConfig)
 }
 
+// Reads the config file as is.
+// A unified project config file is read like ReadProjectConfigFile reads it without project types, but without merging the config files of its parent dirs.
 func ReadConfigFile(configPath string, configType ConfigType) (config *viper.Viper, err error) {
+	if isUnifiedProjectConfigFilePath(configPath) {
+		unifiedConfig, projectType, err := readUnifiedProjectConfigTechnology(configPath)
+		if err != nil {
+			return nil, err
+		}
+		return newProjectConfigViper(unifiedConfig.GetTechnologyConfig(projectType))
+	}
 	config = viper.New()
 	config.SetConfigType(string(configType))
 
@@ -247,9 +283,91 @@ func ReadConfigFile(configPath string, configType ConfigType) (config *viper.Vip
 	return config, errorutils.CheckError(err)
 }
 
-func ReadResolutionOnlyConfiguration(confFilePath string) (*RepositoryConfig, error) {
+// Reads a per-type project config file, merged with the config files of the technology in the .jfrog directories of its parent dirs,
+// as described in GetEffectiveProjectConfig. A unified project config file is read like ReadProjectConfigFile reads it without project types.
+// Other files are read as is, like ReadConfigFile does.
+func ReadEffectiveConfigFile(configPath string, configType ConfigType) (*viper.Viper, error) {
+	if isUnifiedProjectConfigFilePath(configPath) {
+		return ReadProjectConfigFile(configPath)
+	}
+	effectiveConfig, err := getHierarchicalProjectConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if effectiveConfig == nil {
+		return ReadConfigFile(configPath, configType)
+	}
+	config := viper.New()
+	config.SetConfigType(string(configType))
+	return config, errorutils.CheckError(config.MergeConfigMap(effectiveConfig.Config))
+}
+
+// Reads the config of a technology from a project config file returned by GetProjectConfFilePath, in the structure of a legacy per-type config file,
+// merged with the config files of the technology in the .jfrog directories of its parent dirs.
+// The config of the first of the project types configured by a unified project config file is read.
+// If no project types are given, the unified project config file must configure a single technology.
+// Other files are read like ReadEffectiveConfigFile reads them.
+func ReadProjectConfigFile(configPath string, projectTypes ...ProjectType) (*viper.Viper, error) {
+	if !isUnifiedProjectConfigFilePath(configPath) {
+		return ReadEffectiveConfigFile(configPath, YAML)
+	}
+	unifiedConfig, projectType, err := readUnifiedProjectConfigTechnology(configPath, projectTypes...)
+	if err != nil {
+		return nil, err
+	}
+	effectiveConfig, err := getProjectConfigOfFile(ProjectConfigFile{Path: configPath, ProjectType: projectType, Unified: true})
+	if err != nil {
+		return nil, err
+	}
+	if effectiveConfig == nil {
+		// The file isn't in a project, for example if it's in the JFrog home dir.
+		return newProjectConfigViper(unifiedConfig.GetTechnologyConfig(projectType))
+	}
+	return newProjectConfigViper(effectiveConfig.Config)
+}
+
+// Reads the unified project config file, and returns the first of the project types it configures.
+// If no project types are given, the file must configure a single technology.
+func readUnifiedProjectConfigTechnology(configPath string, projectTypes ...ProjectType) (*UnifiedProjectConfig, ProjectType, error) {
+	unifiedConfig, err := ReadUnifiedProjectConfig(configPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(projectTypes) == 0 {
+		if len(unifiedConfig.Technologies) != 1 {
+			return nil, 0, errorutils.CheckErrorf("the project config file %s configures %d technologies. The technology to read must be specified", configPath, len(unifiedConfig.Technologies))
+		}
+		for technology := range unifiedConfig.Technologies {
+			return unifiedConfig, FromString(technology), nil
+		}
+	}
+	for _, projectType := range projectTypes {
+		if unifiedConfig.HasTechnology(projectType) {
+			return unifiedConfig, projectType, nil
+		}
+	}
+	return nil, 0, errorutils.CheckErrorf("the project config file %s doesn't configure any of the technologies: %s", configPath, projectTypesString(projectTypes))
+}
+
+func projectTypesString(projectTypes []ProjectType) string {
+	names := make([]string, len(projectTypes))
+	for i, projectType := range projectTypes {
+		names[i] = projectType.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+func newProjectConfigViper(config map[string]any) (*viper.Viper, error) {
+	vConfig := viper.New()
+	vConfig.SetConfigType(string(YAML))
+	return vConfig, errorutils.CheckError(vConfig.MergeConfigMap(config))
+}
+
+// Reads the resolver repository of a project config file returned by GetProjectConfFilePath.
+// The project types select the technology of a unified project config file, as described in ReadProjectConfigFile.
+func ReadResolutionOnlyConfiguration(confFilePath string, projectTypes ...ProjectType) (*RepositoryConfig, error) {
 	log.Debug("Preparing to read the config file", confFilePath)
-	vConfig, err := ReadConfigFile(confFilePath, YAML)
+	vConfig, err := ReadProjectConfigFile(confFilePath, projectTypes...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,165 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	UnifiedProjectConfigFileName = "project.yaml"
+	UnifiedProjectConfigVersion  = 1
+	projectConfigVersion         = 1
+	projectConfigTypeKey         = "type"
+	projectConfigVersionKey      = "version"
+	projectConfigBuildNameKey    = "buildName"
+)
+
+// UnifiedProjectConfig is the optional .jfrog/project.yaml file, configuring all the technologies of a project in a single file.
+// The file takes precedence over the legacy .jfrog/projects/<type>.yaml files.
+type UnifiedProjectConfig struct {
+	Version int `yaml:"version,omitempty"`
+	// The default server of the technologies, used by resolvers and deployers which don't set a server ID.
+	ServerId  string `yaml:"serverId,omitempty"`
+	Project   string `yaml:"project,omitempty"`
+	BuildName string `yaml:"buildName,omitempty"`
+	// The section of each technology, keyed by the project type, has the same structure as the legacy per-type file, without the version and type.
+	Technologies map[string]map[string]any `yaml:"technologies,omitempty"`
+}
+
+func NewUnifiedProjectConfig() *UnifiedProjectConfig {
+	return &UnifiedProjectConfig{Version: UnifiedProjectConfigVersion, Technologies: map[string]map[string]any{}}
+}
+
+// Returns the path of the unified project config file in the nearest .jfrog directory of the working dir or its parent dirs.
+// If there's no .jfrog directory, the returned path is empty.
+func GetUnifiedProjectConfigFilePath() (confFilePath string, exists bool, err error) {
+	projectDir, found, err := fileutils.FindUpstream(".jfrog", fileutils.Dir)
+	if err != nil || !found {
+		return
+	}
+	confFilePath = filepath.Join(projectDir, ".jfrog", UnifiedProjectConfigFileName)
+	exists, err = fileutils.IsFileExists(confFilePath, false)
+	return
+}
+
+// Returns the unified project config of the working dir, or nil if the project has no unified config file.
+func GetUnifiedProjectConfig() (*UnifiedProjectConfig, error) {
+	confFilePath, exists, err := GetUnifiedProjectConfigFilePath()
+	if err != nil || !exists {
+		return nil, err
+	}
+	return ReadUnifiedProjectConfig(confFilePath)
+}
+
+func ReadUnifiedProjectConfig(confFilePath string) (*UnifiedProjectConfig, error) {
+	content, err := os.ReadFile(confFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	unifiedConfig := NewUnifiedProjectConfig()
+	if err = yaml.Unmarshal(content, unifiedConfig); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the project config file %s: %s", confFilePath, err.Error())
+	}
+	if unifiedConfig.Technologies == nil {
+		unifiedConfig.Technologies = map[string]map[string]any{}
+	}
+	for technology := range unifiedConfig.Technologies {
+		if FromString(technology) < 0 {
+			return nil, errorutils.CheckErrorf("unsupported technology '%s' in the project config file %s. The supported technologies are: %s", technology, confFilePath, strings.Join(ProjectTypes, ", "))
+		}
+	}
+	return unifiedConfig, nil
+}
+
+func WriteUnifiedProjectConfig(confFilePath string, unifiedConfig *UnifiedProjectConfig) error {
+	content, err := yaml.Marshal(unifiedConfig)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(confFilePath, content, 0644))
+}
+
+// Returns true if the unified config configures the technology.
+// The build name of the unified config configures the build technology.
+func (unifiedConfig *UnifiedProjectConfig) HasTechnology(projectType ProjectType) bool {
+	if _, exists := unifiedConfig.Technologies[projectType.String()]; exists {
+		return true
+	}
+	return projectType == Build && unifiedConfig.BuildName != ""
+}
+
+// Sets the section of the technology. The version and type of a legacy per-type config are dropped.
+func (unifiedConfig *UnifiedProjectConfig) SetTechnology(projectType ProjectType, section map[string]any) {
+	technologyConfig := make(map[string]any, len(section))
+	for key, value := range section {
+		if key != projectConfigVersionKey && key != projectConfigTypeKey {
+			technologyConfig[key] = value
+		}
+	}
+	unifiedConfig.Technologies[projectType.String()] = technologyConfig
+}
+
+// Returns the technology's config in the structure of the legacy per-type file, so that it's read the same way.
+// Resolvers and deployers without a server ID use the default server.
+func (unifiedConfig *UnifiedProjectConfig) GetTechnologyConfig(projectType ProjectType) map[string]any {
+	technologyConfig := map[string]any{
+		projectConfigVersionKey: projectConfigVersion,
+		projectConfigTypeKey:    projectType.String(),
+	}
+	for key, value := range unifiedConfig.Technologies[projectType.String()] {
+		technologyConfig[key] = value
+	}
+	if projectType == Build && unifiedConfig.BuildName != "" {
+		if _, exists := technologyConfig[projectConfigBuildNameKey]; !exists {
+			technologyConfig[projectConfigBuildNameKey] = unifiedConfig.BuildName
+		}
+	}
+	if unifiedConfig.ServerId == "" {
+		return technologyConfig
+	}
+	for _, prefix := range []string{ProjectConfigResolverPrefix, ProjectConfigDeployerPrefix} {
+		repository, ok := technologyConfig[prefix].(map[string]any)
+		if !ok {
+			continue
+		}
+		if serverId, _ := repository[ProjectConfigServerId].(string); serverId == "" {
+			// Copied, to keep the unified config unchanged.
+			repositoryWithServer := map[string]any{ProjectConfigServerId: unifiedConfig.ServerId}
+			for key, value := range repository {
+				if key != ProjectConfigServerId {
+					repositoryWithServer[key] = value
+				}
+			}
+			technologyConfig[prefix] = repositoryWithServer
+		}
+	}
+	return technologyConfig
+}
+
+// Returns the default server of the unified config file of the project, whose .jfrog directory contains the per-type config file.
+// Returns an empty string if the path isn't of a project's per-type config file, such as the global config files in the JFrog home dir.
+func GetProjectDefaultServerId(configFilePath string) (string, error) {
+	jfrogDir, _, ok := getProjectConfigJfrogDir(configFilePath)
+	if !ok {
+		return "", nil
+	}
+	isJfrogHome, err := isJfrogHomeDir(jfrogDir)
+	if err != nil || isJfrogHome {
+		return "", err
+	}
+	unifiedFilePath := filepath.Join(jfrogDir, UnifiedProjectConfigFileName)
+	exists, err := fileutils.IsFileExists(unifiedFilePath, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	unifiedConfig, err := ReadUnifiedProjectConfig(unifiedFilePath)
+	if err != nil {
+		return "", err
+	}
+	return unifiedConfig.ServerId, nil
+}
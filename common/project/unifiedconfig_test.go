@@ -0,0 +1,157 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUnifiedProjectConfig = `version: 1
+serverId: default-server
+project: proj
+buildName: unified-build
+technologies:
+  npm:
+    resolver:
+      repo: npm-virtual
+    deployer:
+      serverId: npm-server
+      repo: npm-local
+  go:
+    resolver:
+      repo: go-virtual
+`
+
+// Creates a project with the unified config file and a legacy maven config file, and changes the working dir to it.
+func createUnifiedConfigProject(t *testing.T) (projectDir string, restoreDir func()) {
+	projectDir = t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".jfrog", "projects"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".jfrog", UnifiedProjectConfigFileName), []byte(testUnifiedProjectConfig), 0644))
+	legacyMavenConfig := "version: 1\ntype: maven\nresolver:\n  serverId: maven-server\n  releaseRepo: maven-virtual\n"
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".jfrog", "projects", "maven.yaml"), []byte(legacyMavenConfig), 0644))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	return projectDir, testsutils.ChangeDirWithCallback(t, wd, projectDir)
+}
+
+func TestGetProjectConfigWithUnifiedConfig(t *testing.T) {
+	// No global config files.
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	projectDir, restoreDir := createUnifiedConfigProject(t)
+	defer restoreDir()
+	unifiedFilePath := filepath.Join(projectDir, ".jfrog", UnifiedProjectConfigFileName)
+	mavenConfigPath := filepath.Join(projectDir, ".jfrog", "projects", "maven.yaml")
+
+	testCases := []struct {
+		projectType      ProjectType
+		expectedFile     ProjectConfigFile
+		expectedServerId string
+		expectedRepo     string
+	}{
+		// The default server is used by repositories without a server ID.
+		{Npm, ProjectConfigFile{Path: unifiedFilePath, ProjectType: Npm, Unified: true}, "default-server", "npm-virtual"},
+		{Go, ProjectConfigFile{Path: unifiedFilePath, ProjectType: Go, Unified: true}, "default-server", "go-virtual"},
+		// Technologies which aren't in the unified config are read from the legacy files.
+		{Maven, ProjectConfigFile{Path: mavenConfigPath, ProjectType: Maven}, "maven-server", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.projectType.String(), func(t *testing.T) {
+			vConfig, configFile, err := GetProjectConfig(testCase.projectType)
+			require.NoError(t, err)
+			require.NotNil(t, vConfig)
+			assert.Equal(t, testCase.expectedFile, *configFile)
+			assert.Equal(t, testCase.projectType.String(), vConfig.GetString("type"))
+			assert.Equal(t, testCase.expectedServerId, vConfig.GetString(ProjectConfigResolverPrefix+"."+ProjectConfigServerId))
+			assert.Equal(t, testCase.expectedRepo, vConfig.GetString(ProjectConfigResolverPrefix+"."+ProjectConfigRepo))
+		})
+	}
+
+	// A repository's own server ID overrides the default server.
+	vConfig, _, err := GetProjectConfig(Npm)
+	require.NoError(t, err)
+	assert.Equal(t, "npm-server", vConfig.GetString(ProjectConfigDeployerPrefix+"."+ProjectConfigServerId))
+
+	// The build name of the unified config configures the build technology.
+	vConfig, _, err = GetProjectConfig(Build)
+	require.NoError(t, err)
+	require.NotNil(t, vConfig)
+	assert.Equal(t, "unified-build", vConfig.GetString("buildName"))
+
+	// GetProjectConfFilePath returns the same file as GetProjectConfig, so the unified config file takes precedence.
+	confFilePath, exists, err := GetProjectConfFilePath(Maven)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, mavenConfigPath, confFilePath)
+	confFilePath, exists, err = GetProjectConfFilePath(Npm)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, unifiedFilePath, confFilePath)
+
+	// The technology's section of the unified config file is read from the returned path.
+	vConfig, err = ReadProjectConfigFile(confFilePath, Npm)
+	require.NoError(t, err)
+	assert.Equal(t, "npm", vConfig.GetString("type"))
+	assert.Equal(t, "default-server", vConfig.GetString(ProjectConfigResolverPrefix+"."+ProjectConfigServerId))
+	assert.Equal(t, "npm-virtual", vConfig.GetString(ProjectConfigResolverPrefix+"."+ProjectConfigRepo))
+	vConfig, err = ReadProjectConfigFile(confFilePath, Maven, Go)
+	require.NoError(t, err)
+	assert.Equal(t, "go", vConfig.GetString("type"))
+	_, err = ReadProjectConfigFile(confFilePath, Pip)
+	assert.Error(t, err)
+	// Without a project type, the unified config file must configure a single technology.
+	_, err = ReadConfigFile(confFilePath, YAML)
+	assert.Error(t, err)
+	vConfig, err = ReadProjectConfigFile(mavenConfigPath, Npm)
+	require.NoError(t, err)
+	assert.Equal(t, "maven-virtual", vConfig.GetString(ProjectConfigResolverPrefix+"."+ProjectConfigReleaseRepo))
+}
+
+func TestGetRepoConfigByPrefixDefaultServer(t *testing.T) {
+	jfrogHomeDir := t.TempDir()
+	t.Setenv(coreutils.HomeDir, jfrogHomeDir)
+	projectDir, restoreDir := createUnifiedConfigProject(t)
+	defer restoreDir()
+	withoutServerId := "version: 1\ntype: pip\nresolver:\n  repo: pip-virtual\n"
+
+	// The per-type config file of the project uses the default server of the project's unified config.
+	projectConfigPath := filepath.Join(projectDir, ".jfrog", "projects", "pip.yaml")
+	require.NoError(t, os.WriteFile(projectConfigPath, []byte(withoutServerId), 0644))
+	vConfig, err := ReadConfigFile(projectConfigPath, YAML)
+	require.NoError(t, err)
+	_, err = GetRepoConfigByPrefix(projectConfigPath, ProjectConfigResolverPrefix, vConfig)
+	assert.ErrorContains(t, err, "default-server")
+
+	// The global config file doesn't use the default server of the working dir's project.
+	globalConfigPath := filepath.Join(jfrogHomeDir, "projects", "pip.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(globalConfigPath), 0755))
+	require.NoError(t, os.WriteFile(globalConfigPath, []byte(withoutServerId), 0644))
+	vConfig, err = ReadConfigFile(globalConfigPath, YAML)
+	require.NoError(t, err)
+	_, err = GetRepoConfigByPrefix(globalConfigPath, ProjectConfigResolverPrefix, vConfig)
+	assert.ErrorContains(t, err, "missing server ID")
+}
+
+func TestReadUnifiedProjectConfig(t *testing.T) {
+	projectDir, restoreDir := createUnifiedConfigProject(t)
+	defer restoreDir()
+
+	unifiedConfig, err := GetUnifiedProjectConfig()
+	require.NoError(t, err)
+	require.NotNil(t, unifiedConfig)
+	assert.Equal(t, "default-server", unifiedConfig.ServerId)
+	assert.Equal(t, "proj", unifiedConfig.Project)
+	assert.True(t, unifiedConfig.HasTechnology(Npm))
+	assert.True(t, unifiedConfig.HasTechnology(Build))
+	assert.False(t, unifiedConfig.HasTechnology(Maven))
+
+	// Unsupported technologies are rejected.
+	invalidConfigPath := filepath.Join(projectDir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidConfigPath, []byte("version: 1\ntechnologies:\n  cobol: {}\n"), 0644))
+	_, err = ReadUnifiedProjectConfig(invalidConfigPath)
+	assert.ErrorContains(t, err, "unsupported technology 'cobol'")
+}
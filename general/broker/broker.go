@@ -0,0 +1,75 @@
+package broker
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type BrokerAction string
+
+const (
+	Start  BrokerAction = "start"
+	Stop   BrokerAction = "stop"
+	Status BrokerAction = "status"
+)
+
+// CredentialBrokerCommand starts, stops or shows the status of the credential broker of the current user.
+// The start action serves in the foreground until stopped, so it's expected to run as a background process or a service.
+type CredentialBrokerCommand struct {
+	action     BrokerAction
+	socketPath string
+}
+
+func NewCredentialBrokerCommand(action BrokerAction) *CredentialBrokerCommand {
+	return &CredentialBrokerCommand{action: action}
+}
+
+// Sets the path of the broker's Unix socket. If not set, the socket is created under the JFrog home directory.
+// Note that the CLI processes request tokens from the broker on the default socket path only.
+func (cbc *CredentialBrokerCommand) SetSocketPath(socketPath string) *CredentialBrokerCommand {
+	cbc.socketPath = socketPath
+	return cbc
+}
+
+func (cbc *CredentialBrokerCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (cbc *CredentialBrokerCommand) CommandName() string {
+	return "credential_broker_" + string(cbc.action)
+}
+
+func (cbc *CredentialBrokerCommand) Run() error {
+	switch cbc.action {
+	case Start:
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return config.NewCredentialBroker().SetSocketPath(cbc.socketPath).Serve(ctx)
+	case Stop:
+		if err := config.StopCredentialBroker(cbc.socketPath); err != nil {
+			return err
+		}
+		log.Info("The credential broker was stopped.")
+		return nil
+	case Status:
+		status, err := config.GetCredentialBrokerStatus(cbc.socketPath)
+		if err != nil {
+			return err
+		}
+		log.Output("PID:\t\t" + strconv.Itoa(status.Pid))
+		log.Output("Socket:\t\t" + status.SocketPath)
+		log.Output("Started:\t" + status.StartTime)
+		log.Output("Server IDs:\t" + strings.Join(status.ServerIds, ", "))
+		return nil
+	default:
+		return errorutils.CheckErrorf("unsupported credential broker action: %s", cbc.action)
+	}
+}
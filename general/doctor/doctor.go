@@ -0,0 +1,348 @@
+package doctor
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	configDoctorCommandName = "config_doctor"
+	serviceUrlTimeout       = 10 * time.Second
+	// Access tokens expiring within this duration are reported with a warning.
+	tokenExpiryWarningThreshold = 24 * time.Hour
+)
+
+type CheckStatus string
+
+const (
+	Ok      CheckStatus = "OK"
+	Warning CheckStatus = "WARNING"
+	Failed  CheckStatus = "ERROR"
+	Skipped CheckStatus = "SKIPPED"
+	// The check couldn't determine whether the config works, without modifying it.
+	Unknown CheckStatus = "UNKNOWN"
+)
+
+// The result of a single check of a configured server.
+type CheckResult struct {
+	ServerId string      `json:"serverId" col-name:"Server ID" auto-merge:"true"`
+	Check    string      `json:"check" col-name:"Check"`
+	Status   CheckStatus `json:"status" col-name:"Status"`
+	Details  string      `json:"details,omitempty" col-name:"Details"`
+}
+
+// ConfigDoctorCommand checks the health of the configured servers - their connectivity, service URLs, tokens and key files.
+// The config is not modified, except for refreshing an expired access token when pinging Artifactory, as any other command does.
+type ConfigDoctorCommand struct {
+	serverIds    []string
+	outputFormat format.OutputFormat
+	results      []CheckResult
+}
+
+func NewConfigDoctorCommand() *ConfigDoctorCommand {
+	return &ConfigDoctorCommand{outputFormat: format.Table}
+}
+
+// The servers to check. If empty, all the configured servers are checked.
+func (dc *ConfigDoctorCommand) SetServerIds(serverIds []string) *ConfigDoctorCommand {
+	dc.serverIds = serverIds
+	return dc
+}
+
+func (dc *ConfigDoctorCommand) SetOutputFormat(outputFormat format.OutputFormat) *ConfigDoctorCommand {
+	dc.outputFormat = outputFormat
+	return dc
+}
+
+func (dc *ConfigDoctorCommand) Results() []CheckResult {
+	return dc.results
+}
+
+func (dc *ConfigDoctorCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (dc *ConfigDoctorCommand) CommandName() string {
+	return configDoctorCommandName
+}
+
+func (dc *ConfigDoctorCommand) Run() error {
+	serverIds := dc.serverIds
+	if len(serverIds) == 0 {
+		configurations, err := config.GetAllServersConfigsWithEnvOverlay()
+		if err != nil {
+			return err
+		}
+		for _, serverDetails := range configurations {
+			serverIds = append(serverIds, serverDetails.ServerId)
+		}
+	}
+	dc.results = []CheckResult{}
+	for _, serverId := range serverIds {
+		dc.results = append(dc.results, checkServer(serverId)...)
+	}
+	if err := dc.printResults(); err != nil {
+		return err
+	}
+	failedChecks := 0
+	for _, result := range dc.results {
+		if result.Status == Failed {
+			failedChecks++
+		}
+	}
+	if failedChecks > 0 {
+		return errorutils.CheckErrorf("%d of the config checks failed", failedChecks)
+	}
+	return nil
+}
+
+func (dc *ConfigDoctorCommand) printResults() error {
+	switch dc.outputFormat {
+	case format.Json:
+		content, err := coreutils.GetJsonIndent(dc.results)
+		if err != nil {
+			return err
+		}
+		log.Output(content)
+		return nil
+	case format.Table, format.None:
+		return coreutils.PrintTable(dc.results, "", "No servers are configured.", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. The supported formats are: %s", dc.outputFormat, format.Join([]format.OutputFormat{format.Table, format.Json}))
+	}
+}
+
+func checkServer(serverId string) []CheckResult {
+	// The server is read by its ID, in order to resolve its secrets.
+	serverDetails, err := config.GetSpecificConfig(serverId, false, false)
+	if err != nil {
+		return []CheckResult{{ServerId: serverId, Check: "Configuration", Status: Failed, Details: err.Error()}}
+	}
+	results := []CheckResult{checkConfiguration(serverDetails), checkArtifactoryPing(serverDetails)}
+	results = append(results, checkServiceUrls(serverDetails)...)
+	results = append(results,
+		checkAccessToken(serverDetails),
+		checkRefreshTokenConfig(serverDetails),
+		checkClientCertificate(serverDetails),
+		checkSshKey(serverDetails))
+	return results
+}
+
+func newResult(serverDetails *config.ServerDetails, check string, status CheckStatus, details string) CheckResult {
+	return CheckResult{ServerId: serverDetails.ServerId, Check: check, Status: status, Details: details}
+}
+
+func checkConfiguration(serverDetails *config.ServerDetails) CheckResult {
+	const check = "Configuration"
+	if serverDetails.Url == "" && serverDetails.ArtifactoryUrl == "" {
+		return newResult(serverDetails, check, Failed, "neither the JFrog Platform URL nor the Artifactory URL is configured")
+	}
+	if serverDetails.User == "" && serverDetails.Password == "" && serverDetails.AccessToken == "" && serverDetails.SshKeyPath == "" && serverDetails.ClientCertPath == "" {
+		return newResult(serverDetails, check, Warning, "no credentials are configured")
+	}
+	return newResult(serverDetails, check, Ok, "")
+}
+
+func checkArtifactoryPing(serverDetails *config.ServerDetails) CheckResult {
+	const check = "Artifactory ping"
+	if serverDetails.ArtifactoryUrl == "" {
+		return newResult(serverDetails, check, Skipped, "the Artifactory URL is not configured")
+	}
+	// The server is copied, so the following checks are not affected by a token refresh.
+	pingDetails := *serverDetails
+	pingCmd := generic.NewPingCommand().SetServerDetails(&pingDetails)
+	if err := pingCmd.Run(); err != nil {
+		return newResult(serverDetails, check, Failed, err.Error())
+	}
+	return newResult(serverDetails, check, Ok, strings.TrimSpace(string(pingCmd.Response())))
+}
+
+type serviceEndpoint struct {
+	name string
+	url  string
+	// The path of the service under the JFrog Platform URL, used if the service URL is not configured.
+	platformPath string
+}
+
+func getServiceUrls(serverDetails *config.ServerDetails) []serviceEndpoint {
+	return []serviceEndpoint{
+		{"JFrog Platform", serverDetails.GetUrl(), ""},
+		{"Artifactory", serverDetails.GetArtifactoryUrl(), "artifactory/"},
+		{"Distribution", serverDetails.GetDistributionUrl(), "distribution/"},
+		{"Xray", serverDetails.GetXrayUrl(), "xray/"},
+		{"Mission Control", serverDetails.GetMissionControlUrl(), "mc/"},
+		{"Pipelines", serverDetails.GetPipelinesUrl(), "pipelines/"},
+		{"Access", serverDetails.GetAccessUrl(), "access/"},
+		{"Catalog", serverDetails.GetCatalogUrl(), "catalog/"},
+		{"Lifecycle", serverDetails.GetLifecycleUrl(), "lifecycle/"},
+		{"Evidence", serverDetails.GetEvidenceUrl(), "evidence/"},
+		{"AppTrust", serverDetails.GetApptrustUrl(), "apptrust/"},
+		{"Metadata", serverDetails.GetMetadataUrl(), "metadata/"},
+		{"OneModel", serverDetails.GetOnemodelUrl(), "onemodel/"},
+	}
+}
+
+// Verifies that every service URL, either configured or derived from the JFrog Platform URL, is valid and reachable.
+func checkServiceUrls(serverDetails *config.ServerDetails) []CheckResult {
+	client, err := serverDetails.CreateHttpClient()
+	if err != nil {
+		return []CheckResult{newResult(serverDetails, "HTTP client settings", Failed, err.Error())}
+	}
+	if client == nil {
+		client = &http.Client{}
+		if serverDetails.InsecureTls {
+			// #nosec G402 -- Insecure TLS is explicitly configured for the server.
+			client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+		}
+	}
+	if client.Timeout == 0 || client.Timeout > serviceUrlTimeout {
+		client.Timeout = serviceUrlTimeout
+	}
+	var results []CheckResult
+	for _, service := range getServiceUrls(serverDetails) {
+		serviceUrl := service.url
+		if serviceUrl == "" {
+			if serverDetails.Url == "" || service.platformPath == "" {
+				continue
+			}
+			serviceUrl = utils.AddTrailingSlashIfNeeded(serverDetails.Url) + service.platformPath
+		}
+		status, details := checkServiceUrl(client, serviceUrl)
+		results = append(results, newResult(serverDetails, service.name+" URL", status, details))
+	}
+	return results
+}
+
+func checkServiceUrl(client *http.Client, serviceUrl string) (CheckStatus, string) {
+	parsedUrl, err := url.Parse(serviceUrl)
+	if err != nil {
+		return Failed, fmt.Sprintf("invalid URL '%s': %s", serviceUrl, err.Error())
+	}
+	if (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return Failed, fmt.Sprintf("invalid URL '%s': an absolute http or https URL is expected", serviceUrl)
+	}
+	resp, err := client.Get(serviceUrl)
+	if err != nil {
+		return Failed, fmt.Sprintf("%s is unreachable: %s", serviceUrl, err.Error())
+	}
+	if err = resp.Body.Close(); err != nil {
+		log.Debug("Failed closing the response body: " + err.Error())
+	}
+	// Any response other than a server error means the service is reachable, since the request is not authenticated.
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return Warning, fmt.Sprintf("%s responded with status %s", serviceUrl, resp.Status)
+	}
+	return Ok, serviceUrl
+}
+
+func checkAccessToken(serverDetails *config.ServerDetails) CheckResult {
+	const check = "Access token"
+	token := serverDetails.AccessToken
+	if token == "" {
+		return newResult(serverDetails, check, Skipped, "no access token is configured")
+	}
+	// Reference tokens are not JWTs, so their expiry can't be decoded.
+	if strings.Count(token, ".") != 2 {
+		return newResult(serverDetails, check, Ok, "reference token, the expiry is unknown")
+	}
+	payload, err := auth.ExtractPayloadFromAccessToken(token)
+	if err != nil {
+		return newResult(serverDetails, check, Failed, "failed decoding the access token: "+err.Error())
+	}
+	if payload.ExpirationTime == 0 {
+		return newResult(serverDetails, check, Ok, "the token does not expire")
+	}
+	expiry := time.Unix(int64(payload.ExpirationTime), 0)
+	timeLeft := time.Until(expiry)
+	refreshable := commands.IsAccessTokenRenewable(serverDetails)
+	switch {
+	case timeLeft <= 0 && refreshable:
+		return newResult(serverDetails, check, Warning, fmt.Sprintf("expired at %s, and will be refreshed on the next use", expiry.Format(time.RFC3339)))
+	case timeLeft <= 0:
+		return newResult(serverDetails, check, Failed, fmt.Sprintf("expired at %s", expiry.Format(time.RFC3339)))
+	case timeLeft < tokenExpiryWarningThreshold && !refreshable:
+		return newResult(serverDetails, check, Warning, fmt.Sprintf("expires at %s", expiry.Format(time.RFC3339)))
+	default:
+		return newResult(serverDetails, check, Ok, fmt.Sprintf("expires at %s", expiry.Format(time.RFC3339)))
+	}
+}
+
+// Inspects the refresh token config only. The token isn't tried against the server, since refreshing it would replace
+// the server's tokens, and the doctor doesn't modify the config. Refresh tokens aren't JWTs, so their expiry can't be decoded either.
+// Therefore, a complete config is reported as unknown, rather than OK.
+func checkRefreshTokenConfig(serverDetails *config.ServerDetails) CheckResult {
+	const check = "Refresh token config"
+	switch {
+	case serverDetails.RefreshToken == "" && serverDetails.ArtifactoryRefreshToken == "":
+		return newResult(serverDetails, check, Skipped, "no refresh token is configured")
+	case serverDetails.AccessToken == "":
+		// The access token is required for refreshing it.
+		return newResult(serverDetails, check, Failed, "a refresh token is configured without an access token")
+	case serverDetails.RefreshToken != "" && serverDetails.DisableTokenRefresh:
+		return newResult(serverDetails, check, Warning, "token refresh is disabled for the server")
+	default:
+		return newResult(serverDetails, check, Unknown, "configured, but whether the refresh token is valid can't be checked without refreshing it")
+	}
+}
+
+func checkClientCertificate(serverDetails *config.ServerDetails) CheckResult {
+	const check = "Client certificate"
+	certPath, keyPath := serverDetails.ClientCertPath, serverDetails.ClientCertKeyPath
+	if certPath == "" && keyPath == "" {
+		return newResult(serverDetails, check, Skipped, "no client certificate is configured")
+	}
+	if certPath == "" {
+		return newResult(serverDetails, check, Failed, "a client certificate key is configured without a client certificate")
+	}
+	// The key may be included in the certificate file, and may be encrypted.
+	certificate, err := serverDetails.LoadClientCertificate()
+	if err != nil {
+		return newResult(serverDetails, check, Failed, "failed loading the client certificate: "+err.Error())
+	}
+	if certificate.Leaf != nil && time.Now().After(certificate.Leaf.NotAfter) {
+		return newResult(serverDetails, check, Failed, fmt.Sprintf("the client certificate expired at %s", certificate.Leaf.NotAfter.Format(time.RFC3339)))
+	}
+	return newResult(serverDetails, check, Ok, certPath)
+}
+
+func checkSshKey(serverDetails *config.ServerDetails) CheckResult {
+	const check = "SSH key"
+	keyPath := serverDetails.SshKeyPath
+	if keyPath == "" {
+		return newResult(serverDetails, check, Skipped, "no SSH key is configured")
+	}
+	fileInfo, err := os.Stat(keyPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return newResult(serverDetails, check, Failed, fmt.Sprintf("the SSH key file '%s' does not exist", keyPath))
+		}
+		return newResult(serverDetails, check, Failed, err.Error())
+	}
+	if fileInfo.IsDir() {
+		return newResult(serverDetails, check, Failed, fmt.Sprintf("the SSH key path '%s' is a directory", keyPath))
+	}
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return newResult(serverDetails, check, Failed, err.Error())
+	}
+	if !strings.Contains(string(content), "PRIVATE KEY") {
+		return newResult(serverDetails, check, Warning, fmt.Sprintf("the SSH key file '%s' does not seem to contain a private key", keyPath))
+	}
+	return newResult(serverDetails, check, Ok, keyPath)
+}
@@ -2,8 +2,13 @@ package login
 
 import (
 	"fmt"
+	"io"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
 	"github.com/jfrog/jfrog-cli-core/v2/general"
+	generic "github.com/jfrog/jfrog-cli-core/v2/general/token"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -19,11 +24,19 @@ type LoginCommand struct {
 	disableTokenRefresh *bool
 	// When true, preserve per-service URL prompts (Artifactory/Distribution/Xray/Mission Control/Pipelines)
 	// and skip the browser-based web login, for Artifactory v6.x self-hosted customers.
-	legacy bool
+	legacy          bool
+	webLoginOptions utils.WebLoginOptions
+	// If set, the login is non-interactive, using the login method. See SetPlatformUrl.
+	platformUrl       string
+	loginMethod       LoginMethod
+	oidcParams        *generic.OidcParams
+	accessTokenReader io.Reader
+	outputFormat      format.OutputFormat
+	result            *LoginResult
 }
 
 func NewLoginCommand() *LoginCommand {
-	return &LoginCommand{}
+	return &LoginCommand{outputFormat: format.Json}
 }
 
 func (lc *LoginCommand) SetServerId(serverId string) *LoginCommand {
@@ -38,32 +51,80 @@ func (lc *LoginCommand) SetDisableTokenRefresh(disableTokenRefresh *bool) *Login
 	return lc
 }
 
+// SetWebLoginOptions sets the options of the web login, such as a headless login for SSH sessions and containers.
+func (lc *LoginCommand) SetWebLoginOptions(webLoginOptions utils.WebLoginOptions) *LoginCommand {
+	lc.webLoginOptions = webLoginOptions
+	return lc
+}
+
 func (lc *LoginCommand) SetLegacy(legacy bool) *LoginCommand {
 	lc.legacy = legacy
 	return lc
 }
 
+// SetPlatformUrl sets the URL of the JFrog Platform to log in to without prompting, for provisioning scripts.
+// A non-interactive login requires a login method, and prints a LoginResult.
+func (lc *LoginCommand) SetPlatformUrl(platformUrl string) *LoginCommand {
+	lc.platformUrl = platformUrl
+	return lc
+}
+
+func (lc *LoginCommand) SetLoginMethod(loginMethod LoginMethod) *LoginCommand {
+	lc.loginMethod = loginMethod
+	return lc
+}
+
+// SetOidcParams sets the parameters of the OIDC token exchange, used by the OIDC login method.
+// If no OIDC token is provided, it's acquired from the environment of the OIDC provider.
+func (lc *LoginCommand) SetOidcParams(oidcParams *generic.OidcParams) *LoginCommand {
+	lc.oidcParams = oidcParams
+	return lc
+}
+
+// SetAccessTokenReader sets the reader of the access token used by the access token login method, which is usually the standard input.
+func (lc *LoginCommand) SetAccessTokenReader(accessTokenReader io.Reader) *LoginCommand {
+	lc.accessTokenReader = accessTokenReader
+	return lc
+}
+
+// SetOutputFormat sets the format of the non-interactive login result. The default format is JSON.
+func (lc *LoginCommand) SetOutputFormat(outputFormat format.OutputFormat) *LoginCommand {
+	lc.outputFormat = outputFormat
+	return lc
+}
+
+// Result returns the result of a non-interactive login.
+func (lc *LoginCommand) Result() *LoginResult {
+	return lc.result
+}
+
 func (lc *LoginCommand) Run() error {
+	if err := lc.validateNonInteractiveLogin(); err != nil {
+		return err
+	}
+	if lc.platformUrl != "" {
+		return lc.nonInteractiveLogin()
+	}
 	if lc.serverId != "" {
-		return existingServerLogin(lc.serverId, lc.disableTokenRefresh, lc.legacy)
+		return existingServerLogin(lc.serverId, lc.disableTokenRefresh, lc.legacy, lc.webLoginOptions)
 	}
 	configurations, err := config.GetAllServersConfigs()
 	if err != nil {
 		return err
 	}
 	if len(configurations) == 0 {
-		return newConfLogin(lc.disableTokenRefresh, lc.legacy)
+		return newConfLogin(lc.disableTokenRefresh, lc.legacy, lc.webLoginOptions)
 	}
-	return existingConfLogin(configurations, lc.disableTokenRefresh, lc.legacy)
+	return existingConfLogin(configurations, lc.disableTokenRefresh, lc.legacy, lc.webLoginOptions)
 }
 
-func newConfLogin(disableTokenRefresh *bool, legacy bool) error {
+func newConfLogin(disableTokenRefresh *bool, legacy bool, webLoginOptions utils.WebLoginOptions) error {
 	platformUrl := promptPlatformUrl()
 	newServer := config.ServerDetails{Url: platformUrl}
 	if disableTokenRefresh != nil {
 		newServer.DisableTokenRefresh = *disableTokenRefresh
 	}
-	return general.ConfigServerWithDeducedId(&newServer, true, !legacy, legacy)
+	return general.ConfigServerWithDeducedIdWithOptions(&newServer, true, !legacy, legacy, webLoginOptions)
 }
 
 func promptPlatformUrl() string {
@@ -79,30 +140,30 @@ func promptPlatformUrl() string {
 	return platformUrl
 }
 
-func existingConfLogin(configurations []*config.ServerDetails, disableTokenRefresh *bool, legacy bool) error {
+func existingConfLogin(configurations []*config.ServerDetails, disableTokenRefresh *bool, legacy bool, webLoginOptions utils.WebLoginOptions) error {
 	selectedChoice, err := promptAddOrEdit(configurations)
 	if err != nil {
 		return err
 	}
 	if selectedChoice == newSeverPlaceholder {
-		return selectedNewServer(disableTokenRefresh, legacy)
+		return selectedNewServer(disableTokenRefresh, legacy, webLoginOptions)
 	}
-	return existingServerLogin(selectedChoice, disableTokenRefresh, legacy)
+	return existingServerLogin(selectedChoice, disableTokenRefresh, legacy, webLoginOptions)
 }
 
 // When configurations exist and the user chose to log in with a new server we direct him to a clean config process,
 // where he will be prompted for server ID and URL.
-func selectedNewServer(disableTokenRefresh *bool, legacy bool) error {
+func selectedNewServer(disableTokenRefresh *bool, legacy bool, webLoginOptions utils.WebLoginOptions) error {
 	var newServer *config.ServerDetails
 	if disableTokenRefresh != nil {
 		newServer = &config.ServerDetails{DisableTokenRefresh: *disableTokenRefresh}
 	}
-	return general.ConfigServerAsDefault(newServer, "", true, !legacy, legacy)
+	return general.ConfigServerAsDefaultWithOptions(newServer, "", true, !legacy, legacy, webLoginOptions)
 }
 
 // When a user chose to log in to an existing server,
 // we run a config process while keeping all his current server details except credentials.
-func existingServerLogin(serverId string, disableTokenRefresh *bool, legacy bool) error {
+func existingServerLogin(serverId string, disableTokenRefresh *bool, legacy bool, webLoginOptions utils.WebLoginOptions) error {
 	serverDetails, err := commands.GetConfig(serverId, true)
 	if err != nil {
 		return err
@@ -121,7 +182,7 @@ func existingServerLogin(serverId string, disableTokenRefresh *bool, legacy bool
 	if disableTokenRefresh != nil {
 		serverDetails.DisableTokenRefresh = *disableTokenRefresh
 	}
-	return general.ConfigServerAsDefault(serverDetails, serverId, true, !legacy, legacy)
+	return general.ConfigServerAsDefaultWithOptions(serverDetails, serverId, true, !legacy, legacy, webLoginOptions)
 }
 
 // Prompt a list of all server IDs and an option for a new server, and let the user choose to which to log in.
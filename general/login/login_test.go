@@ -1,10 +1,17 @@
 package login
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	generic "github.com/jfrog/jfrog-cli-core/v2/general/token"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	utilsTests "github.com/jfrog/jfrog-cli-core/v2/utils/tests"
+	"github.com/jfrog/jfrog-client-go/auth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -54,3 +61,93 @@ func TestRunWithNonExistentServerId(t *testing.T) {
 	err = lc.Run()
 	assert.ErrorContains(t, err, "non-existent-server")
 }
+
+const pingPath = "/artifactory/api/system/ping"
+
+func TestNonInteractiveLoginWithAccessToken(t *testing.T) {
+	cleanUp, err := utilsTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUp()
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The ping is sent with the access token.
+		if r.URL.Path != pingPath || r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := w.Write([]byte("OK"))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	lc := NewLoginCommand().SetServerId("acme").SetPlatformUrl(testServer.URL).SetLoginMethod(AccessTokenLoginMethod).
+		SetAccessTokenReader(strings.NewReader("access-token\n")).SetOutputFormat(format.Table)
+	require.NoError(t, lc.Run())
+	assert.Equal(t, &LoginResult{ServerId: "acme", Url: testServer.URL + "/", Method: AccessTokenLoginMethod}, lc.Result())
+
+	serverDetails, err := config.GetDefaultServerConf()
+	require.NoError(t, err)
+	assert.Equal(t, "acme", serverDetails.ServerId)
+	assert.Equal(t, "access-token", serverDetails.AccessToken)
+	assert.Equal(t, testServer.URL+"/artifactory/", serverDetails.ArtifactoryUrl)
+
+	// A rejected token fails the login.
+	lc = NewLoginCommand().SetServerId("other").SetPlatformUrl(testServer.URL).SetLoginMethod(AccessTokenLoginMethod).
+		SetAccessTokenReader(strings.NewReader("wrong-token"))
+	assert.ErrorContains(t, lc.Run(), "failed to connect")
+	_, err = config.GetSpecificConfig("other", false, false)
+	assert.Error(t, err)
+
+	// An empty token is rejected before any request is sent.
+	lc = NewLoginCommand().SetPlatformUrl(testServer.URL).SetLoginMethod(AccessTokenLoginMethod).SetAccessTokenReader(strings.NewReader(" "))
+	assert.ErrorContains(t, lc.Run(), "no access token")
+}
+
+func TestNonInteractiveLoginWithOidc(t *testing.T) {
+	cleanUp, err := utilsTests.SetJfrogHome()
+	require.NoError(t, err)
+	defer cleanUp()
+	var requestsCount int
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestsCount++
+		switch r.URL.Path {
+		case pingPath:
+			_, err := w.Write([]byte("OK"))
+			assert.NoError(t, err)
+		case "/access/api/v1/oidc/token":
+			content, err := json.Marshal(auth.OidcTokenResponseData{CreateTokenResponseData: auth.CreateTokenResponseData{CommonTokenParams: auth.CommonTokenParams{AccessToken: "exchanged-token"}}})
+			assert.NoError(t, err)
+			_, err = w.Write(content)
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	lc := NewLoginCommand().SetServerId("ci").SetPlatformUrl(testServer.URL).SetLoginMethod(OidcLoginMethod).
+		SetOidcParams(&generic.OidcParams{ProviderName: "github-oidc", TokenId: "id-token"})
+	require.NoError(t, lc.Run())
+	assert.Equal(t, "ci", lc.Result().ServerId)
+	assert.Equal(t, OidcLoginMethod, lc.Result().Method)
+
+	serverDetails, err := config.GetSpecificConfig("ci", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "exchanged-token", serverDetails.AccessToken)
+	assert.True(t, serverDetails.IsDefault)
+	require.NotNil(t, serverDetails.OidcExchangeParams)
+	assert.Equal(t, "github-oidc", serverDetails.OidcExchangeParams.ProviderName)
+
+	// A missing provider name is rejected before any request is sent.
+	requestsCount = 0
+	lc = NewLoginCommand().SetPlatformUrl(testServer.URL).SetLoginMethod(OidcLoginMethod)
+	assert.ErrorContains(t, lc.Run(), "OIDC provider name")
+	assert.Zero(t, requestsCount)
+}
+
+func TestNonInteractiveLoginValidation(t *testing.T) {
+	assert.ErrorContains(t, NewLoginCommand().SetPlatformUrl("https://acme.jfrog.io").Run(), "a login method must be provided")
+	assert.ErrorContains(t, NewLoginCommand().SetLoginMethod(WebLoginMethod).Run(), "the platform URL must be provided")
+	assert.ErrorContains(t, NewLoginCommand().SetPlatformUrl("https://acme.jfrog.io").SetLoginMethod(WebLoginMethod).SetLegacy(true).Run(), "legacy")
+	assert.ErrorContains(t, NewLoginCommand().SetPlatformUrl("https://acme.jfrog.io").SetLoginMethod("password").Run(), "unsupported login method")
+	assert.ErrorContains(t, NewLoginCommand().SetPlatformUrl("ssh://acme.jfrog.io:1339").SetLoginMethod(WebLoginMethod).Run(), "SSH")
+}
@@ -0,0 +1,162 @@
+package login
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/general"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type LoginMethod string
+
+const (
+	// A headless web login, printing the login URL for opening on any device.
+	WebLoginMethod LoginMethod = "web"
+	// An exchange of the OIDC token of the CI job or workload. See SetOidcParams.
+	OidcLoginMethod LoginMethod = "oidc"
+	// An access token read from the standard input, or from the reader set by SetAccessTokenReader.
+	AccessTokenLoginMethod LoginMethod = "access-token"
+)
+
+// The result of a non-interactive login, printed for provisioning scripts.
+type LoginResult struct {
+	ServerId string      `json:"serverId" col-name:"Server ID"`
+	Url      string      `json:"url" col-name:"URL"`
+	User     string      `json:"user,omitempty" col-name:"User"`
+	Method   LoginMethod `json:"method" col-name:"Login Method"`
+}
+
+// Logs in to the platform URL without prompting, using the login method, and configures the server as the default server.
+// The server ID is deduced from the platform URL, unless set explicitly.
+func (lc *LoginCommand) nonInteractiveLogin() error {
+	if fileutils.IsSshUrl(lc.platformUrl) {
+		return errorutils.CheckErrorf("a non-interactive login cannot be performed via SSH. Please provide the HTTP(S) URL of the JFrog Platform")
+	}
+	platformUrl := clientUtils.AddTrailingSlashIfNeeded(lc.platformUrl)
+	serverId := lc.serverId
+	if serverId == "" {
+		var err error
+		if serverId, err = general.DeduceServerId(platformUrl); err != nil {
+			return err
+		}
+	}
+	serverDetails := &config.ServerDetails{Url: platformUrl, ArtifactoryUrl: platformUrl + "artifactory/"}
+	if lc.disableTokenRefresh != nil {
+		serverDetails.DisableTokenRefresh = *lc.disableTokenRefresh
+	}
+	configCmd := commands.NewConfigCommand(commands.AddOrEdit, serverId).SetInteractive(false).SetMakeDefault(true).SetDetails(serverDetails)
+	// The login details are validated before the connectivity.
+	switch lc.loginMethod {
+	case WebLoginMethod:
+		// The web login validates the connectivity itself.
+		webLoginOptions := lc.webLoginOptions
+		webLoginOptions.Headless = true
+		token, err := utils.DoWebLoginWithOptions(serverDetails, webLoginOptions)
+		if err != nil {
+			return err
+		}
+		serverDetails.AccessToken = token.AccessToken
+		serverDetails.RefreshToken = token.RefreshToken
+		serverDetails.WebLogin = true
+	case OidcLoginMethod:
+		if lc.oidcParams == nil || lc.oidcParams.ProviderName == "" {
+			return errorutils.CheckErrorf("the OIDC provider name must be provided for an OIDC login")
+		}
+		if err := validateConnectivity(serverDetails); err != nil {
+			return err
+		}
+		configCmd.SetOIDCParams(lc.oidcParams)
+	case AccessTokenLoginMethod:
+		accessToken, err := readAccessToken(lc.accessTokenReader)
+		if err != nil {
+			return err
+		}
+		serverDetails.AccessToken = accessToken
+		// The ping is sent with the access token, to fail if the token is rejected.
+		if err = validateConnectivity(serverDetails); err != nil {
+			return err
+		}
+	}
+	if err := configCmd.Run(); err != nil {
+		return err
+	}
+	// Read the server back, since the config command may complete its details, such as the user.
+	configuredServer, err := config.GetSpecificConfig(serverId, false, false)
+	if err != nil {
+		return err
+	}
+	lc.result = &LoginResult{ServerId: serverId, Url: configuredServer.Url, User: configuredServer.User, Method: lc.loginMethod}
+	return lc.printResult()
+}
+
+// Pings Artifactory with the server's credentials, if any, to fail before the server is configured, if the platform can't be reached.
+func validateConnectivity(serverDetails *config.ServerDetails) error {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	if _, err = servicesManager.Ping(); err != nil {
+		return errorutils.CheckErrorf("failed to connect to the JFrog Platform at %s: %s", serverDetails.Url, err.Error())
+	}
+	return nil
+}
+
+func readAccessToken(reader io.Reader) (string, error) {
+	if reader == nil {
+		return "", errorutils.CheckErrorf("no access token was provided via stdin")
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	accessToken := strings.TrimSpace(string(content))
+	if accessToken == "" {
+		return "", errorutils.CheckErrorf("no access token was provided via stdin")
+	}
+	return accessToken, nil
+}
+
+func (lc *LoginCommand) printResult() error {
+	switch lc.outputFormat {
+	case format.Json, format.None:
+		content, err := coreutils.GetJsonIndent(lc.result)
+		if err != nil {
+			return err
+		}
+		log.Output(content)
+		return nil
+	case format.Table:
+		return coreutils.PrintTable([]LoginResult{*lc.result}, "", "", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. The supported formats are: %s", lc.outputFormat, format.Join([]format.OutputFormat{format.Json, format.Table}))
+	}
+}
+
+func (lc *LoginCommand) validateNonInteractiveLogin() error {
+	if lc.platformUrl == "" && (lc.loginMethod != "" || lc.oidcParams != nil || lc.accessTokenReader != nil) {
+		return errorutils.CheckErrorf("the platform URL must be provided for a non-interactive login")
+	}
+	if lc.platformUrl != "" && lc.legacy {
+		return errorutils.CheckErrorf("a non-interactive login doesn't support the legacy login")
+	}
+	if lc.platformUrl == "" {
+		return nil
+	}
+	switch lc.loginMethod {
+	case WebLoginMethod, OidcLoginMethod, AccessTokenLoginMethod:
+		return nil
+	case "":
+		return errorutils.CheckErrorf("a login method must be provided for a non-interactive login: %s, %s or %s", WebLoginMethod, OidcLoginMethod, AccessTokenLoginMethod)
+	default:
+		return errorutils.CheckErrorf("unsupported login method '%s'. The supported methods for a non-interactive login are: %s, %s or %s", lc.loginMethod, WebLoginMethod, OidcLoginMethod, AccessTokenLoginMethod)
+	}
+}
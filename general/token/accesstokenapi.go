@@ -0,0 +1,90 @@
+package token
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const tokensApi = "api/v1/tokens"
+
+// A request to the tokens API of the Access service.
+type tokensApiRequest struct {
+	method string
+	path   string
+	// The form content of the request, if any.
+	form url.Values
+	// The error message reported if the server responds with 404.
+	notFoundMessage string
+}
+
+// Sends a request to the tokens API of the Access service, and returns the response body.
+// The request is sent with the server's HTTP settings, and the access token of the server is refreshed before sending the request, if needed.
+func sendTokensApiRequest(serverDetails *config.ServerDetails, request tokensApiRequest) ([]byte, error) {
+	client, accessDetails, err := rtUtils.CreateAccessHttpClient(serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	httpClientDetails := accessDetails.CreateHttpClientDetails()
+	requestUrl := clientUtils.AddTrailingSlashIfNeeded(accessDetails.GetUrl()) + request.path
+	var response *http.Response
+	var body []byte
+	switch request.method {
+	case http.MethodGet:
+		response, body, _, err = client.SendGet(requestUrl, true, &httpClientDetails)
+	case http.MethodPost:
+		if httpClientDetails.Headers == nil {
+			httpClientDetails.Headers = make(map[string]string)
+		}
+		httpClientDetails.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+		response, body, err = client.SendPost(requestUrl, []byte(request.form.Encode()), &httpClientDetails)
+	case http.MethodDelete:
+		response, body, err = client.SendDelete(requestUrl, nil, &httpClientDetails)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported method of the tokens API: %s", request.method)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return body, nil
+	case http.StatusNotFound:
+		return nil, errorutils.CheckErrorf("%s: %s", request.notFoundMessage, response.Status)
+	default:
+		return nil, errorutils.CheckErrorf("Access response: %s\n%s", response.Status, clientUtils.IndentJson(body))
+	}
+}
+
+// Prints the output of a token command in the requested format. Rows are printed as a table, while content is printed as JSON.
+func printTokenCommandOutput(outputFormat format.OutputFormat, content, rows interface{}, emptyTableMessage string) error {
+	switch outputFormat {
+	case format.Json:
+		jsonContent, err := coreutils.GetJsonIndent(content)
+		if err != nil {
+			return err
+		}
+		log.Output(jsonContent)
+		return nil
+	case format.Table, format.None:
+		return coreutils.PrintTable(rows, "", emptyTableMessage, false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. The supported formats are: %s", outputFormat, format.Join([]format.OutputFormat{format.Table, format.Json}))
+	}
+}
+
+// Formats a Unix time in seconds for printing. A zero time is formatted as an empty string.
+func formatUnixTime(unixTime int64) string {
+	if unixTime == 0 {
+		return ""
+	}
+	return time.Unix(unixTime, 0).UTC().Format(time.RFC3339)
+}
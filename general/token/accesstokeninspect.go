@@ -0,0 +1,110 @@
+package token
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The claims of a JWT access token.
+type TokenClaims struct {
+	TokenId  string   `json:"tokenId,omitempty"`
+	Subject  string   `json:"subject,omitempty"`
+	Issuer   string   `json:"issuer,omitempty"`
+	Scope    string   `json:"scope,omitempty"`
+	Audience []string `json:"audience,omitempty"`
+	IssuedAt string   `json:"issuedAt,omitempty"`
+	// Empty if the token doesn't expire.
+	Expiry  string `json:"expiry,omitempty"`
+	Expired bool   `json:"expired"`
+}
+
+type tokenClaimRow struct {
+	Claim string `col-name:"Claim"`
+	Value string `col-name:"Value"`
+}
+
+// AccessTokenInspectCommand decodes and prints the claims of an access token offline.
+// The signature of the token is not verified.
+type AccessTokenInspectCommand struct {
+	token        string
+	outputFormat format.OutputFormat
+	claims       *TokenClaims
+}
+
+func NewAccessTokenInspectCommand() *AccessTokenInspectCommand {
+	return &AccessTokenInspectCommand{outputFormat: format.Table}
+}
+
+func (ati *AccessTokenInspectCommand) SetToken(token string) *AccessTokenInspectCommand {
+	ati.token = token
+	return ati
+}
+
+func (ati *AccessTokenInspectCommand) SetOutputFormat(outputFormat format.OutputFormat) *AccessTokenInspectCommand {
+	ati.outputFormat = outputFormat
+	return ati
+}
+
+func (ati *AccessTokenInspectCommand) Claims() *TokenClaims {
+	return ati.claims
+}
+
+func (ati *AccessTokenInspectCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (ati *AccessTokenInspectCommand) CommandName() string {
+	return "jf_access_token_inspect"
+}
+
+func (ati *AccessTokenInspectCommand) Run() (err error) {
+	ati.claims, err = DecodeTokenClaims(ati.token)
+	if err != nil {
+		return err
+	}
+	expiry := ati.claims.Expiry
+	if expiry == "" {
+		expiry = "never"
+	}
+	rows := []tokenClaimRow{
+		{"Token ID", ati.claims.TokenId},
+		{"Subject", ati.claims.Subject},
+		{"Issuer", ati.claims.Issuer},
+		{"Scope", ati.claims.Scope},
+		{"Audience", strings.Join(ati.claims.Audience, ", ")},
+		{"Issued At", ati.claims.IssuedAt},
+		{"Expiry", expiry},
+	}
+	if ati.claims.Expired {
+		rows = append(rows, tokenClaimRow{"Expired", "true"})
+	}
+	return printTokenCommandOutput(ati.outputFormat, ati.claims, rows, "")
+}
+
+// Decodes the claims of a JWT access token, without verifying its signature.
+func DecodeTokenClaims(token string) (*TokenClaims, error) {
+	token = strings.TrimSpace(token)
+	if strings.Count(token, ".") != 2 {
+		return nil, errorutils.CheckErrorf("the token isn't a JWT access token. Reference tokens can't be decoded, and should be inspected by their token ID")
+	}
+	payload, err := auth.ExtractPayloadFromAccessToken(token)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to decode the claims of the token: %s", err.Error())
+	}
+	expiry := int64(payload.ExpirationTime)
+	return &TokenClaims{
+		TokenId:  payload.JwtId,
+		Subject:  payload.Subject,
+		Issuer:   payload.Issuer,
+		Scope:    payload.Scope,
+		Audience: strings.Fields(payload.Audience),
+		IssuedAt: formatUnixTime(int64(payload.IssuedAt)),
+		Expiry:   formatUnixTime(expiry),
+		Expired:  expiry != 0 && time.Now().Unix() >= expiry,
+	}, nil
+}
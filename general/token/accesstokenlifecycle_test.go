@@ -0,0 +1,120 @@
+package token
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestToken(t *testing.T, claims map[string]interface{}) string {
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	return "header." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+// A stand-in for the tokens API of the Access service, holding a single token with the ID "token-id".
+// Revoking by value succeeds for any token value except "missing-token".
+func createTokensApiTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	revoked := &[]string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer admin-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/access/api/v1/tokens":
+			_, err := w.Write([]byte(`{"tokens":[{"token_id":"token-id","subject":"jfac@01/users/admin","scope":"applied-permissions/user","issued_at":1700000000,"expiry":1800000000,"refreshable":true}]}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodDelete && r.URL.Path == "/access/api/v1/tokens/token-id":
+			*revoked = append(*revoked, strings.TrimPrefix(r.URL.Path, "/access/api/v1/tokens/"))
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && r.URL.Path == "/access/api/v1/tokens/revoke":
+			assert.NoError(t, r.ParseForm())
+			if r.PostForm.Get("token") == "missing-token" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			*revoked = append(*revoked, r.PostForm.Get("token"))
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(testServer.Close)
+	return testServer, revoked
+}
+
+func TestAccessTokenList(t *testing.T) {
+	testServer, _ := createTokensApiTestServer(t)
+	serverDetails := &config.ServerDetails{Url: testServer.URL + "/", AccessToken: "admin-token"}
+	for _, outputFormat := range []format.OutputFormat{format.Table, format.Json} {
+		listCommand := NewAccessTokenListCommand().SetServerDetails(serverDetails).SetOutputFormat(outputFormat)
+		require.NoError(t, listCommand.Run())
+		require.Len(t, listCommand.Tokens(), 1)
+		assert.Equal(t, TokenInfo{TokenId: "token-id", Subject: "jfac@01/users/admin", Scope: "applied-permissions/user", IssuedAt: 1700000000, Expiry: 1800000000, Refreshable: true}, listCommand.Tokens()[0])
+	}
+
+	serverDetails.AccessToken = "wrong-token"
+	assert.ErrorContains(t, NewAccessTokenListCommand().SetServerDetails(serverDetails).Run(), "401")
+
+	// A server without the tokens API.
+	serverDetails = &config.ServerDetails{Url: testServer.URL + "/unsupported/", AccessToken: "admin-token"}
+	assert.ErrorContains(t, NewAccessTokenListCommand().SetServerDetails(serverDetails).Run(), "doesn't support listing tokens")
+}
+
+func TestAccessTokenInspect(t *testing.T) {
+	now := time.Now().Unix()
+	token := buildTestToken(t, map[string]interface{}{"jti": "token-id", "sub": "jfac@01/users/admin", "iss": "jfac@01", "scp": "applied-permissions/user", "aud": "*@*", "iat": now, "exp": now + 3600})
+	inspectCommand := NewAccessTokenInspectCommand().SetToken(token).SetOutputFormat(format.Json)
+	require.NoError(t, inspectCommand.Run())
+	claims := inspectCommand.Claims()
+	assert.Equal(t, "token-id", claims.TokenId)
+	assert.Equal(t, "jfac@01/users/admin", claims.Subject)
+	assert.Equal(t, "applied-permissions/user", claims.Scope)
+	assert.Equal(t, []string{"*@*"}, claims.Audience)
+	assert.Equal(t, time.Unix(now+3600, 0).UTC().Format(time.RFC3339), claims.Expiry)
+	assert.False(t, claims.Expired)
+
+	// An expired token, and a token that never expires.
+	claims, err := DecodeTokenClaims(buildTestToken(t, map[string]interface{}{"exp": now - 1}))
+	require.NoError(t, err)
+	assert.True(t, claims.Expired)
+	claims, err = DecodeTokenClaims(buildTestToken(t, map[string]interface{}{"sub": "user"}))
+	require.NoError(t, err)
+	assert.Empty(t, claims.Expiry)
+	assert.False(t, claims.Expired)
+
+	assert.ErrorContains(t, NewAccessTokenInspectCommand().SetToken("cmVmdGtuOjAx").Run(), "isn't a JWT")
+}
+
+func TestAccessTokenRevoke(t *testing.T) {
+	testServer, revoked := createTokensApiTestServer(t)
+	serverDetails := &config.ServerDetails{Url: testServer.URL + "/", AccessToken: "admin-token"}
+
+	revokeCommand := NewAccessTokenRevokeCommand().SetServerDetails(serverDetails).SetTokenId("token-id")
+	require.NoError(t, revokeCommand.Run())
+	assert.Equal(t, &RevokeResult{TokenId: "token-id", Status: "revoked"}, revokeCommand.Result())
+
+	// Revoke by value. The token ID is read from JWT access tokens for printing.
+	token := buildTestToken(t, map[string]interface{}{"jti": "token-id"})
+	revokeCommand = NewAccessTokenRevokeCommand().SetServerDetails(serverDetails).SetToken(token).SetOutputFormat(format.Json)
+	require.NoError(t, revokeCommand.Run())
+	assert.Equal(t, &RevokeResult{TokenId: "token-id", Status: "revoked"}, revokeCommand.Result())
+	revokeCommand = NewAccessTokenRevokeCommand().SetServerDetails(serverDetails).SetToken("reference-token")
+	require.NoError(t, revokeCommand.Run())
+	assert.Equal(t, &RevokeResult{Status: "revoked"}, revokeCommand.Result())
+	assert.Equal(t, []string{"token-id", token, "reference-token"}, *revoked)
+
+	assert.ErrorContains(t, NewAccessTokenRevokeCommand().SetServerDetails(serverDetails).SetTokenId("missing").Run(), "wasn't found")
+	assert.ErrorContains(t, NewAccessTokenRevokeCommand().SetServerDetails(serverDetails).SetToken("missing-token").Run(), "wasn't found")
+	assert.ErrorContains(t, NewAccessTokenRevokeCommand().SetServerDetails(serverDetails).Run(), "either a token ID or a token value")
+}
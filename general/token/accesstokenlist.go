@@ -0,0 +1,101 @@
+package token
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The details of a token, as returned by the tokens API of the Access service.
+type TokenInfo struct {
+	TokenId     string `json:"token_id"`
+	Subject     string `json:"subject,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description,omitempty"`
+	Issuer      string `json:"issuer,omitempty"`
+	IssuedAt    int64  `json:"issued_at,omitempty"`
+	Expiry      int64  `json:"expiry,omitempty"`
+	Refreshable bool   `json:"refreshable,omitempty"`
+}
+
+type tokenInfoRow struct {
+	TokenId     string `col-name:"Token ID"`
+	Subject     string `col-name:"Subject"`
+	Scope       string `col-name:"Scope"`
+	Description string `col-name:"Description"`
+	IssuedAt    string `col-name:"Issued At"`
+	Expiry      string `col-name:"Expiry"`
+	Refreshable string `col-name:"Refreshable"`
+}
+
+// AccessTokenListCommand lists the tokens of the current user. Admins get the tokens of all the users.
+type AccessTokenListCommand struct {
+	serverDetails *config.ServerDetails
+	outputFormat  format.OutputFormat
+	tokens        []TokenInfo
+}
+
+func NewAccessTokenListCommand() *AccessTokenListCommand {
+	return &AccessTokenListCommand{outputFormat: format.Table}
+}
+
+func (atl *AccessTokenListCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenListCommand {
+	atl.serverDetails = serverDetails
+	return atl
+}
+
+func (atl *AccessTokenListCommand) SetOutputFormat(outputFormat format.OutputFormat) *AccessTokenListCommand {
+	atl.outputFormat = outputFormat
+	return atl
+}
+
+func (atl *AccessTokenListCommand) Tokens() []TokenInfo {
+	return atl.tokens
+}
+
+func (atl *AccessTokenListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atl.serverDetails, nil
+}
+
+func (atl *AccessTokenListCommand) CommandName() string {
+	return "jf_access_token_list"
+}
+
+func (atl *AccessTokenListCommand) Run() error {
+	body, err := sendTokensApiRequest(atl.serverDetails, tokensApiRequest{
+		method: http.MethodGet,
+		path:   tokensApi,
+		// Servers whose Access service has no tokens API respond with 404.
+		notFoundMessage: "the server doesn't support listing tokens. Listing tokens requires a JFrog Platform server which provides the tokens API of the Access service",
+	})
+	if err != nil {
+		return err
+	}
+	var response struct {
+		Tokens []TokenInfo `json:"tokens"`
+	}
+	if err = errorutils.CheckError(json.Unmarshal(body, &response)); err != nil {
+		return err
+	}
+	atl.tokens = response.Tokens
+	if atl.tokens == nil {
+		atl.tokens = []TokenInfo{}
+	}
+	rows := make([]tokenInfoRow, 0, len(atl.tokens))
+	for _, token := range atl.tokens {
+		rows = append(rows, tokenInfoRow{
+			TokenId:     token.TokenId,
+			Subject:     token.Subject,
+			Scope:       token.Scope,
+			Description: token.Description,
+			IssuedAt:    formatUnixTime(token.IssuedAt),
+			Expiry:      formatUnixTime(token.Expiry),
+			Refreshable: strconv.FormatBool(token.Refreshable),
+		})
+	}
+	return printTokenCommandOutput(atl.outputFormat, atl.tokens, rows, "No tokens were found.")
+}
@@ -0,0 +1,97 @@
+package token
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type RevokeResult struct {
+	// Empty if the token was revoked by its value, and its token ID can't be read from it.
+	TokenId string `json:"tokenId,omitempty" col-name:"Token ID"`
+	Status  string `json:"status" col-name:"Status"`
+	// The credentials of the server which expire soon, included in the JSON output instead of a warning.
+	CredentialExpiry []config.CredentialExpiry `json:"credentialExpiry,omitempty"`
+}
+
+// AccessTokenRevokeCommand revokes a token by its ID, or by its value.
+type AccessTokenRevokeCommand struct {
+	serverDetails *config.ServerDetails
+	tokenId       string
+	token         string
+	outputFormat  format.OutputFormat
+	result        *RevokeResult
+	// The expiring credentials of the server, reported before the command runs.
+	credentialExpiry []config.CredentialExpiry
+}
+
+func NewAccessTokenRevokeCommand() *AccessTokenRevokeCommand {
+	return &AccessTokenRevokeCommand{outputFormat: format.Table}
+}
+
+func (atr *AccessTokenRevokeCommand) SetServerDetails(serverDetails *config.ServerDetails) *AccessTokenRevokeCommand {
+	atr.serverDetails = serverDetails
+	return atr
+}
+
+func (atr *AccessTokenRevokeCommand) SetTokenId(tokenId string) *AccessTokenRevokeCommand {
+	atr.tokenId = tokenId
+	return atr
+}
+
+func (atr *AccessTokenRevokeCommand) SetToken(token string) *AccessTokenRevokeCommand {
+	atr.token = token
+	return atr
+}
+
+func (atr *AccessTokenRevokeCommand) SetOutputFormat(outputFormat format.OutputFormat) *AccessTokenRevokeCommand {
+	atr.outputFormat = outputFormat
+	return atr
+}
+
+func (atr *AccessTokenRevokeCommand) Result() *RevokeResult {
+	return atr.result
+}
+
+func (atr *AccessTokenRevokeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return atr.serverDetails, nil
+}
+
+// Includes the expiring credentials of the server in the JSON output.
+func (atr *AccessTokenRevokeCommand) ReportCredentialExpiry(expiringCredentials []config.CredentialExpiry) bool {
+	if atr.outputFormat != format.Json {
+		return false
+	}
+	atr.credentialExpiry = expiringCredentials
+	return true
+}
+
+func (atr *AccessTokenRevokeCommand) CommandName() string {
+	return "jf_access_token_revoke"
+}
+
+func (atr *AccessTokenRevokeCommand) Run() error {
+	if (atr.tokenId == "") == (atr.token == "") {
+		return errorutils.CheckErrorf("either a token ID or a token value must be provided")
+	}
+	request := tokensApiRequest{notFoundMessage: "the token wasn't found on the Access service"}
+	tokenId := atr.tokenId
+	if tokenId != "" {
+		request.method, request.path = http.MethodDelete, tokensApi+"/"+url.PathEscape(tokenId)
+	} else {
+		request.method, request.path, request.form = http.MethodPost, tokensApi+"/revoke", url.Values{"token": {atr.token}}
+		// The token ID is only printed, so it's read from JWT access tokens if possible.
+		if payload, err := auth.ExtractPayloadFromAccessToken(atr.token); err == nil {
+			tokenId = payload.JwtId
+		}
+	}
+	if _, err := sendTokensApiRequest(atr.serverDetails, request); err != nil {
+		return err
+	}
+	atr.result = &RevokeResult{TokenId: tokenId, Status: "revoked", CredentialExpiry: atr.credentialExpiry}
+	return printTokenCommandOutput(atr.outputFormat, atr.result, []RevokeResult{*atr.result}, "")
+}
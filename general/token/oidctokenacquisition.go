@@ -0,0 +1,160 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// Set by GitHub Actions in jobs with the 'id-token: write' permission.
+	gitHubIdTokenRequestUrlEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	gitHubIdTokenRequestTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+	// Set by the Azure workload identity webhook.
+	azureFederatedTokenFileEnv = "AZURE_FEDERATED_TOKEN_FILE"
+	// The token file of the pod's service account, mounted by Kubernetes.
+	//#nosec G101 // False positive: This is a path, not a hardcoded credential.
+	defaultKubernetesTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	idTokenRequestTimeout      = 30 * time.Second
+)
+
+func init() {
+	// The access tokens exchanged for OIDC tokens are re-exchanged by the config package, with OIDC tokens acquired by this package.
+	config.SetOidcTokenIdAcquirer(acquireOidcTokenIdForReExchange)
+}
+
+// Acquires the OIDC token for re-exchanging the access token of a server, by the provider type saved in its OIDC exchange parameters.
+func acquireOidcTokenIdForReExchange(providerType, audience string) (string, error) {
+	if providerType == "" {
+		return "", errorutils.CheckErrorf("the OIDC provider type is missing")
+	}
+	oidcProviderType, err := parseOidcProviderType(providerType)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return AcquireOidcTokenId(oidcProviderType, audience)
+}
+
+// Acquires the OIDC token of the current job or workload from the local environment of the provider.
+// Returns an empty token if the environment doesn't provide one, or if the provider type doesn't support automatic acquisition.
+func AcquireOidcTokenId(providerType OidcProviderType, audience string) (tokenId string, err error) {
+	switch providerType {
+	case GitHub:
+		tokenId, err = getGitHubIdToken(audience)
+	case GitLab:
+		tokenId, err = getGitLabIdToken()
+	case Kubernetes:
+		tokenId, err = getKubernetesIdToken()
+	case Azure:
+		tokenId, err = readIdTokenFile(os.Getenv(azureFederatedTokenFileEnv))
+	}
+	if err != nil || tokenId == "" {
+		return
+	}
+	log.Debug(fmt.Sprintf("Acquired the OIDC token from the %s provider's environment.", providerType))
+	return
+}
+
+// Requests an ID token from the GitHub Actions token service, with the provided audience if not empty.
+func getGitHubIdToken(audience string) (tokenId string, err error) {
+	requestUrl := os.Getenv(gitHubIdTokenRequestUrlEnv)
+	requestToken := os.Getenv(gitHubIdTokenRequestTokenEnv)
+	if requestUrl == "" || requestToken == "" {
+		return "", nil
+	}
+	if audience != "" {
+		parsedUrl, err := url.Parse(requestUrl)
+		if err != nil {
+			return "", errorutils.CheckErrorf("invalid %s: %s", gitHubIdTokenRequestUrlEnv, err.Error())
+		}
+		query := parsedUrl.Query()
+		query.Set("audience", audience)
+		parsedUrl.RawQuery = query.Encode()
+		requestUrl = parsedUrl.String()
+	}
+	request, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	request.Header.Set("Authorization", "Bearer "+requestToken)
+	request.Header.Set("Accept", "application/json")
+	client := &http.Client{Timeout: idTokenRequestTimeout}
+	response, err := client.Do(request)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(response.Body.Close()))
+	}()
+	body, err := io.ReadAll(response.Body)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", errorutils.CheckErrorf("failed to request the OIDC token from GitHub Actions: %s\n%s", response.Status, string(body))
+	}
+	var idTokenResponse struct {
+		Value string `json:"value"`
+	}
+	if err = errorutils.CheckError(json.Unmarshal(body, &idTokenResponse)); err != nil {
+		return "", err
+	}
+	if idTokenResponse.Value == "" {
+		return "", errorutils.CheckErrorf("the GitHub Actions token service returned an empty OIDC token")
+	}
+	return idTokenResponse.Value, nil
+}
+
+// Reads the ID token of the GitLab CI job. GitLab no longer provides an ID token by default, so the job must declare one.
+// ID tokens declared with other names under 'id_tokens' should be provided explicitly with --oidc-token-id.
+func getGitLabIdToken() (string, error) {
+	if tokenId := os.Getenv(coreutils.OidcGitLabIdToken); tokenId != "" {
+		return tokenId, nil
+	}
+	return "", errorutils.CheckErrorf("no OIDC token is available in the GitLab CI job. Declare an ID token named %s under the 'id_tokens' keyword of the job, or provide the OIDC token explicitly", coreutils.OidcGitLabIdToken)
+}
+
+// Reads the projected service account token of the pod, or the token file set by JFROG_CLI_OIDC_TOKEN_FILE.
+func getKubernetesIdToken() (string, error) {
+	if tokenFile := os.Getenv(coreutils.OidcTokenFile); tokenFile != "" {
+		tokenId, err := readIdTokenFile(tokenFile)
+		if err == nil && tokenId == "" {
+			err = errorutils.CheckErrorf("the OIDC token file '%s' doesn't exist", tokenFile)
+		}
+		return tokenId, err
+	}
+	return readIdTokenFile(defaultKubernetesTokenFile)
+}
+
+// Returns the trimmed content of the token file, or an empty token if the path is empty or the file doesn't exist.
+// An empty token file is an error.
+func readIdTokenFile(tokenFile string) (string, error) {
+	if tokenFile == "" {
+		return "", nil
+	}
+	exists, err := fileutils.IsFileExists(tokenFile, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	content, err := os.ReadFile(tokenFile)
+	if errorutils.CheckError(err) != nil {
+		return "", err
+	}
+	tokenId := strings.TrimSpace(string(content))
+	if tokenId == "" {
+		return "", errorutils.CheckErrorf("the OIDC token file '%s' is empty", tokenFile)
+	}
+	return tokenId, nil
+}
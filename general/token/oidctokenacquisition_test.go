@@ -0,0 +1,100 @@
+package token
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireOidcTokenIdFromGitHub(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer request-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := w.Write([]byte(`{"count":1,"value":"github-token-for-` + r.URL.Query().Get("audience") + `"}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, gitHubIdTokenRequestUrlEnv, testServer.URL+"/token?api-version=2.0")()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, gitHubIdTokenRequestTokenEnv, "request-token")()
+
+	tokenId, err := AcquireOidcTokenId(GitHub, "jfrog-github")
+	assert.NoError(t, err)
+	assert.Equal(t, "github-token-for-jfrog-github", tokenId)
+
+	defer testsutils.SetEnvWithCallbackAndAssert(t, gitHubIdTokenRequestTokenEnv, "wrong-token")()
+	_, err = AcquireOidcTokenId(GitHub, "jfrog-github")
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestAcquireOidcTokenIdFromGitLab(t *testing.T) {
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.OidcGitLabIdToken, "gitlab-token")()
+	tokenId, err := AcquireOidcTokenId(GitLab, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "gitlab-token", tokenId)
+
+	// The job must declare the ID token.
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.OidcGitLabIdToken, "")()
+	_, err = AcquireOidcTokenId(GitLab, "")
+	assert.ErrorContains(t, err, "'id_tokens'")
+}
+
+func TestAcquireOidcTokenIdFromFiles(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0600))
+
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.OidcTokenFile, tokenFile)()
+	tokenId, err := AcquireOidcTokenId(Kubernetes, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "file-token", tokenId)
+
+	defer testsutils.SetEnvWithCallbackAndAssert(t, azureFederatedTokenFileEnv, tokenFile)()
+	tokenId, err = AcquireOidcTokenId(Azure, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "file-token", tokenId)
+
+	// An empty token file is an error.
+	require.NoError(t, os.WriteFile(tokenFile, []byte("\n"), 0600))
+	_, err = AcquireOidcTokenId(Kubernetes, "")
+	assert.ErrorContains(t, err, "is empty")
+
+	// A missing token file that was explicitly set is an error.
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.OidcTokenFile, filepath.Join(t.TempDir(), "missing"))()
+	_, err = AcquireOidcTokenId(Kubernetes, "")
+	assert.ErrorContains(t, err, "doesn't exist")
+}
+
+func TestAcquireOidcTokenIdWithoutEnvironment(t *testing.T) {
+	for _, envName := range []string{gitHubIdTokenRequestUrlEnv, azureFederatedTokenFileEnv} {
+		defer testsutils.SetEnvWithCallbackAndAssert(t, envName, "")()
+	}
+	for _, providerType := range []OidcProviderType{GitHub, Azure, GenericOidc} {
+		tokenId, err := AcquireOidcTokenId(providerType, "")
+		assert.NoError(t, err)
+		assert.Empty(t, tokenId, providerType.String())
+	}
+}
+
+func TestAcquireOidcTokenIdForReExchange(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("file-token"), 0600))
+	defer testsutils.SetEnvWithCallbackAndAssert(t, azureFederatedTokenFileEnv, tokenFile)()
+
+	// The provider type is saved in the config by its name, and parsed case-insensitively.
+	tokenId, err := acquireOidcTokenIdForReExchange("azure", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "file-token", tokenId)
+
+	_, err = acquireOidcTokenIdForReExchange("", "")
+	assert.ErrorContains(t, err, "provider type is missing")
+	_, err = acquireOidcTokenIdForReExchange("bitbucket", "")
+	assert.ErrorContains(t, err, "unsupported oidc provider type")
+}
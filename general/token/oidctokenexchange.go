@@ -11,26 +11,22 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/access/services"
 	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
-const (
-	//#nosec G101 // False positive: This is not a hardcoded credential.
-	grantType = "urn:ietf:params:oauth:grant-type:token-exchange"
-	//#nosec G101 jfrog-ignore
-	subjectTokenType = "urn:ietf:params:oauth:token-type:id_token"
-)
-
 type OidcProviderType int
 
 const (
 	GitHub OidcProviderType = iota
 	Azure
 	GenericOidc
+	GitLab
+	Kubernetes
 )
 
 func (p OidcProviderType) String() string {
-	return [...]string{"GitHub", "Azure", "GenericOidc"}[p]
+	return [...]string{"GitHub", "Azure", "GenericOidc", "GitLab", "Kubernetes"}[p]
 }
 
 func OidcProviderTypeFromString(providerType string) (OidcProviderType, error) {
@@ -39,15 +35,9 @@ func OidcProviderTypeFromString(providerType string) (OidcProviderType, error) {
 		// If no provider type is provided, return 0 (GitHub) as default
 		oidcProviderType = 0
 	} else {
-		switch strings.ToLower(providerType) {
-		case strings.ToLower(GitHub.String()):
-			oidcProviderType = GitHub
-		case strings.ToLower(Azure.String()):
-			oidcProviderType = Azure
-		case strings.ToLower(GenericOidc.String()):
-			oidcProviderType = GenericOidc
-		default:
-			return 0, fmt.Errorf("unsupported oidc provider type: %s", providerType)
+		var err error
+		if oidcProviderType, err = parseOidcProviderType(providerType); err != nil {
+			return 0, err
 		}
 	}
 	// This is used for usage reporting
@@ -57,6 +47,16 @@ func OidcProviderTypeFromString(providerType string) (OidcProviderType, error) {
 	return oidcProviderType, nil
 }
 
+// Parses the provider type, case-insensitively. Unlike OidcProviderTypeFromString, an empty provider type is unsupported.
+func parseOidcProviderType(providerType string) (OidcProviderType, error) {
+	for _, oidcProviderType := range []OidcProviderType{GitHub, Azure, GenericOidc, GitLab, Kubernetes} {
+		if strings.EqualFold(providerType, oidcProviderType.String()) {
+			return oidcProviderType, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported oidc provider type: %s", providerType)
+}
+
 type OidcTokenExchangeCommand struct {
 	*OidcParams
 	serverDetails *config.ServerDetails
@@ -182,6 +182,14 @@ func (otc *OidcTokenExchangeCommand) PrintResponseToConsole() {
 }
 
 func (otc *OidcTokenExchangeCommand) Run() (err error) {
+	if otc.TokenId == "" {
+		if otc.TokenId, err = AcquireOidcTokenId(otc.ProviderType, otc.Audience); err != nil {
+			return err
+		}
+		if otc.TokenId == "" {
+			return errorutils.CheckErrorf("no OIDC token was provided, and it couldn't be acquired from the %s provider's environment", otc.ProviderType)
+		}
+	}
 	servicesManager, err := rtUtils.CreateAccessServiceManager(otc.serverDetails, false)
 	if err != nil {
 		return err
@@ -194,8 +202,8 @@ func (otc *OidcTokenExchangeCommand) Run() (err error) {
 
 func (otc *OidcTokenExchangeCommand) getOidcTokenParams() services.CreateOidcTokenParams {
 	oidcTokenParams := services.CreateOidcTokenParams{}
-	oidcTokenParams.GrantType = grantType
-	oidcTokenParams.SubjectTokenType = subjectTokenType
+	oidcTokenParams.GrantType = config.OidcTokenExchangeGrantType
+	oidcTokenParams.SubjectTokenType = config.OidcSubjectTokenType
 	oidcTokenParams.OidcTokenID = otc.TokenId
 	oidcTokenParams.ProjectKey = otc.ProjectKey
 	oidcTokenParams.ApplicationKey = otc.ApplicationKey
@@ -1,6 +1,7 @@
 package general
 
 import (
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -13,14 +14,20 @@ const defaultServerId = "default-server"
 
 // Deduce the server ID from the URL and add server details to config.
 func ConfigServerWithDeducedId(server *config.ServerDetails, interactive, webLogin, legacy bool) error {
-	serverId, err := deduceServerId(server.Url)
+	return ConfigServerWithDeducedIdWithOptions(server, interactive, webLogin, legacy, utils.WebLoginOptions{})
+}
+
+// Same as ConfigServerWithDeducedId, with the options of the web login.
+func ConfigServerWithDeducedIdWithOptions(server *config.ServerDetails, interactive, webLogin, legacy bool, webLoginOptions utils.WebLoginOptions) error {
+	serverId, err := DeduceServerId(server.Url)
 	if err != nil {
 		return err
 	}
-	return ConfigServerAsDefault(server, serverId, interactive, webLogin, legacy)
+	return ConfigServerAsDefaultWithOptions(server, serverId, interactive, webLogin, legacy, webLoginOptions)
 }
 
-func deduceServerId(platformUrl string) (string, error) {
+// Deduce the server ID from the platform URL's host name, or use a default server ID if the host is an IP address.
+func DeduceServerId(platformUrl string) (string, error) {
 	u, err := url.Parse(platformUrl)
 	if errorutils.CheckError(err) != nil {
 		return "", err
@@ -37,7 +44,12 @@ func deduceServerId(platformUrl string) (string, error) {
 
 // Add the given server details to the CLI's config by running a 'jf config' command, and make it the default server.
 func ConfigServerAsDefault(server *config.ServerDetails, serverId string, interactive, webLogin, legacy bool) error {
+	return ConfigServerAsDefaultWithOptions(server, serverId, interactive, webLogin, legacy, utils.WebLoginOptions{})
+}
+
+// Same as ConfigServerAsDefault, with the options of the web login.
+func ConfigServerAsDefaultWithOptions(server *config.ServerDetails, serverId string, interactive, webLogin, legacy bool, webLoginOptions utils.WebLoginOptions) error {
 	return commands.NewConfigCommand(commands.AddOrEdit, serverId).
-		SetInteractive(interactive).SetUseWebLogin(webLogin).SetLegacy(legacy).
+		SetInteractive(interactive).SetUseWebLogin(webLogin).SetWebLoginOptions(webLoginOptions).SetLegacy(legacy).
 		SetDetails(server).SetMakeDefault(true).Run()
 }
@@ -20,7 +20,7 @@ func TestDeduceServerId(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.url, func(t *testing.T) {
-			serverId, err := deduceServerId(testCase.url)
+			serverId, err := DeduceServerId(testCase.url)
 			assert.NoError(t, err)
 			assert.Equal(t, testCase.expectedServerID, serverId)
 		})
@@ -14,10 +14,13 @@ func CreateServiceManager(serviceDetails *utilsconfig.ServerDetails) (*pipelines
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientConfig.NewConfigBuilder().
+	configBuilder := clientConfig.NewConfigBuilder().
 		SetServiceDetails(pAuth).
-		SetDryRun(false).
-		Build()
+		SetDryRun(false)
+	if _, err = utilsconfig.ApplyHttpSettings(configBuilder, &pipelinesDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -189,10 +189,21 @@ func isEnvFailNoOp() bool {
 	return strings.ToLower(os.Getenv(coreutils.FailNoOp)) == "true"
 }
 
-// Get project key from flag or environment variable
+// Get project key from flag or environment variable, falling back to the project of the active config context
 func GetProject(c *components.Context) string {
-	projectKey := c.GetStringFlagValue("project")
-	return getOrDefaultEnv(projectKey, coreutils.Project)
+	projectKey := getOrDefaultEnv(c.GetStringFlagValue("project"), coreutils.Project)
+	if projectKey != "" {
+		return projectKey
+	}
+	context, err := coreConfig.GetActiveContext()
+	if err != nil {
+		log.Debug("Couldn't resolve the active config context: " + err.Error())
+		return ""
+	}
+	if context == nil {
+		return ""
+	}
+	return context.Project
 }
 
 // Return argument if not empty or retrieve from environment variable
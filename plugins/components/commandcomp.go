@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 )
 
 type Argument struct {
@@ -62,14 +63,30 @@ type Context struct {
 	FlagsUsed        []string
 }
 
-// Get the output format from the --format flag, falling back to None if not set.
-// None means no output format was specified.
+// Get the output format from the --format flag, falling back to the output format of the active config context,
+// and then to None if neither is set. None means no output format was specified.
 // An error is returned if the output format is not supported.
 func (c *Context) GetOutputFormat() (format.OutputFormat, error) {
 	if c.IsFlagSet(format.FlagName) {
 		return format.ParseOutputFormat(c.GetStringFlagValue(format.FlagName), c.supportedFormats)
 	}
-	return format.None, nil
+	return c.getContextOutputFormat(), nil
+}
+
+// Returns the output format of the active config context, if set and supported by the command.
+func (c *Context) getContextOutputFormat() format.OutputFormat {
+	if len(c.supportedFormats) == 0 {
+		return format.None
+	}
+	context, err := config.GetActiveContext()
+	if err != nil || context == nil || context.OutputFormat == "" {
+		return format.None
+	}
+	contextFormat, err := format.ParseOutputFormat(context.OutputFormat, c.supportedFormats)
+	if err != nil {
+		return format.None
+	}
+	return contextFormat
 }
 
 func (c *Context) GetStringFlagValue(flagName string) string {
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const maskedSecret = "***"
+
+// If the audit log is enabled, every save of the config file appends an entry to the audit log, in the JSON lines format.
+// The values of the secrets are never written to the audit log.
+type auditEntry struct {
+	Timestamp string `json:"timestamp"`
+	User      string `json:"user,omitempty"`
+	Hostname  string `json:"hostname,omitempty"`
+	Pid       int    `json:"pid"`
+	// The command line, without the arguments following the first flag, since they may include secrets.
+	Command string         `json:"command,omitempty"`
+	Changes []configChange `json:"changes"`
+}
+
+type configChangeAction string
+
+const (
+	auditActionAdd    configChangeAction = "add"
+	auditActionRemove configChangeAction = "remove"
+	auditActionModify configChangeAction = "modify"
+)
+
+// A change of a server, or of the config itself if ServerId is empty.
+type configChange struct {
+	Action   configChangeAction `json:"action"`
+	ServerId string             `json:"serverId,omitempty"`
+	Fields   []fieldChange      `json:"fields,omitempty"`
+}
+
+type fieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"oldValue,omitempty"`
+	NewValue string `json:"newValue,omitempty"`
+}
+
+// The fields whose values are masked in the audit log.
+var secretJsonFields = map[string]bool{
+	"password":                true,
+	"accessToken":             true,
+	"refreshToken":            true,
+	"artifactoryRefreshToken": true,
+	"sshPassphrase":           true,
+	"clientCertKeyPassphrase": true,
+	"serviceCredentials":      true,
+}
+
+// The audit log is disabled by default, and enabled by the JFROG_CLI_CONFIG_AUDIT_LOG environment variable.
+func isConfigAuditLogEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(coreutils.ConfigAuditLog))
+	return err == nil && enabled
+}
+
+// Reads the current config file for comparing it with the saved config.
+// Failures are ignored, since they shouldn't fail the save, and the config is compared to an empty config instead.
+func readConfForAudit() *Config {
+	config := new(Config)
+	content, err := getConfigFile()
+	if err != nil || len(content) == 0 {
+		return config
+	}
+	if err = json.Unmarshal(content, config); err != nil {
+		log.Debug("Failed reading the current config for the audit log: " + err.Error())
+		return new(Config)
+	}
+	if config.Enc {
+		key, err := getEncryptionKey()
+		if err == nil && key != "" {
+			err = handleSecrets(config, decrypt, key)
+		}
+		if err != nil {
+			log.Debug("Failed decrypting the current config for the audit log: " + err.Error())
+		}
+	}
+	return config
+}
+
+// Returns the changes between the old and the new configs, with the secrets masked.
+func getConfigChanges(oldConfig, newConfig *Config) []configChange {
+	changes := []configChange{}
+	if fields := getFieldChanges(getConfigJsonFields(oldConfig), getConfigJsonFields(newConfig)); len(fields) > 0 {
+		changes = append(changes, configChange{Action: auditActionModify, Fields: fields})
+	}
+	oldServers := make(map[string]*ServerDetails, len(oldConfig.Servers))
+	for _, details := range oldConfig.Servers {
+		oldServers[details.ServerId] = details
+	}
+	for _, details := range newConfig.Servers {
+		oldDetails, exists := oldServers[details.ServerId]
+		delete(oldServers, details.ServerId)
+		if !exists {
+			changes = append(changes, configChange{
+				Action:   auditActionAdd,
+				ServerId: details.ServerId,
+				Fields:   getFieldChanges(map[string]any{}, toJsonFields(details)),
+			})
+			continue
+		}
+		if fields := getFieldChanges(toJsonFields(oldDetails), toJsonFields(details)); len(fields) > 0 {
+			changes = append(changes, configChange{Action: auditActionModify, ServerId: details.ServerId, Fields: fields})
+		}
+	}
+	removedServerIds := make([]string, 0, len(oldServers))
+	for serverId := range oldServers {
+		removedServerIds = append(removedServerIds, serverId)
+	}
+	sort.Strings(removedServerIds)
+	for _, serverId := range removedServerIds {
+		changes = append(changes, configChange{Action: auditActionRemove, ServerId: serverId})
+	}
+	return changes
+}
+
+// Returns the fields of the config itself, excluding the servers.
+func getConfigJsonFields(config *Config) map[string]any {
+	fields := toJsonFields(config)
+	delete(fields, "servers")
+	// The encryption state is not compared, since the new config is compared before being encrypted.
+	delete(fields, "enc")
+	return fields
+}
+
+func toJsonFields(value any) map[string]any {
+	fields := make(map[string]any)
+	content, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(content, &fields)
+	}
+	if err != nil {
+		log.Debug("Failed comparing the config for the audit log: " + err.Error())
+	}
+	return fields
+}
+
+func getFieldChanges(oldFields, newFields map[string]any) []fieldChange {
+	names := make(map[string]bool, len(oldFields)+len(newFields))
+	for name := range oldFields {
+		names[name] = true
+	}
+	for name := range newFields {
+		names[name] = true
+	}
+	var changes []fieldChange
+	for name := range names {
+		oldValue, newValue := oldFields[name], newFields[name]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, fieldChange{
+			Field:    name,
+			OldValue: auditValue(name, oldValue),
+			NewValue: auditValue(name, newValue),
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+func auditValue(field string, value any) string {
+	if value == nil {
+		return ""
+	}
+	if secretJsonFields[field] {
+		return maskedSecret
+	}
+	if stringValue, ok := value.(string); ok {
+		return stringValue
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
+// Appends an entry with the provided changes to the audit log.
+// Failures are logged as warnings, since the config file was already saved.
+func writeAuditEntry(changes []configChange) {
+	if err := appendAuditEntry(newAuditEntry(changes)); err != nil {
+		log.Warn("Failed writing to the config audit log: " + err.Error())
+	}
+}
+
+func newAuditEntry(changes []configChange) *auditEntry {
+	entry := &auditEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Pid:       os.Getpid(),
+		Command:   getAuditCommand(os.Args),
+		Changes:   changes,
+	}
+	if currentUser, err := user.Current(); err == nil {
+		entry.User = currentUser.Username
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		entry.Hostname = hostname
+	}
+	return entry
+}
+
+// Returns the command line, omitting the arguments following the first flag, since they may include secrets.
+func getAuditCommand(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	command := []string{filepath.Base(args[0])}
+	for _, arg := range args[1:] {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		command = append(command, arg)
+	}
+	return strings.Join(command, " ")
+}
+
+func appendAuditEntry(entry *auditEntry) (err error) {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	auditLogPath, err := coreutils.GetJfrogConfigAuditLogFilePath()
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(filepath.Dir(auditLogPath)); err != nil {
+		return err
+	}
+	auditLog, err := os.OpenFile(auditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(auditLog.Close()))
+	}()
+	_, err = auditLog.Write(append(content, '\n'))
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"testing"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigAuditLog(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, true)
+	defer cleanUpTempEnv()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.ConfigAuditLog, "true")()
+	require.NoError(t, SaveServersConf([]*ServerDetails{
+		{ServerId: "server1", Url: "http://server1/", User: "admin", Password: "password1", IsDefault: true},
+		{ServerId: "server2", Url: "http://server2/", AccessToken: "token2"},
+	}))
+	require.NoError(t, SaveServersConf([]*ServerDetails{
+		{ServerId: "server1", Url: "http://server1-new/", User: "admin", Password: "password1-new", IsDefault: true},
+		{ServerId: "server3", Url: "http://server3/", AccessToken: "token3"},
+	}))
+
+	auditLogPath, err := coreutils.GetJfrogConfigAuditLogFilePath()
+	require.NoError(t, err)
+	content, err := os.ReadFile(auditLogPath)
+	require.NoError(t, err)
+	// The secrets should never be written to the audit log, even when the config is encrypted.
+	for _, secret := range []string{"password1", "token2", "token3"} {
+		assert.NotContains(t, string(content), secret)
+	}
+
+	entries := readAuditEntries(t, auditLogPath)
+	require.NotEmpty(t, entries)
+	lastEntry := entries[len(entries)-1]
+	assert.NotEmpty(t, lastEntry.Timestamp)
+	assert.Equal(t, os.Getpid(), lastEntry.Pid)
+	assert.Equal(t, []configChange{
+		{Action: auditActionModify, ServerId: "server1", Fields: []fieldChange{
+			{Field: "password", OldValue: maskedSecret, NewValue: maskedSecret},
+			{Field: "url", OldValue: "http://server1/", NewValue: "http://server1-new/"},
+		}},
+		{Action: auditActionAdd, ServerId: "server3", Fields: []fieldChange{
+			{Field: "accessToken", NewValue: maskedSecret},
+			{Field: "serverId", NewValue: "server3"},
+			{Field: "url", NewValue: "http://server3/"},
+		}},
+		{Action: auditActionRemove, ServerId: "server2"},
+	}, lastEntry.Changes)
+}
+
+func TestConfigAuditLogClientCertPassphrase(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.ConfigAuditLog, "true")()
+	require.NoError(t, SaveServersConf([]*ServerDetails{
+		{ServerId: "server1", Url: "http://server1/", ClientCertPath: "client.p12", ClientCertKeyPassphrase: "passphrase1", IsDefault: true},
+	}))
+
+	auditLogPath, err := coreutils.GetJfrogConfigAuditLogFilePath()
+	require.NoError(t, err)
+	content, err := os.ReadFile(auditLogPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "passphrase1")
+	entries := readAuditEntries(t, auditLogPath)
+	require.NotEmpty(t, entries)
+	changes := entries[len(entries)-1].Changes
+	require.NotEmpty(t, changes)
+	assert.Contains(t, changes[len(changes)-1].Fields, fieldChange{Field: "clientCertKeyPassphrase", NewValue: maskedSecret})
+}
+
+func TestConfigAuditLogDisabled(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	require.NoError(t, SaveServersConf([]*ServerDetails{{ServerId: "server1", Url: "http://server1/", IsDefault: true}}))
+	auditLogPath, err := coreutils.GetJfrogConfigAuditLogFilePath()
+	require.NoError(t, err)
+	assert.NoFileExists(t, auditLogPath)
+}
+
+func TestGetAuditCommand(t *testing.T) {
+	assert.Equal(t, "jf c add my-server", getAuditCommand([]string{"/usr/bin/jf", "c", "add", "my-server", "--password", "secret", "--interactive=false"}))
+	assert.Equal(t, "jf", getAuditCommand([]string{"jf"}))
+	assert.Empty(t, getAuditCommand(nil))
+}
+
+func readAuditEntries(t *testing.T, auditLogPath string) []auditEntry {
+	auditLog, err := os.Open(auditLogPath)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, auditLog.Close())
+	}()
+	var entries []auditEntry
+	scanner := bufio.NewScanner(auditLog)
+	for scanner.Scan() {
+		var entry auditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
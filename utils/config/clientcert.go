@@ -0,0 +1,156 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/youmark/pkcs8"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Returns true if the client certificate file is a PKCS#12 bundle, which includes the private key.
+func IsPkcs12ClientCert(certPath string) bool {
+	extension := strings.ToLower(filepath.Ext(certPath))
+	return extension == ".p12" || extension == ".pfx"
+}
+
+// Returns true if the client certificate can't be loaded by the default client of the services,
+// since it's a PKCS#12 bundle, its private key is encrypted, or its private key is included in the certificate file.
+func (serverDetails *ServerDetails) hasCustomClientCert() bool {
+	return serverDetails.ClientCertPath != "" &&
+		(IsPkcs12ClientCert(serverDetails.ClientCertPath) || serverDetails.ClientCertKeyPassphrase != "" || serverDetails.ClientCertKeyPath == "")
+}
+
+// Returns true if loading the client certificate requires a passphrase.
+// PKCS#12 bundles are always considered encrypted, although their passphrase may be empty.
+func (serverDetails *ServerDetails) IsClientCertEncrypted() (bool, error) {
+	if serverDetails.ClientCertPath == "" {
+		return false, nil
+	}
+	if IsPkcs12ClientCert(serverDetails.ClientCertPath) {
+		return true, nil
+	}
+	keyPem, err := os.ReadFile(serverDetails.getClientCertKeyPath())
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	for block, rest := pem.Decode(keyPem); block != nil; block, rest = pem.Decode(rest) {
+		if isEncryptedPemBlock(block) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Loads the client certificate of the server.
+// The certificate may be a PKCS#12 bundle, or a PEM file with a PEM private key, which may be encrypted with the client certificate key passphrase.
+// If no key path is configured, the key is expected to be included in the certificate file.
+func (serverDetails *ServerDetails) LoadClientCertificate() (tls.Certificate, error) {
+	if IsPkcs12ClientCert(serverDetails.ClientCertPath) {
+		return loadPkcs12ClientCert(serverDetails.ClientCertPath, serverDetails.ClientCertKeyPassphrase)
+	}
+	return loadPemClientCert(serverDetails.ClientCertPath, serverDetails.getClientCertKeyPath(), serverDetails.ClientCertKeyPassphrase)
+}
+
+// Loads only the leaf certificate of the client certificate, without the private key.
+// The certificate of a PEM file is parsed without decrypting the key. A PKCS#12 bundle is decoded whole, since it's encrypted with the passphrase.
+func (serverDetails *ServerDetails) LoadClientCertificateLeaf() (*x509.Certificate, error) {
+	if IsPkcs12ClientCert(serverDetails.ClientCertPath) {
+		certificate, err := serverDetails.LoadClientCertificate()
+		return certificate.Leaf, err
+	}
+	certPem, err := os.ReadFile(serverDetails.ClientCertPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for block, rest := pem.Decode(certPem); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "CERTIFICATE" {
+			leaf, err := x509.ParseCertificate(block.Bytes)
+			return leaf, errorutils.CheckError(err)
+		}
+	}
+	return nil, errorutils.CheckErrorf("no certificate was found in the client certificate file '%s'", serverDetails.ClientCertPath)
+}
+
+func (serverDetails *ServerDetails) getClientCertKeyPath() string {
+	if serverDetails.ClientCertKeyPath == "" {
+		return serverDetails.ClientCertPath
+	}
+	return serverDetails.ClientCertKeyPath
+}
+
+func loadPkcs12ClientCert(certPath, passphrase string) (tls.Certificate, error) {
+	pfxData, err := os.ReadFile(certPath)
+	if err != nil {
+		return tls.Certificate{}, errorutils.CheckError(err)
+	}
+	privateKey, leaf, caCerts, err := pkcs12.DecodeChain(pfxData, passphrase)
+	if err != nil {
+		return tls.Certificate{}, errorutils.CheckErrorf("failed decoding the PKCS#12 client certificate '%s': %s", certPath, err.Error())
+	}
+	certificate := tls.Certificate{Certificate: [][]byte{leaf.Raw}, PrivateKey: privateKey, Leaf: leaf}
+	for _, caCert := range caCerts {
+		certificate.Certificate = append(certificate.Certificate, caCert.Raw)
+	}
+	return certificate, nil
+}
+
+func loadPemClientCert(certPath, keyPath, passphrase string) (tls.Certificate, error) {
+	certPem, err := os.ReadFile(certPath)
+	if err != nil {
+		return tls.Certificate{}, errorutils.CheckError(err)
+	}
+	keyPem, err := os.ReadFile(keyPath)
+	if err != nil {
+		return tls.Certificate{}, errorutils.CheckError(err)
+	}
+	if keyPem, err = decryptPemPrivateKey(keyPem, passphrase); err != nil {
+		return tls.Certificate{}, err
+	}
+	certificate, err := tls.X509KeyPair(certPem, keyPem)
+	return certificate, errorutils.CheckError(err)
+}
+
+// Returns the private key of the PEM data as an unencrypted PEM block.
+// Both PKCS#8 encrypted keys and legacy OpenSSL encrypted keys are supported. Unencrypted keys are returned as is.
+func decryptPemPrivateKey(keyPem []byte, passphrase string) ([]byte, error) {
+	for block, rest := pem.Decode(keyPem); block != nil; block, rest = pem.Decode(rest) {
+		if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			continue
+		}
+		if !isEncryptedPemBlock(block) {
+			return keyPem, nil
+		}
+		if passphrase == "" {
+			return nil, errorutils.CheckErrorf("the client certificate key is encrypted, but no passphrase is configured")
+		}
+		if block.Type == "ENCRYPTED PRIVATE KEY" {
+			privateKey, err := pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(passphrase))
+			if err != nil {
+				return nil, errorutils.CheckErrorf("failed decrypting the client certificate key: %s", err.Error())
+			}
+			der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+		}
+		// Legacy encryption is insecure by design, but it's still used by keys created with older OpenSSL versions.
+		der, err := x509.DecryptPEMBlock(block, []byte(passphrase)) //nolint:staticcheck
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed decrypting the client certificate key: %s", err.Error())
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: der}), nil
+	}
+	// Let the key pair parsing report the missing key.
+	return keyPem, nil
+}
+
+func isEncryptedPemBlock(block *pem.Block) bool {
+	return block.Type == "ENCRYPTED PRIVATE KEY" || x509.IsEncryptedPEMBlock(block) //nolint:staticcheck
+}
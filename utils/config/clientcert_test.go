@@ -0,0 +1,164 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youmark/pkcs8"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+const testClientCertPassphrase = "passphrase"
+
+type testClientCert struct {
+	dir         string
+	privateKey  *ecdsa.PrivateKey
+	certificate *x509.Certificate
+	certPem     []byte
+}
+
+func createTestClientCert(t *testing.T) *testClientCert {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "jfrog-cli-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(certDer)
+	require.NoError(t, err)
+	return &testClientCert{
+		dir:         t.TempDir(),
+		privateKey:  privateKey,
+		certificate: certificate,
+		certPem:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer}),
+	}
+}
+
+func (cert *testClientCert) writeFile(t *testing.T, name string, content []byte) string {
+	path := filepath.Join(cert.dir, name)
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	return path
+}
+
+func (cert *testClientCert) writePkcs12(t *testing.T) string {
+	pfxData, err := pkcs12.Modern.Encode(cert.privateKey, cert.certificate, nil, testClientCertPassphrase)
+	require.NoError(t, err)
+	return cert.writeFile(t, "client.p12", pfxData)
+}
+
+func (cert *testClientCert) writePemKey(t *testing.T, name string, encrypted bool) string {
+	if !encrypted {
+		keyDer, err := x509.MarshalECPrivateKey(cert.privateKey)
+		require.NoError(t, err)
+		return cert.writeFile(t, name, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+	}
+	keyDer, err := pkcs8.MarshalPrivateKey(cert.privateKey, []byte(testClientCertPassphrase), nil)
+	require.NoError(t, err)
+	return cert.writeFile(t, name, pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: keyDer}))
+}
+
+func (cert *testClientCert) writeLegacyEncryptedPemKey(t *testing.T) string {
+	keyDer, err := x509.MarshalECPrivateKey(cert.privateKey)
+	require.NoError(t, err)
+	block, err := x509.EncryptPEMBlock(rand.Reader, "EC PRIVATE KEY", keyDer, []byte(testClientCertPassphrase), x509.PEMCipherAES256) //nolint:staticcheck
+	require.NoError(t, err)
+	return cert.writeFile(t, "legacy.key", pem.EncodeToMemory(block))
+}
+
+func TestLoadClientCertificate(t *testing.T) {
+	cert := createTestClientCert(t)
+	certPath := cert.writeFile(t, "client.pem", cert.certPem)
+	keyPath := cert.writePemKey(t, "client.key", false)
+	encryptedKeyPath := cert.writePemKey(t, "encrypted.key", true)
+	combinedPath := cert.writeFile(t, "combined.pem", append(cert.certPem, readTestFile(t, keyPath)...))
+	testCases := []struct {
+		name              string
+		serverDetails     *ServerDetails
+		expectedEncrypted bool
+		expectedError     string
+	}{
+		{"pem", &ServerDetails{ClientCertPath: certPath, ClientCertKeyPath: keyPath}, false, ""},
+		{"keyInCertificateFile", &ServerDetails{ClientCertPath: combinedPath}, false, ""},
+		{"encryptedPkcs8Key", &ServerDetails{ClientCertPath: certPath, ClientCertKeyPath: encryptedKeyPath, ClientCertKeyPassphrase: testClientCertPassphrase}, true, ""},
+		{"legacyEncryptedKey", &ServerDetails{ClientCertPath: certPath, ClientCertKeyPath: cert.writeLegacyEncryptedPemKey(t), ClientCertKeyPassphrase: testClientCertPassphrase}, true, ""},
+		{"encryptedKeyWithoutPassphrase", &ServerDetails{ClientCertPath: certPath, ClientCertKeyPath: encryptedKeyPath}, true, "no passphrase is configured"},
+		{"encryptedKeyWithWrongPassphrase", &ServerDetails{ClientCertPath: certPath, ClientCertKeyPath: encryptedKeyPath, ClientCertKeyPassphrase: "wrong"}, true, "failed decrypting"},
+		{"pkcs12", &ServerDetails{ClientCertPath: cert.writePkcs12(t), ClientCertKeyPassphrase: testClientCertPassphrase}, true, ""},
+		{"pkcs12WithWrongPassphrase", &ServerDetails{ClientCertPath: cert.writePkcs12(t), ClientCertKeyPassphrase: "wrong"}, true, "failed decoding the PKCS#12"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			encrypted, err := testCase.serverDetails.IsClientCertEncrypted()
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedEncrypted, encrypted)
+
+			certificate, err := testCase.serverDetails.LoadClientCertificate()
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, certificate.Certificate, 1)
+			assert.Equal(t, cert.certificate.Raw, certificate.Certificate[0])
+			assert.True(t, cert.privateKey.Equal(certificate.PrivateKey))
+		})
+	}
+}
+
+func TestCreateHttpClientWithPkcs12ClientCert(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	cert := createTestClientCert(t)
+	clientCas := x509.NewCertPool()
+	clientCas.AddCert(cert.certificate)
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	testServer.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCas}
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	serverDetails := &ServerDetails{Url: testServer.URL, ClientCertPath: cert.writePkcs12(t), ClientCertKeyPassphrase: testClientCertPassphrase, InsecureTls: true}
+	// The default client of the services can't load the PKCS#12 bundle.
+	assert.True(t, serverDetails.HasHttpClientSettings())
+	httpClient, err := serverDetails.CreateHttpClient()
+	require.NoError(t, err)
+	response, err := httpClient.Get(testServer.URL)
+	require.NoError(t, err)
+	assert.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	// Unencrypted PEM files are loaded by the default client.
+	certPath := cert.writeFile(t, "client.pem", cert.certPem)
+	keyPath := cert.writePemKey(t, "client.key", false)
+	assert.False(t, (&ServerDetails{ClientCertPath: certPath, ClientCertKeyPath: keyPath}).HasHttpClientSettings())
+	// A PEM file which includes the key isn't loaded by the default client.
+	combinedPath := cert.writeFile(t, "combined.pem", append(cert.certPem, readTestFile(t, keyPath)...))
+	assert.True(t, (&ServerDetails{ClientCertPath: combinedPath}).HasHttpClientSettings())
+}
+
+func readTestFile(t *testing.T, path string) []byte {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return content
+}
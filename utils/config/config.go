@@ -52,34 +52,56 @@ func IsServerConfExists() (bool, error) {
 // If defaultOrEmpty: return empty details if no configurations found, or default conf for empty serverId.
 // Exclude refreshable tokens when working with external tools (build tools, curl, etc.) or when sending requests not via ArtifactoryHttpClient.
 func GetSpecificConfig(serverId string, defaultOrEmpty bool, excludeRefreshableTokens bool) (*ServerDetails, error) {
-	configs, err := GetAllServersConfigs()
+	conf, err := readConf()
 	if err != nil {
 		return nil, err
 	}
-
-	if defaultOrEmpty {
-		if len(configs) == 0 {
-			return new(ServerDetails), nil
-		}
-		if len(serverId) == 0 {
-			details, err := GetDefaultConfiguredConf(configs)
-			if excludeRefreshableTokens {
-				excludeRefreshableTokensFromDetails(details)
-			}
-			return details, errorutils.CheckError(err)
-		}
+	configs := applyEnvOverlay(conf.Servers)
+	if defaultOrEmpty && len(configs) == 0 {
+		return new(ServerDetails), nil
 	}
-
-	details, err := getServerConfByServerId(serverId, configs)
+	details, err := getServerConf(conf, configs, serverId, defaultOrEmpty)
 	if err != nil {
 		return nil, err
 	}
+	applyInMemoryTokens(details)
 	if excludeRefreshableTokens {
 		excludeRefreshableTokensFromDetails(details)
 	}
 	return details, nil
 }
 
+// Returns the configured server as saved in the config file, without the values of the environment variables and the tokens kept in memory.
+// Used to export the config, which should include only the saved config.
+// If defaultOrEmpty: return empty details if no configurations found, or default conf for empty serverId.
+func GetSavedServerConfig(serverId string, defaultOrEmpty bool) (*ServerDetails, error) {
+	conf, err := readConf()
+	if err != nil {
+		return nil, err
+	}
+	if defaultOrEmpty && len(conf.Servers) == 0 {
+		return new(ServerDetails), nil
+	}
+	return getServerConf(conf, conf.Servers, serverId, defaultOrEmpty)
+}
+
+// Returns the server from the configs, with its secrets resolved from the secret store of the config.
+func getServerConf(conf *Config, configs []*ServerDetails, serverId string, defaultOrEmpty bool) (details *ServerDetails, err error) {
+	if defaultOrEmpty && len(serverId) == 0 {
+		details, err = getActiveContextOrDefaultConf(conf, configs)
+		err = errorutils.CheckError(err)
+	} else {
+		details, err = getServerConfByServerId(serverId, configs)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = resolveSecrets(details, conf.SecretStore); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
 // Disables the refreshable tokens mechanism if set in details.
 // We identify the refreshable tokens mechanism by having both conditions:
 // 1. Non-empty username and password
@@ -115,17 +137,48 @@ func GetDefaultConfiguredConf(configs []*ServerDetails) (*ServerDetails, error)
 
 // Returns default artifactory conf. Returns nil if default server doesn't exists.
 func GetDefaultServerConf() (*ServerDetails, error) {
-	configurations, err := GetAllServersConfigs()
+	conf, err := readConf()
 	if err != nil {
 		return nil, err
 	}
 
-	if len(configurations) == 0 {
+	configs := applyEnvOverlay(conf.Servers)
+	if len(configs) == 0 {
 		log.Debug("No servers were configured.")
 		return nil, err
 	}
 
-	return GetDefaultConfiguredConf(configurations)
+	details, err := getActiveContextOrDefaultConf(conf, configs)
+	if err != nil {
+		return nil, err
+	}
+	if err = resolveSecrets(details, conf.SecretStore); err != nil {
+		return nil, err
+	}
+	applyInMemoryTokens(details)
+	return details, nil
+}
+
+// Returns the server of the active context, if a context is active and it references a server.
+// Otherwise, returns the default server.
+// The contexts are taken from the provided config, which was already read.
+func getActiveContextOrDefaultConf(conf *Config, configs []*ServerDetails) (*ServerDetails, error) {
+	context, err := getActiveContext(func() ([]*Context, error) {
+		return conf.Contexts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var details *ServerDetails
+	if context == nil || context.ServerId == "" {
+		details, err = GetDefaultConfiguredConf(configs)
+	} else {
+		details, err = getServerConfByServerId(context.ServerId, configs)
+	}
+	if err == nil && details != nil && context != nil {
+		details.contextOutputFormat = context.OutputFormat
+	}
+	return details, err
 }
 
 // Returns the configured server or error if the server id not found
@@ -135,6 +188,15 @@ func getServerConfByServerId(serverId string, configs []*ServerDetails) (*Server
 			return conf, nil
 		}
 	}
+	// The IDs of servers defined by environment variables are normalized, so they should be matched by their normalized form.
+	// A copy is returned with the requested server ID, to keep the casing of the ID used by the user.
+	for _, conf := range configs {
+		if conf.definedByEnv && toEnvServerId(conf.ServerId) == toEnvServerId(serverId) {
+			envServer := *conf
+			envServer.ServerId = serverId
+			return &envServer, nil
+		}
+	}
 	return nil, errorutils.CheckErrorf("Server ID '%s' does not exist.", serverId)
 }
 
@@ -148,6 +210,9 @@ func GetAndRemoveConfiguration(serverName string, configs []*ServerDetails) (*Se
 	return nil, configs
 }
 
+// Returns all the servers of the config file.
+// If a secret store is configured, the secrets are kept as references, to avoid accessing the secret store for every listed server.
+// Use ResolveServerSecrets to resolve the secrets of the servers which are used.
 func GetAllServersConfigs() ([]*ServerDetails, error) {
 	conf, err := readConf()
 	if err != nil {
@@ -160,21 +225,55 @@ func GetAllServersConfigs() ([]*ServerDetails, error) {
 	return details, nil
 }
 
+// Returns all the servers, including the servers defined by environment variables, with the environment variables applied.
+// The returned servers should not be saved, since they may contain values which aren't in the config file.
+func GetAllServersConfigsWithEnvOverlay() ([]*ServerDetails, error) {
+	configs, err := GetAllServersConfigs()
+	if err != nil {
+		return nil, err
+	}
+	return applyEnvOverlay(configs), nil
+}
+
 func SaveServersConf(details []*ServerDetails) error {
 	conf, err := readConf()
 	if err != nil {
 		return err
 	}
+	if err = deleteUnusedSecrets(conf.Servers, details, conf.SecretStore); err != nil {
+		// Unused secrets don't affect the config, so failing to delete them shouldn't fail the save.
+		log.Warn("Failed deleting unused secrets from the secret store: " + err.Error())
+	}
 	conf.Servers = details
 	conf.Version = strconv.Itoa(coreutils.GetCliConfigVersion())
 	return saveConfig(conf)
 }
 
 func saveConfig(config *Config) error {
+	if IsConfigReadOnly() {
+		return errorutils.CheckError(ErrConfigReadOnly)
+	}
 	cloneConfig, err := config.Clone()
 	if err != nil {
 		return err
 	}
+	spec := getSecretStoreSpec(cloneConfig)
+	if cloneConfig.SecretStore != "" && spec != cloneConfig.SecretStore {
+		// The secret store was disabled or replaced, so the secrets are read from the store the config was saved with.
+		if err = restoreSecrets(cloneConfig); err != nil {
+			return err
+		}
+	}
+	err = storeSecrets(cloneConfig, spec)
+	if err != nil {
+		return err
+	}
+	// The changes are computed before encrypting, since the encrypted secrets differ on every save.
+	var changes []configChange
+	auditLogEnabled := isConfigAuditLogEnabled()
+	if auditLogEnabled {
+		changes = getConfigChanges(readConfForAudit(), cloneConfig)
+	}
 	err = cloneConfig.encrypt()
 	if err != nil {
 		return err
@@ -194,6 +293,9 @@ func saveConfig(config *Config) error {
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
+	if auditLogEnabled {
+		writeAuditEntry(changes)
+	}
 	return nil
 }
 
@@ -366,6 +468,12 @@ func convertIfNeeded(content []byte) ([]byte, error) {
 		fallthrough
 	case "5":
 		content, err = convertConfigV5toV6(content)
+		if err != nil {
+			return nil, err
+		}
+		fallthrough
+	case "6":
+		content, err = convertConfigV6toV7(content)
 	}
 	if err != nil {
 		return nil, err
@@ -378,8 +486,9 @@ func convertIfNeeded(content []byte) ([]byte, error) {
 		return nil, err
 	}
 	result.Version = strconv.Itoa(coreutils.GetCliConfigVersion())
-	err = saveConfig(result)
-	if err != nil {
+	if IsConfigReadOnly() {
+		log.Debug("The converted config is not saved, since the config is read-only.")
+	} else if err = saveConfig(result); err != nil {
 		return nil, err
 	}
 	content, err = json.Marshal(&result)
@@ -469,6 +578,18 @@ func convertConfigV5toV6(content []byte) ([]byte, error) {
 	return content, errorutils.CheckError(err)
 }
 
+func convertConfigV6toV7(content []byte) ([]byte, error) {
+	config := new(ConfigV6)
+	err := json.Unmarshal(content, &config)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+
+	result := config.Convert()
+	content, err = json.Marshal(&result)
+	return content, errorutils.CheckError(err)
+}
+
 func GetJfrogDependenciesPath() (string, error) {
 	dependenciesDir := os.Getenv(coreutils.DependenciesDir)
 	if dependenciesDir != "" {
@@ -512,7 +633,15 @@ func getLegacyConfigFilePath(version int) (string, error) {
 
 // Config represents the CLI latest config version.
 type Config struct {
+	ConfigV7
+}
+
+// Version 7 adds the contexts, the secret store, and the optional per-service credentials of the servers.
+type ConfigV7 struct {
 	ConfigV6
+	Contexts []*Context `json:"contexts,omitempty"`
+	// The secret store in which the secrets of the servers are kept, if the servers reference their secrets.
+	SecretStore string `json:"secretStore,omitempty"`
 }
 
 type ConfigV6 struct {
@@ -533,6 +662,13 @@ type ConfigV4 struct {
 	Enc            bool                   `json:"enc,omitempty"`
 }
 
+// The fields added by version 7 are optional, so the servers are kept as is.
+func (o *ConfigV6) Convert() *ConfigV7 {
+	config := new(ConfigV7)
+	config.ConfigV6 = *o
+	return config
+}
+
 func (o *ConfigV5) Convert() *ConfigV6 {
 	config := new(ConfigV6)
 	config.Servers = o.Servers
@@ -599,11 +735,83 @@ type ServerDetails struct {
 	ArtifactoryTokenRefreshInterval int    `json:"tokenRefreshInterval,omitempty"`
 	ClientCertPath                  string `json:"clientCertPath,omitempty"`
 	ClientCertKeyPath               string `json:"clientCertKeyPath,omitempty"`
+	ClientCertKeyPassphrase         string `json:"clientCertKeyPassphrase,omitempty"`
 	ServerId                        string `json:"serverId,omitempty"`
 	IsDefault                       bool   `json:"isDefault,omitempty"`
 	InsecureTls                     bool   `json:"-"`
 	WebLogin                        bool   `json:"webLogin,omitempty"`
 	DisableTokenRefresh             bool   `json:"disableTokenRefresh,omitempty"`
+	// The HTTP client settings of the server. See CreateHttpClient.
+	Proxy              string `json:"proxy,omitempty"`
+	CaBundlePath       string `json:"caBundlePath,omitempty"`
+	DialTimeoutSecs    int    `json:"dialTimeoutSecs,omitempty"`
+	RequestTimeoutSecs int    `json:"requestTimeoutSecs,omitempty"`
+	// Nil if not configured, since zero retries is a valid setting. The retry wait is applied only if the retries are configured.
+	HttpRetries            *int `json:"httpRetries,omitempty"`
+	HttpRetryWaitMilliSecs int  `json:"httpRetryWaitMilliSecs,omitempty"`
+	// Optional credentials for specific services, keyed by the service name (ArtifactoryService, XrayService, etc.).
+	// When credentials are set for a service, they are used for the service instead of the server credentials.
+	ServiceCredentials map[string]*ServiceCredentials `json:"serviceCredentials,omitempty"`
+	// The parameters of the OIDC token exchange which issued the access token, if configured with OIDC.
+	// Used for re-exchanging the access token before it expires, since it has no refresh token.
+	OidcExchangeParams *OidcExchangeParams `json:"oidcExchangeParams,omitempty"`
+	// The environment variables which set the server's fields, keyed by the JSON name of the field. See applyEnvOverlay.
+	envVars map[string]string
+	// The output format of the active context, if the server was selected by the active context.
+	contextOutputFormat string
+	// True if the server is not in the config file, and is defined by environment variables only.
+	definedByEnv bool
+}
+
+// The names of the services, used as the keys of the ServerDetails.ServiceCredentials map.
+const (
+	ArtifactoryService  = "artifactory"
+	DistributionService = "distribution"
+	XrayService         = "xray"
+	CatalogService      = "catalog"
+	PipelinesService    = "pipelines"
+	AccessService       = "access"
+	JfConnectService    = "jfconnect"
+	LifecycleService    = "lifecycle"
+	EvidenceService     = "evidence"
+	ApptrustService     = "apptrust"
+	MetadataService     = "metadata"
+	OnemodelService     = "onemodel"
+)
+
+// The services which may have their own credentials.
+var Services = []string{ArtifactoryService, DistributionService, XrayService, CatalogService, PipelinesService, AccessService,
+	JfConnectService, LifecycleService, EvidenceService, ApptrustService, MetadataService, OnemodelService}
+
+// ServiceCredentials are the credentials used to access a specific service of the server.
+type ServiceCredentials struct {
+	User        string `json:"user,omitempty"`
+	Password    string `json:"password,omitempty"`    // #nosec G117 -- config struct for auth
+	AccessToken string `json:"accessToken,omitempty"` // #nosec G117 -- config struct for auth
+}
+
+func (serviceCredentials *ServiceCredentials) IsEmpty() bool {
+	return serviceCredentials == nil || serviceCredentials.User == "" && serviceCredentials.Password == "" && serviceCredentials.AccessToken == ""
+}
+
+// Returns the credentials configured for the provided service, or nil if the service uses the server credentials.
+func (serverDetails *ServerDetails) GetServiceCredentials(service string) *ServiceCredentials {
+	serviceCredentials := serverDetails.ServiceCredentials[service]
+	if serviceCredentials.IsEmpty() {
+		return nil
+	}
+	return serviceCredentials
+}
+
+func (serverDetails *ServerDetails) SetServiceCredentials(service string, serviceCredentials *ServiceCredentials) {
+	if serviceCredentials.IsEmpty() {
+		delete(serverDetails.ServiceCredentials, service)
+		return
+	}
+	if serverDetails.ServiceCredentials == nil {
+		serverDetails.ServiceCredentials = make(map[string]*ServiceCredentials)
+	}
+	serverDetails.ServiceCredentials[service] = serviceCredentials
 }
 
 // Deprecated
@@ -616,6 +824,11 @@ func (serverDetails *ServerDetails) IsEmpty() bool {
 	return len(serverDetails.ServerId) == 0 && serverDetails.Url == ""
 }
 
+// Returns the output format of the active context, if the server was selected by the active context.
+func (serverDetails *ServerDetails) GetContextOutputFormat() string {
+	return serverDetails.contextOutputFormat
+}
+
 func (serverDetails *ServerDetails) SetUser(username string) {
 	serverDetails.User = username
 }
@@ -648,6 +861,10 @@ func (serverDetails *ServerDetails) SetClientCertKeyPath(certificatePath string)
 	serverDetails.ClientCertKeyPath = certificatePath
 }
 
+func (serverDetails *ServerDetails) SetClientCertKeyPassphrase(passphrase string) {
+	serverDetails.ClientCertKeyPassphrase = passphrase
+}
+
 func (serverDetails *ServerDetails) GetUrl() string {
 	return serverDetails.Url
 }
@@ -727,31 +944,32 @@ func (serverDetails *ServerDetails) GetClientCertKeyPath() string {
 func (serverDetails *ServerDetails) CreateArtAuthConfig() (auth.ServiceDetails, error) {
 	artAuth := artifactoryAuth.NewArtifactoryDetails()
 	artAuth.SetUrl(serverDetails.ArtifactoryUrl)
-	return serverDetails.createAuthConfig(artAuth)
+	return serverDetails.createAuthConfig(artAuth, ArtifactoryService)
 }
 
 func (serverDetails *ServerDetails) CreateDistAuthConfig() (auth.ServiceDetails, error) {
 	artAuth := distributionAuth.NewDistributionDetails()
 	artAuth.SetUrl(serverDetails.DistributionUrl)
-	return serverDetails.createAuthConfig(artAuth)
+	return serverDetails.createAuthConfig(artAuth, DistributionService)
 }
 
 func (serverDetails *ServerDetails) CreateXrayAuthConfig() (auth.ServiceDetails, error) {
 	artAuth := xrayAuth.NewXrayDetails()
 	artAuth.SetUrl(serverDetails.XrayUrl)
-	return serverDetails.createAuthConfig(artAuth)
+	return serverDetails.createAuthConfig(artAuth, XrayService)
 }
 
 func (serverDetails *ServerDetails) CreateCatalogAuthConfig() (auth.ServiceDetails, error) {
 	catAuth := catalogAuth.NewCatalogDetails()
 	catAuth.SetUrl(utils.AddTrailingSlashIfNeeded(serverDetails.Url) + "catalog/")
-	return serverDetails.createAuthConfig(catAuth)
+	return serverDetails.createAuthConfig(catAuth, CatalogService)
 }
 
 func (serverDetails *ServerDetails) CreateXscAuthConfig() (auth.ServiceDetails, error) {
 	ascAuth := xscAuth.NewXscDetails()
 	ascAuth.SetUrl(serverDetails.convertXrayUrlToXscUrl())
-	return serverDetails.createAuthConfig(ascAuth)
+	// Xsc is accessed with the Xray credentials.
+	return serverDetails.createAuthConfig(ascAuth, XrayService)
 }
 
 // Xray and Xsc will always have the same platform url.
@@ -764,65 +982,75 @@ func (serverDetails *ServerDetails) convertXrayUrlToXscUrl() string {
 func (serverDetails *ServerDetails) CreatePipelinesAuthConfig() (auth.ServiceDetails, error) {
 	pAuth := pipelinesAuth.NewPipelinesDetails()
 	pAuth.SetUrl(serverDetails.PipelinesUrl)
-	return serverDetails.createAuthConfig(pAuth)
+	return serverDetails.createAuthConfig(pAuth, PipelinesService)
 }
 
 func (serverDetails *ServerDetails) CreateAccessAuthConfig() (auth.ServiceDetails, error) {
 	pAuth := accessAuth.NewAccessDetails()
 	pAuth.SetUrl(utils.AddTrailingSlashIfNeeded(serverDetails.Url) + "access/")
-	return serverDetails.createAuthConfig(pAuth)
+	return serverDetails.createAuthConfig(pAuth, AccessService)
 }
 
 func (serverDetails *ServerDetails) CreateJfConnectAuthConfig() (auth.ServiceDetails, error) {
 	pAuth := accessAuth.NewAccessDetails()
 	pAuth.SetUrl(utils.AddTrailingSlashIfNeeded(serverDetails.Url) + "jfconnect/")
-	return serverDetails.createAuthConfig(pAuth)
+	return serverDetails.createAuthConfig(pAuth, JfConnectService)
 }
 
 func (serverDetails *ServerDetails) CreateLifecycleAuthConfig() (auth.ServiceDetails, error) {
 	lcAuth := lifecycleAuth.NewLifecycleDetails()
 	lcAuth.SetUrl(serverDetails.LifecycleUrl)
-	return serverDetails.createAuthConfig(lcAuth)
+	return serverDetails.createAuthConfig(lcAuth, LifecycleService)
 }
 
 func (serverDetails *ServerDetails) CreateEvidenceAuthConfig() (auth.ServiceDetails, error) {
 	evdAuth := evidenceAuth.NewEvidenceDetails()
 	evdAuth.SetUrl(serverDetails.EvidenceUrl)
-	return serverDetails.createAuthConfig(evdAuth)
+	return serverDetails.createAuthConfig(evdAuth, EvidenceService)
 }
 
 func (serverDetails *ServerDetails) CreateApptrustAuthConfig() (auth.ServiceDetails, error) {
 	apptrustAuth := apptrustAuth.NewApptrustDetails()
 	apptrustAuth.SetUrl(serverDetails.ApptrustUrl)
-	return serverDetails.createAuthConfig(apptrustAuth)
+	return serverDetails.createAuthConfig(apptrustAuth, ApptrustService)
 }
 
 func (serverDetails *ServerDetails) CreateMetadataAuthConfig() (auth.ServiceDetails, error) {
 	mdAuth := metadataAuth.NewMetadataDetails()
 	mdAuth.SetUrl(serverDetails.MetadataUrl)
-	return serverDetails.createAuthConfig(mdAuth)
+	return serverDetails.createAuthConfig(mdAuth, MetadataService)
 }
 
 func (serverDetails *ServerDetails) CreateOnemodelAuthConfig() (auth.ServiceDetails, error) {
 	omAuth := onemodelAuth.NewOnemodelDetails()
 	omAuth.SetUrl(serverDetails.OnemodelUrl)
-	return serverDetails.createAuthConfig(omAuth)
+	return serverDetails.createAuthConfig(omAuth, OnemodelService)
 }
 
-func (serverDetails *ServerDetails) createAuthConfig(details auth.ServiceDetails) (auth.ServiceDetails, error) {
+func (serverDetails *ServerDetails) createAuthConfig(details auth.ServiceDetails, service string) (auth.ServiceDetails, error) {
 	details.SetSshUrl(serverDetails.SshUrl)
-	details.SetAccessToken(serverDetails.AccessToken)
+	// Credentials set for the specific service take precedence over the server credentials.
+	// The refresh tokens belong to the server credentials, so the service credentials aren't refreshed.
+	serviceCredentials := serverDetails.GetServiceCredentials(service)
+	if serviceCredentials != nil {
+		details.SetAccessToken(serviceCredentials.AccessToken)
+	} else {
+		details.SetAccessToken(serverDetails.AccessToken)
+	}
 	// If refresh token is not empty, set a refresh handler and skip other credentials.
 	// First we check access's token, if empty we check artifactory's token.
 	switch {
+	case serviceCredentials != nil:
+		details.SetUser(serviceCredentials.User)
+		details.SetPassword(serviceCredentials.Password)
 	case serverDetails.RefreshToken != "" && !serverDetails.DisableTokenRefresh:
-		// Save serverId for refreshing if needed. If empty serverId is saved, default will be used.
-		tokenRefreshServerId = serverDetails.ServerId
-		details.AppendPreRequestFunction(AccessTokenRefreshPreRequestInterceptor)
+		// The interceptor is bound to the server's ID. If the ID is empty, the default server is used.
+		details.AppendPreRequestFunction(NewTokenRefreshPreRequestInterceptor(serverDetails, AccessToken))
 	case serverDetails.ArtifactoryRefreshToken != "":
-		// Save serverId for refreshing if needed. If empty serverId is saved, default will be used.
-		tokenRefreshServerId = serverDetails.ServerId
-		details.AppendPreRequestFunction(ArtifactoryTokenRefreshPreRequestInterceptor)
+		details.AppendPreRequestFunction(NewTokenRefreshPreRequestInterceptor(serverDetails, ArtifactoryToken))
+	case serverDetails.OidcExchangeParams != nil && serverDetails.AccessToken != "" && !serverDetails.DisableTokenRefresh:
+		// The access token is re-exchanged before it expires.
+		details.AppendPreRequestFunction(NewTokenRefreshPreRequestInterceptor(serverDetails, AccessToken))
 	default:
 		details.SetUser(serverDetails.User)
 		details.SetPassword(serverDetails.Password)
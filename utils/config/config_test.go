@@ -16,6 +16,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/log"
 	artifactoryAuth "github.com/jfrog/jfrog-client-go/artifactory/auth"
+	"github.com/jfrog/jfrog-client-go/auth"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/stretchr/testify/assert"
 )
@@ -166,6 +167,36 @@ func TestConvertConfigV5ToV6(t *testing.T) {
 	assertionHelper(t, configV6, 5)
 }
 
+func TestConvertConfigV6ToV7(t *testing.T) {
+	configV6 := `
+		{
+		  "servers": [
+			      {
+					  "url": "http://localhost:8080/",
+					  "artifactoryUrl": "http://localhost:8080/artifactory/",
+					  "xrayUrl": "http://localhost:8080/xray/",
+					  "user": "user",
+			          "password": "password",
+					  "serverId": "Default-Server",
+  					  "isDefault": true
+				  }
+		  ],
+		  "version": "6"
+		}
+	`
+
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	content, err := convertIfNeeded([]byte(configV6))
+	assert.NoError(t, err)
+	configV7 := new(ConfigV7)
+	assert.NoError(t, json.Unmarshal(content, &configV7))
+	assert.Equal(t, "7", configV7.Version)
+	assert.Len(t, configV7.Servers, 1)
+	assert.Equal(t, "password", configV7.Servers[0].Password)
+	assert.Empty(t, configV7.Servers[0].ServiceCredentials)
+}
+
 func TestConfigEncryption(t *testing.T) {
 	// Config
 	cleanUpTempEnv := configtests.CreateTempEnv(t, true)
@@ -240,7 +271,7 @@ func TestConfigEncryptionEnvVarUpdate(t *testing.T) {
 }
 
 func createEncryptionTestConfig() *Config {
-	return &Config{ConfigV6{ConfigV5{
+	return &Config{ConfigV7{ConfigV6: ConfigV6{ConfigV5{
 		Version: strconv.Itoa(coreutils.GetCliConfigVersion()),
 		Servers: []*ServerDetails{{
 			ServerId:      "test-server",
@@ -250,7 +281,7 @@ func createEncryptionTestConfig() *Config {
 			AccessToken:   "DewiciousWegOfWamb", // #nosec G101 -- test data only
 			SshPassphrase: "KiwwTheWabbit",      // #nosec G101 -- test data only
 		}}},
-	}}
+	}}}
 }
 
 // Read config file "as-is" - without decryption
@@ -342,7 +373,7 @@ func assertionV4Helper(t *testing.T, convertedConfig *ConfigV4, expectedVersion
 }
 
 func assertionHelper(t *testing.T, convertedConfig *ConfigV6, previousVersion int) {
-	assert.Equal(t, "6", convertedConfig.Version)
+	assert.Equal(t, strconv.Itoa(coreutils.GetCliConfigVersion()), convertedConfig.Version)
 
 	serversConverted := convertedConfig.Servers
 	if serversConverted == nil {
@@ -372,7 +403,7 @@ func TestHandleSecrets(t *testing.T) {
 
 	original := new(Config)
 	original.Servers = []*ServerDetails{{User: "user", Password: "password", Url: "http://localhost:8080/artifactory/", AccessToken: "accessToken",
-		RefreshToken: "refreshToken", SshPassphrase: "sshPass"}}
+		RefreshToken: "refreshToken", SshPassphrase: "sshPass", ServiceCredentials: map[string]*ServiceCredentials{XrayService: {AccessToken: "xrayToken"}}}}
 	original.Enc = true
 
 	newConf, err := original.Clone()
@@ -401,6 +432,11 @@ func verifyEncryptionStatus(t *testing.T, original, actual *Config, encryptionEx
 		if original.Servers[i].RefreshToken != "" {
 			equals = append(equals, original.Servers[i].RefreshToken == actual.Servers[i].RefreshToken)
 		}
+		for service, serviceCredentials := range original.Servers[i].ServiceCredentials {
+			if serviceCredentials.AccessToken != "" {
+				equals = append(equals, serviceCredentials.AccessToken == actual.Servers[i].ServiceCredentials[service].AccessToken)
+			}
+		}
 		assert.Equal(t, encryptionExpected, actual.Enc)
 	}
 
@@ -423,6 +459,46 @@ func assertCertsMigration(t *testing.T) {
 	assert.Len(t, files, 2)
 }
 
+func TestCreateAuthConfigServiceCredentials(t *testing.T) {
+	serverDetails := &ServerDetails{
+		ServerId:       "test-server",
+		ArtifactoryUrl: "https://test.com/artifactory/",
+		XrayUrl:        "https://test.com/xray/",
+		AccessToken:    "platform-token",
+		RefreshToken:   "refresh-token",
+		ServiceCredentials: map[string]*ServiceCredentials{
+			XrayService:         {AccessToken: "xray-token"},
+			DistributionService: {User: "deployer", Password: "deployer-password"},
+			LifecycleService:    {},
+		},
+	}
+
+	// Artifactory has no service credentials, and uses the server credentials.
+	artDetails, err := serverDetails.CreateArtAuthConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "platform-token", artDetails.GetAccessToken())
+	assert.Len(t, artDetails.GetPreRequestFunctions(), 1)
+
+	// Xray and Xsc use the Xray credentials, without refreshing the platform token.
+	for _, createAuthConfig := range []func() (auth.ServiceDetails, error){serverDetails.CreateXrayAuthConfig, serverDetails.CreateXscAuthConfig} {
+		details, err := createAuthConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, "xray-token", details.GetAccessToken())
+		assert.Empty(t, details.GetPreRequestFunctions())
+	}
+
+	distDetails, err := serverDetails.CreateDistAuthConfig()
+	assert.NoError(t, err)
+	assert.Empty(t, distDetails.GetAccessToken())
+	assert.Equal(t, "deployer", distDetails.GetUser())
+	assert.Equal(t, "deployer-password", distDetails.GetPassword())
+
+	// Empty service credentials are ignored.
+	lcDetails, err := serverDetails.CreateLifecycleAuthConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "platform-token", lcDetails.GetAccessToken())
+}
+
 func TestCreateAuthConfigAppendPreRequestFunctionBehavior(t *testing.T) {
 	test := []struct {
 		name                       string
@@ -506,7 +582,7 @@ func TestCreateAuthConfigAppendPreRequestFunctionBehavior(t *testing.T) {
 			artDetails := artifactoryAuth.NewArtifactoryDetails()
 			artDetails.SetUrl("https://test.com/artifactory/")
 
-			result, err := tt.serverDetails.createAuthConfig(artDetails)
+			result, err := tt.serverDetails.createAuthConfig(artDetails, ArtifactoryService)
 
 			assert.NoError(t, err)
 			assert.Equal(t, artDetails, result)
@@ -1,32 +1,48 @@
 package config
 
 import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 )
 
-const tokenVersion = 2
+const (
+	tokenVersion = 2
+	// Version 3 tokens bundle multiple servers, and may be encrypted with a passphrase.
+	multiServerTokenVersion = 3
+
+	passphraseSaltLength = 16
+	// The number of PBKDF2 iterations used to derive the token encryption key from the passphrase, as recommended by OWASP.
+	passphraseIterations = 600000
+)
+
+// ErrConfigTokenPassphraseRequired is returned when importing an encrypted config token without a passphrase.
+var ErrConfigTokenPassphraseRequired = errors.New("the config token is encrypted, and a passphrase is required to import it")
 
 type configToken struct {
-	Version              int    `json:"version,omitempty"`
-	Url                  string `json:"url,omitempty"`
-	ArtifactoryUrl       string `json:"artifactoryUrl,omitempty"`
-	DistributionUrl      string `json:"distributionUrl,omitempty"`
-	XrayUrl              string `json:"xrayUrl,omitempty"`
-	MissionControlUrl    string `json:"missionControlUrl,omitempty"`
-	PipelinesUrl         string `json:"pipelinesUrl,omitempty"`
-	User                 string `json:"user,omitempty"`
-	Password             string `json:"password,omitempty"` // #nosec G117 -- config struct for auth
-	SshKeyPath           string `json:"sshKeyPath,omitempty"`
-	SshPassphrase        string `json:"sshPassphrase,omitempty"`
-	AccessToken          string `json:"accessToken,omitempty"`  // #nosec G117 -- config struct for auth
-	RefreshToken         string `json:"refreshToken,omitempty"` // #nosec G117 -- config struct for auth
-	TokenRefreshInterval int    `json:"tokenRefreshInterval,omitempty"`
-	ClientCertPath       string `json:"clientCertPath,omitempty"`
-	ClientCertKeyPath    string `json:"clientCertKeyPath,omitempty"`
-	ServerId             string `json:"serverId,omitempty"`
+	Version                 int    `json:"version,omitempty"`
+	Url                     string `json:"url,omitempty"`
+	ArtifactoryUrl          string `json:"artifactoryUrl,omitempty"`
+	DistributionUrl         string `json:"distributionUrl,omitempty"`
+	XrayUrl                 string `json:"xrayUrl,omitempty"`
+	MissionControlUrl       string `json:"missionControlUrl,omitempty"`
+	PipelinesUrl            string `json:"pipelinesUrl,omitempty"`
+	User                    string `json:"user,omitempty"`
+	Password                string `json:"password,omitempty"` // #nosec G117 -- config struct for auth
+	SshKeyPath              string `json:"sshKeyPath,omitempty"`
+	SshPassphrase           string `json:"sshPassphrase,omitempty"`
+	AccessToken             string `json:"accessToken,omitempty"`  // #nosec G117 -- config struct for auth
+	RefreshToken            string `json:"refreshToken,omitempty"` // #nosec G117 -- config struct for auth
+	TokenRefreshInterval    int    `json:"tokenRefreshInterval,omitempty"`
+	ClientCertPath          string `json:"clientCertPath,omitempty"`
+	ClientCertKeyPath       string `json:"clientCertKeyPath,omitempty"`
+	ClientCertKeyPassphrase string `json:"clientCertKeyPassphrase,omitempty"`
+	ServerId                string `json:"serverId,omitempty"`
 }
 
 func (token *configToken) convertToV2() {
@@ -38,23 +54,24 @@ func (token *configToken) convertToV2() {
 
 func fromServerDetails(details *ServerDetails) *configToken {
 	return &configToken{
-		Version:              tokenVersion,
-		Url:                  details.Url,
-		ArtifactoryUrl:       details.ArtifactoryUrl,
-		DistributionUrl:      details.DistributionUrl,
-		XrayUrl:              details.XrayUrl,
-		MissionControlUrl:    details.MissionControlUrl,
-		PipelinesUrl:         details.PipelinesUrl,
-		User:                 details.User,
-		Password:             details.Password,
-		SshKeyPath:           details.SshKeyPath,
-		SshPassphrase:        details.SshPassphrase,
-		AccessToken:          details.AccessToken,
-		RefreshToken:         details.ArtifactoryRefreshToken,
-		TokenRefreshInterval: details.ArtifactoryTokenRefreshInterval,
-		ClientCertPath:       details.ClientCertPath,
-		ClientCertKeyPath:    details.ClientCertKeyPath,
-		ServerId:             details.ServerId,
+		Version:                 tokenVersion,
+		Url:                     details.Url,
+		ArtifactoryUrl:          details.ArtifactoryUrl,
+		DistributionUrl:         details.DistributionUrl,
+		XrayUrl:                 details.XrayUrl,
+		MissionControlUrl:       details.MissionControlUrl,
+		PipelinesUrl:            details.PipelinesUrl,
+		User:                    details.User,
+		Password:                details.Password,
+		SshKeyPath:              details.SshKeyPath,
+		SshPassphrase:           details.SshPassphrase,
+		AccessToken:             details.AccessToken,
+		RefreshToken:            details.ArtifactoryRefreshToken,
+		TokenRefreshInterval:    details.ArtifactoryTokenRefreshInterval,
+		ClientCertPath:          details.ClientCertPath,
+		ClientCertKeyPath:       details.ClientCertKeyPath,
+		ClientCertKeyPassphrase: details.ClientCertKeyPassphrase,
+		ServerId:                details.ServerId,
 	}
 }
 
@@ -75,34 +92,42 @@ func toServerDetails(detailsSerialization *configToken) *ServerDetails {
 		ArtifactoryTokenRefreshInterval: detailsSerialization.TokenRefreshInterval,
 		ClientCertPath:                  detailsSerialization.ClientCertPath,
 		ClientCertKeyPath:               detailsSerialization.ClientCertKeyPath,
+		ClientCertKeyPassphrase:         detailsSerialization.ClientCertKeyPassphrase,
 		ServerId:                        detailsSerialization.ServerId,
 	}
 }
 
 func Export(details *ServerDetails) (string, error) {
-	conf, err := readConf()
+	if err := verifyMasterKeyIfEncrypted(); err != nil {
+		return "", err
+	}
+	buffer, err := json.Marshal(fromServerDetails(details)) // #nosec G117 -- intentional serialization of auth config for export token
 	if err != nil {
 		return "", err
 	}
-	// If config is encrypted, ask for master key.
+	return base64.StdEncoding.EncodeToString(buffer), nil
+}
+
+// If config is encrypted, ask for master key.
+func verifyMasterKeyIfEncrypted() error {
+	conf, err := readConf()
+	if err != nil {
+		return err
+	}
 	if conf.Enc {
 		masterKeyFromFile, err := getEncryptionKey()
 		if err != nil {
-			return "", err
+			return err
 		}
 		masterKeyFromConsole, err := readMasterKeyFromConsole()
 		if err != nil {
-			return "", err
+			return err
 		}
 		if masterKeyFromConsole != masterKeyFromFile {
-			return "", errorutils.CheckErrorf("could not generate config token: config is encrypted, and wrong master key was provided")
+			return errorutils.CheckErrorf("could not generate config token: config is encrypted, and wrong master key was provided")
 		}
 	}
-	buffer, err := json.Marshal(fromServerDetails(details)) // #nosec G117 -- intentional serialization of auth config for export token
-	if err != nil {
-		return "", err
-	}
-	return base64.StdEncoding.EncodeToString(buffer), nil
+	return nil
 }
 
 func Import(configTokenString string) (*ServerDetails, error) {
@@ -119,3 +144,194 @@ func Import(configTokenString string) (*ServerDetails, error) {
 	}
 	return toServerDetails(token), nil
 }
+
+// A version 3 config token, bundling multiple servers.
+// If the token is encrypted with a passphrase, the servers are kept encrypted in EncryptedServers.
+type multiServerConfigToken struct {
+	Version          int                   `json:"version,omitempty"`
+	Servers          []*serverTokenDetails `json:"servers,omitempty"`
+	EncryptedServers string                `json:"encryptedServers,omitempty"`
+	Salt             string                `json:"salt,omitempty"`
+	Iterations       int                   `json:"iterations,omitempty"`
+}
+
+// The server details as serialized in the config token.
+// Includes the fields which aren't saved in the config file, so that every field of ServerDetails is kept.
+type serverTokenDetails struct {
+	*ServerDetails
+	SshUrl       string `json:"sshUrl,omitempty"`
+	LifecycleUrl string `json:"lifecycleUrl,omitempty"`
+	EvidenceUrl  string `json:"evidenceUrl,omitempty"`
+	MetadataUrl  string `json:"metadataUrl,omitempty"`
+	OnemodelUrl  string `json:"onemodelUrl,omitempty"`
+	ApptrustUrl  string `json:"apptrustUrl,omitempty"`
+	InsecureTls  bool   `json:"insecureTls,omitempty"`
+}
+
+func toServerTokenDetails(details *ServerDetails) *serverTokenDetails {
+	return &serverTokenDetails{
+		ServerDetails: details,
+		SshUrl:        details.SshUrl,
+		LifecycleUrl:  details.LifecycleUrl,
+		EvidenceUrl:   details.EvidenceUrl,
+		MetadataUrl:   details.MetadataUrl,
+		OnemodelUrl:   details.OnemodelUrl,
+		ApptrustUrl:   details.ApptrustUrl,
+		InsecureTls:   details.InsecureTls,
+	}
+}
+
+func (tokenDetails *serverTokenDetails) toServerDetails() *ServerDetails {
+	details := tokenDetails.ServerDetails
+	if details == nil {
+		details = new(ServerDetails)
+	}
+	details.SshUrl = tokenDetails.SshUrl
+	details.LifecycleUrl = tokenDetails.LifecycleUrl
+	details.EvidenceUrl = tokenDetails.EvidenceUrl
+	details.MetadataUrl = tokenDetails.MetadataUrl
+	details.OnemodelUrl = tokenDetails.OnemodelUrl
+	details.ApptrustUrl = tokenDetails.ApptrustUrl
+	details.InsecureTls = tokenDetails.InsecureTls
+	return details
+}
+
+type ExportServersOptions struct {
+	// Exclude the passwords, tokens and passphrases of the servers from the token.
+	WithoutSecrets bool
+	// If set, the servers are encrypted in the token with a key derived from the passphrase.
+	Passphrase string
+}
+
+// Creates a config token bundling the provided servers.
+func ExportServers(servers []*ServerDetails, options ExportServersOptions) (string, error) {
+	if err := verifyMasterKeyIfEncrypted(); err != nil {
+		return "", err
+	}
+	token := &multiServerConfigToken{Version: multiServerTokenVersion}
+	for _, details := range servers {
+		// Copy the details, to avoid modifying the provided servers.
+		detailsCopy := *details
+		if options.WithoutSecrets {
+			removeSecrets(&detailsCopy)
+		}
+		token.Servers = append(token.Servers, toServerTokenDetails(&detailsCopy))
+	}
+	if options.Passphrase != "" {
+		if err := token.encrypt(options.Passphrase); err != nil {
+			return "", err
+		}
+	}
+	buffer, err := json.Marshal(token) // #nosec G117 -- intentional serialization of auth config for export token
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return base64.StdEncoding.EncodeToString(buffer), nil
+}
+
+// Returns the servers of the provided config token. Supports both single server tokens and multiple servers tokens.
+// If the token is encrypted, the passphrase is required. Otherwise, the passphrase is ignored.
+func ImportServers(configTokenString, passphrase string) ([]*ServerDetails, error) {
+	decoded, err := base64.StdEncoding.DecodeString(configTokenString)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	token := &multiServerConfigToken{}
+	if err = json.Unmarshal(decoded, token); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if token.Version < multiServerTokenVersion {
+		details, err := Import(configTokenString)
+		if err != nil {
+			return nil, err
+		}
+		return []*ServerDetails{details}, nil
+	}
+	if token.EncryptedServers != "" {
+		if passphrase == "" {
+			return nil, ErrConfigTokenPassphraseRequired
+		}
+		if err = token.decrypt(passphrase); err != nil {
+			return nil, err
+		}
+	}
+	servers := make([]*ServerDetails, 0, len(token.Servers))
+	for _, tokenDetails := range token.Servers {
+		servers = append(servers, tokenDetails.toServerDetails())
+	}
+	return servers, nil
+}
+
+// Returns true if the provided config token is encrypted with a passphrase.
+func IsConfigTokenEncrypted(configTokenString string) (bool, error) {
+	decoded, err := base64.StdEncoding.DecodeString(configTokenString)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	token := &multiServerConfigToken{}
+	if err = json.Unmarshal(decoded, token); err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	return token.EncryptedServers != "", nil
+}
+
+func removeSecrets(details *ServerDetails) {
+	details.Password = ""
+	details.AccessToken = ""
+	details.RefreshToken = ""
+	details.ArtifactoryRefreshToken = ""
+	details.SshPassphrase = ""
+	details.ClientCertKeyPassphrase = ""
+	if details.ServiceCredentials == nil {
+		return
+	}
+	serviceCredentials := make(map[string]*ServiceCredentials, len(details.ServiceCredentials))
+	for service, credentials := range details.ServiceCredentials {
+		if credentials != nil && credentials.User != "" {
+			serviceCredentials[service] = &ServiceCredentials{User: credentials.User}
+		}
+	}
+	details.ServiceCredentials = serviceCredentials
+}
+
+func (token *multiServerConfigToken) encrypt(passphrase string) error {
+	salt := make([]byte, passphraseSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return errorutils.CheckError(err)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, passphraseIterations, masterKeyLength)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	servers, err := json.Marshal(token.Servers) // #nosec G117 -- intentional serialization of auth config for export token
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if token.EncryptedServers, err = encrypt(string(servers), string(key)); err != nil {
+		return err
+	}
+	token.Servers = nil
+	token.Salt = base64.StdEncoding.EncodeToString(salt)
+	token.Iterations = passphraseIterations
+	return nil
+}
+
+func (token *multiServerConfigToken) decrypt(passphrase string) error {
+	if token.Iterations <= 0 {
+		return errorutils.CheckErrorf("invalid config token: missing the passphrase key derivation iterations")
+	}
+	salt, err := base64.StdEncoding.DecodeString(token.Salt)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, token.Iterations, masterKeyLength)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	servers, err := decrypt(token.EncryptedServers, string(key))
+	if err != nil {
+		return errorutils.CheckErrorf("failed decrypting the config token. Please make sure the passphrase is correct: %s", err.Error())
+	}
+	token.EncryptedServers = ""
+	return errorutils.CheckError(json.Unmarshal([]byte(servers), &token.Servers))
+}
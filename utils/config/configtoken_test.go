@@ -3,7 +3,9 @@ package config
 import (
 	"testing"
 
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -40,3 +42,116 @@ func TestImportFromV2(t *testing.T) {
 	assert.Equal(t, "admin", serverDetails.User)
 	assert.Equal(t, "password", serverDetails.Password)
 }
+
+func TestExportImportServers(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	servers := createMultiServerTokenTestServers()
+
+	configToken, err := ExportServers(servers, ExportServersOptions{})
+	require.NoError(t, err)
+	encrypted, err := IsConfigTokenEncrypted(configToken)
+	assert.NoError(t, err)
+	assert.False(t, encrypted)
+
+	// The passphrase should be ignored for tokens which aren't encrypted.
+	importedServers, err := ImportServers(configToken, "ignored")
+	require.NoError(t, err)
+	assert.Equal(t, createMultiServerTokenTestServers(), importedServers)
+}
+
+func TestExportServersWithoutSecrets(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	servers := createMultiServerTokenTestServers()
+
+	configToken, err := ExportServers(servers, ExportServersOptions{WithoutSecrets: true})
+	require.NoError(t, err)
+	// The provided servers should not be modified.
+	assert.Equal(t, createMultiServerTokenTestServers(), servers)
+
+	importedServers, err := ImportServers(configToken, "")
+	require.NoError(t, err)
+	require.Len(t, importedServers, 2)
+	for _, details := range importedServers {
+		assert.Empty(t, details.Password)
+		assert.Empty(t, details.AccessToken)
+		assert.Empty(t, details.RefreshToken)
+		assert.Empty(t, details.ArtifactoryRefreshToken)
+		assert.Empty(t, details.SshPassphrase)
+	}
+	assert.Equal(t, "admin", importedServers[0].User)
+	assert.Equal(t, map[string]*ServiceCredentials{XrayService: {User: "xray-user"}}, importedServers[0].ServiceCredentials)
+	assert.Equal(t, "https://server2.jfrog.io/", importedServers[1].Url)
+}
+
+func TestExportServersWithPassphrase(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+
+	configToken, err := ExportServers(createMultiServerTokenTestServers(), ExportServersOptions{Passphrase: "passphrase"})
+	require.NoError(t, err)
+	encrypted, err := IsConfigTokenEncrypted(configToken)
+	assert.NoError(t, err)
+	assert.True(t, encrypted)
+
+	_, err = ImportServers(configToken, "")
+	assert.ErrorIs(t, err, ErrConfigTokenPassphraseRequired)
+	_, err = ImportServers(configToken, "wrong-passphrase")
+	assert.ErrorContains(t, err, "passphrase is correct")
+
+	importedServers, err := ImportServers(configToken, "passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, createMultiServerTokenTestServers(), importedServers)
+}
+
+func TestImportServersFromV2(t *testing.T) {
+	servers, err := ImportServers(v2Token, "")
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "local", servers[0].ServerId)
+	assert.Equal(t, "http://127.0.0.1:8081/", servers[0].Url)
+	assert.Equal(t, "password", servers[0].Password)
+}
+
+func createMultiServerTokenTestServers() []*ServerDetails {
+	return []*ServerDetails{
+		{
+			ServerId:                "server1",
+			IsDefault:               true,
+			Url:                     "https://server1.jfrog.io/",
+			ArtifactoryUrl:          "https://server1.jfrog.io/artifactory/",
+			DistributionUrl:         "https://server1.jfrog.io/distribution/",
+			XrayUrl:                 "https://server1.jfrog.io/xray/",
+			MissionControlUrl:       "https://server1.jfrog.io/mc/",
+			PipelinesUrl:            "https://server1.jfrog.io/pipelines/",
+			AccessUrl:               "https://server1.jfrog.io/access/",
+			LifecycleUrl:            "https://server1.jfrog.io/lifecycle/",
+			EvidenceUrl:             "https://server1.jfrog.io/evidence/",
+			MetadataUrl:             "https://server1.jfrog.io/metadata/",
+			OnemodelUrl:             "https://server1.jfrog.io/onemodel/",
+			ApptrustUrl:             "https://server1.jfrog.io/apptrust/",
+			SshUrl:                  "ssh://server1.jfrog.io:1339/",
+			User:                    "admin",
+			Password:                "password",
+			SshKeyPath:              "/path/to/key",
+			SshPassphrase:           "sshPassphrase",
+			ArtifactoryRefreshToken: "artifactoryRefreshToken",
+			ClientCertPath:          "/path/to/cert",
+			ClientCertKeyPath:       "/path/to/cert/key",
+			WebLogin:                true,
+			InsecureTls:             true,
+			ServiceCredentials: map[string]*ServiceCredentials{
+				XrayService: {User: "xray-user", Password: "xray-password"},
+			},
+		},
+		{
+			ServerId:            "server2",
+			Url:                 "https://server2.jfrog.io/",
+			ArtifactoryUrl:      "https://server2.jfrog.io/artifactory/",
+			AccessToken:         "accessToken",
+			RefreshToken:        "refreshToken",
+			DisableTokenRefresh: true,
+		},
+	}
+}
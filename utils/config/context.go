@@ -0,0 +1,191 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// The directory searched upstream from the working directory for a context file.
+	ContextDirName = ".jfrog"
+	// The name of the per-directory context file, located inside ContextDirName.
+	ContextFileName = "context.yaml"
+)
+
+// Context is a named selection of a configured server, together with the defaults to use with it.
+// Contexts are layered over the configured servers and allow switching between servers per shell
+// (using the JFROG_CLI_CONTEXT environment variable) or per directory (using a .jfrog/context.yaml file),
+// without changing the global default server.
+type Context struct {
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
+	ServerId     string `json:"serverId,omitempty" yaml:"serverId,omitempty"`
+	Project      string `json:"project,omitempty" yaml:"project,omitempty"`
+	ResolveRepo  string `json:"resolveRepo,omitempty" yaml:"resolveRepo,omitempty"`
+	DeployRepo   string `json:"deployRepo,omitempty" yaml:"deployRepo,omitempty"`
+	OutputFormat string `json:"outputFormat,omitempty" yaml:"outputFormat,omitempty"`
+}
+
+// The content of a .jfrog/context.yaml file.
+// The file may reference a named context, override any of its fields, or both.
+type contextFile struct {
+	ContextName string  `yaml:"context,omitempty"`
+	Overrides   Context `yaml:",inline"`
+}
+
+// Returns a copy of the context, with the non-empty fields of the overrides applied.
+func (context *Context) merge(overrides Context) *Context {
+	merged := *context
+	for _, field := range []struct {
+		target *string
+		value  string
+	}{
+		{&merged.ServerId, overrides.ServerId},
+		{&merged.Project, overrides.Project},
+		{&merged.ResolveRepo, overrides.ResolveRepo},
+		{&merged.DeployRepo, overrides.DeployRepo},
+		{&merged.OutputFormat, overrides.OutputFormat},
+	} {
+		if field.value != "" {
+			*field.target = field.value
+		}
+	}
+	return &merged
+}
+
+func GetAllContexts() ([]*Context, error) {
+	conf, err := readConf()
+	if err != nil {
+		return nil, err
+	}
+	if conf.Contexts == nil {
+		return make([]*Context, 0), nil
+	}
+	return conf.Contexts, nil
+}
+
+func SaveContexts(contexts []*Context) error {
+	conf, err := readConf()
+	if err != nil {
+		return err
+	}
+	conf.Contexts = contexts
+	conf.Version = strconv.Itoa(coreutils.GetCliConfigVersion())
+	return saveConfig(conf)
+}
+
+// Returns the context with the provided name or error if the context was not found.
+func GetContext(name string) (*Context, error) {
+	contexts, err := GetAllContexts()
+	if err != nil {
+		return nil, err
+	}
+	return getContextByName(name, contexts)
+}
+
+func getContextByName(name string, contexts []*Context) (*Context, error) {
+	for _, context := range contexts {
+		if context.Name == name {
+			return context, nil
+		}
+	}
+	return nil, errorutils.CheckErrorf("Context '%s' does not exist.", name)
+}
+
+func GetAndRemoveContext(name string, contexts []*Context) (*Context, []*Context) {
+	for i, context := range contexts {
+		if context.Name == name {
+			contexts = append(contexts[:i], contexts[i+1:]...)
+			return context, contexts
+		}
+	}
+	return nil, contexts
+}
+
+// Returns the active context, or nil if no context is active.
+// The active context is resolved in the following order:
+// 1. The context named by the JFROG_CLI_CONTEXT environment variable.
+// 2. The .jfrog/context.yaml file, in the working directory or in one of its parent directories.
+// The fields set in the context file override the fields of the context it references.
+func GetActiveContext() (*Context, error) {
+	return getActiveContext(GetAllContexts)
+}
+
+// The contexts are read from the config only if a named context is used,
+// since the active context is resolved by most commands, which usually don't use a context.
+func getActiveContext(getContexts func() ([]*Context, error)) (*Context, error) {
+	if name := os.Getenv(coreutils.ContextName); name != "" {
+		log.Debug("Using context '" + name + "' from the " + coreutils.ContextName + " environment variable.")
+		contexts, err := getContexts()
+		if err != nil {
+			return nil, err
+		}
+		return getContextByName(name, contexts)
+	}
+	contextFilePath, exists, err := GetContextFilePath()
+	if err != nil || !exists {
+		return nil, err
+	}
+	fileContent, err := readContextFile(contextFilePath)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Using context from " + contextFilePath)
+	context := &Context{}
+	if fileContent.ContextName != "" {
+		contexts, err := getContexts()
+		if err != nil {
+			return nil, err
+		}
+		if context, err = getContextByName(fileContent.ContextName, contexts); err != nil {
+			return nil, errorutils.CheckErrorf("the context file at %s references an unknown context: %s", contextFilePath, err.Error())
+		}
+	}
+	return context.merge(fileContent.Overrides), nil
+}
+
+// Returns the path of the .jfrog/context.yaml file in the working directory or in one of its parent directories.
+func GetContextFilePath() (contextFilePath string, exists bool, err error) {
+	relativePath := filepath.Join(ContextDirName, ContextFileName)
+	contextDir, exists, err := fileutils.FindUpstream(relativePath, fileutils.File)
+	if err != nil || !exists {
+		return
+	}
+	return filepath.Join(contextDir, relativePath), true, nil
+}
+
+func readContextFile(contextFilePath string) (*contextFile, error) {
+	content, err := fileutils.ReadFile(contextFilePath)
+	if err != nil {
+		return nil, err
+	}
+	fileContent := new(contextFile)
+	if err = yaml.Unmarshal(content, fileContent); err != nil {
+		return nil, errorutils.CheckErrorf("failed parsing the context file at %s: %s", contextFilePath, err.Error())
+	}
+	return fileContent, nil
+}
+
+// Selects the named context for the provided directory, by writing a .jfrog/context.yaml file in it.
+func UseContextInDir(name, dir string) (contextFilePath string, err error) {
+	if _, err = GetContext(name); err != nil {
+		return
+	}
+	contextDir := filepath.Join(dir, ContextDirName)
+	if err = fileutils.CreateDirIfNotExist(contextDir); err != nil {
+		return
+	}
+	content, err := yaml.Marshal(&contextFile{ContextName: name})
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	contextFilePath = filepath.Join(contextDir, ContextFileName)
+	err = errorutils.CheckError(os.WriteFile(contextFilePath, content, 0644))
+	return
+}
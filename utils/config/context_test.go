@@ -0,0 +1,161 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createContextsTestEnv(t *testing.T) (tmpDir string, cleanUp func()) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	require.NoError(t, SaveServersConf([]*ServerDetails{
+		{ServerId: "default-server", Url: "http://default/", IsDefault: true},
+		{ServerId: "dev-server", Url: "http://dev/"},
+		{ServerId: "prod-server", Url: "http://prod/"},
+	}))
+	require.NoError(t, SaveContexts([]*Context{
+		{Name: "dev", ServerId: "dev-server", Project: "dev-proj", ResolveRepo: "dev-remote", OutputFormat: "json"},
+		{Name: "prod", ServerId: "prod-server", Project: "prod-proj", DeployRepo: "prod-local"},
+	}))
+	tmpDir, err := os.MkdirTemp("", "context_test")
+	require.NoError(t, err)
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	chdirCallback := testsutils.ChangeDirWithCallback(t, wd, tmpDir)
+	return tmpDir, func() {
+		chdirCallback()
+		testsutils.RemoveAllAndAssert(t, tmpDir)
+		cleanUpTempEnv()
+	}
+}
+
+func TestNoActiveContext(t *testing.T) {
+	_, cleanUp := createContextsTestEnv(t)
+	defer cleanUp()
+
+	context, err := GetActiveContext()
+	assert.NoError(t, err)
+	assert.Nil(t, context)
+
+	details, err := GetSpecificConfig("", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "default-server", details.ServerId)
+}
+
+func TestNoActiveContextDoesNotReadConfig(t *testing.T) {
+	_, cleanUp := createContextsTestEnv(t)
+	defer cleanUp()
+	// A config which fails to be read shouldn't fail commands which don't use a context.
+	confFilePath, err := getConfFilePath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(confFilePath, []byte("{"), 0600))
+
+	context, err := GetActiveContext()
+	assert.NoError(t, err)
+	assert.Nil(t, context)
+
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.ContextName, "prod")()
+	_, err = GetActiveContext()
+	assert.Error(t, err)
+}
+
+func TestActiveContextFromEnv(t *testing.T) {
+	_, cleanUp := createContextsTestEnv(t)
+	defer cleanUp()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.ContextName, "prod")()
+
+	context, err := GetActiveContext()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", context.Name)
+	assert.Equal(t, "prod-local", context.DeployRepo)
+
+	details, err := GetSpecificConfig("", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "prod-server", details.ServerId)
+	// An explicit server ID takes precedence over the context.
+	details, err = GetSpecificConfig("dev-server", true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "dev-server", details.ServerId)
+	// The global default server remains unchanged.
+	configs, err := GetAllServersConfigs()
+	assert.NoError(t, err)
+	assert.True(t, configs[0].IsDefault)
+}
+
+func TestActiveContextUnknownName(t *testing.T) {
+	_, cleanUp := createContextsTestEnv(t)
+	defer cleanUp()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.ContextName, "staging")()
+
+	_, err := GetActiveContext()
+	assert.ErrorContains(t, err, "Context 'staging' does not exist.")
+	_, err = GetSpecificConfig("", true, false)
+	assert.Error(t, err)
+}
+
+func TestActiveContextFromDir(t *testing.T) {
+	tmpDir, cleanUp := createContextsTestEnv(t)
+	defer cleanUp()
+
+	contextFilePath, err := UseContextInDir("dev", tmpDir)
+	require.NoError(t, err)
+	assert.FileExists(t, contextFilePath)
+
+	// The context file should be found from a subdirectory.
+	subDir := filepath.Join(tmpDir, "sub", "dir")
+	require.NoError(t, os.MkdirAll(subDir, 0755))
+	defer testsutils.ChangeDirWithCallback(t, tmpDir, subDir)()
+
+	context, err := GetActiveContext()
+	require.NoError(t, err)
+	assert.Equal(t, &Context{Name: "dev", ServerId: "dev-server", Project: "dev-proj", ResolveRepo: "dev-remote", OutputFormat: "json"}, context)
+
+	details, err := GetDefaultServerConf()
+	assert.NoError(t, err)
+	assert.Equal(t, "dev-server", details.ServerId)
+
+	// The environment variable takes precedence over the context file.
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.ContextName, "prod")()
+	context, err = GetActiveContext()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", context.Name)
+}
+
+func TestActiveContextFileOverrides(t *testing.T) {
+	tmpDir, cleanUp := createContextsTestEnv(t)
+	defer cleanUp()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, ContextDirName), 0755))
+	content := "context: dev\nproject: other-proj\ndeployRepo: other-local\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ContextDirName, ContextFileName), []byte(content), 0644))
+
+	context, err := GetActiveContext()
+	require.NoError(t, err)
+	assert.Equal(t, "dev-server", context.ServerId)
+	assert.Equal(t, "other-proj", context.Project)
+	assert.Equal(t, "dev-remote", context.ResolveRepo)
+	assert.Equal(t, "other-local", context.DeployRepo)
+
+	// The overrides should not modify the stored context.
+	stored, err := GetContext("dev")
+	require.NoError(t, err)
+	assert.Equal(t, "dev-proj", stored.Project)
+}
+
+func TestContextsPreservedOnServersSave(t *testing.T) {
+	_, cleanUp := createContextsTestEnv(t)
+	defer cleanUp()
+
+	configs, err := GetAllServersConfigs()
+	require.NoError(t, err)
+	require.NoError(t, SaveServersConf(configs[:1]))
+	contexts, err := GetAllContexts()
+	assert.NoError(t, err)
+	assert.Len(t, contexts, 2)
+}
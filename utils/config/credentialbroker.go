@@ -0,0 +1,408 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The credential broker is an optional per-user process, which owns the tokens of the configured servers.
+// It refreshes the tokens ahead of their expiry, and hands the access tokens to CLI processes through the token refresh interceptors,
+// so that parallel CLI processes don't refresh the same tokens and rewrite the config file.
+// The refresh tokens never leave the broker. If the broker isn't running, the CLI processes refresh the tokens themselves.
+// Each refresh is written to the config file under the config lock, as done by the CLI processes,
+// so that the tokens in the config file remain valid if the broker stops or is bypassed.
+
+const (
+	brokerActionToken  = "token"
+	brokerActionStatus = "status"
+	brokerActionStop   = "stop"
+
+	brokerDialTimeout = time.Second
+	// The maximal time for handling a request, which may include refreshing the token.
+	brokerRequestTimeout = 2 * time.Minute
+	// The interval, in which the broker checks if the tokens should be refreshed.
+	brokerRefreshCheckInterval = time.Minute
+)
+
+type brokerRequest struct {
+	Action    string    `json:"action"`
+	ServerId  string    `json:"serverId,omitempty"`
+	TokenType TokenType `json:"tokenType,omitempty"`
+	// The URLs of the server in the CLI process, which must match the URLs of the server in the broker's config.
+	Url            string `json:"url,omitempty"`
+	ArtifactoryUrl string `json:"artifactoryUrl,omitempty"`
+	// The access token used by the CLI process, which is about to expire.
+	AccessToken string `json:"accessToken,omitempty"` // #nosec G117 -- broker protocol field
+}
+
+type brokerResponse struct {
+	AccessToken string `json:"accessToken,omitempty"` // #nosec G117 -- broker protocol field
+	Error       string `json:"error,omitempty"`
+	// The status of the broker, returned for the status action.
+	Status *CredentialBrokerStatus `json:"status,omitempty"`
+}
+
+// CredentialBrokerStatus describes a running credential broker.
+type CredentialBrokerStatus struct {
+	Pid        int    `json:"pid"`
+	SocketPath string `json:"socketPath"`
+	StartTime  string `json:"startTime"`
+	// The IDs of the servers, whose tokens are owned by the broker.
+	ServerIds []string `json:"serverIds"`
+}
+
+// A server whose tokens are owned by the broker.
+type brokerServer struct {
+	serverId  string
+	tokenType TokenType
+	// Locked while refreshing the tokens, so that concurrent requests refresh the tokens once.
+	mutex sync.Mutex
+}
+
+type CredentialBroker struct {
+	socketPath string
+	startTime  time.Time
+	listener   net.Listener
+	mutex      sync.Mutex
+	servers    map[string]*brokerServer
+	stopOnce   sync.Once
+	stopped    chan struct{}
+}
+
+func NewCredentialBroker() *CredentialBroker {
+	return &CredentialBroker{servers: make(map[string]*brokerServer), stopped: make(chan struct{})}
+}
+
+func (broker *CredentialBroker) SetSocketPath(socketPath string) *CredentialBroker {
+	broker.socketPath = socketPath
+	return broker
+}
+
+// Serves the CLI processes until the context is done, or a stop request is received.
+func (broker *CredentialBroker) Serve(ctx context.Context) (err error) {
+	if err = broker.listen(); err != nil {
+		return err
+	}
+	broker.startTime = time.Now()
+	log.Info("The credential broker is listening on " + broker.socketPath)
+	go broker.refreshTokensPeriodically()
+	go func() {
+		select {
+		case <-ctx.Done():
+			broker.stop()
+		case <-broker.stopped:
+		}
+	}()
+	for {
+		conn, acceptErr := broker.listener.Accept()
+		if acceptErr != nil {
+			select {
+			case <-broker.stopped:
+				return errorutils.CheckError(removeSocketFile(broker.socketPath))
+			default:
+				return errorutils.CheckError(acceptErr)
+			}
+		}
+		go broker.handleConnection(conn)
+	}
+}
+
+func (broker *CredentialBroker) listen() (err error) {
+	if broker.socketPath == "" {
+		if broker.socketPath, err = coreutils.GetJfrogCredentialBrokerSocketPath(); err != nil {
+			return err
+		}
+	}
+	exists, err := fileutils.IsFileExists(broker.socketPath, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if _, err = sendBrokerRequest(broker.socketPath, &brokerRequest{Action: brokerActionStatus}); err == nil {
+			return errorutils.CheckErrorf("a credential broker is already listening on %s", broker.socketPath)
+		}
+		log.Debug("Removing the socket file of a credential broker which is no longer running: " + broker.socketPath)
+		if err = removeSocketFile(broker.socketPath); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	// Only the current user may request tokens from the broker.
+	broker.listener, err = listenOnPrivateSocket(broker.socketPath)
+	return err
+}
+
+func (broker *CredentialBroker) stop() {
+	broker.stopOnce.Do(func() {
+		close(broker.stopped)
+		if err := broker.listener.Close(); err != nil {
+			log.Debug("Failed closing the credential broker listener: " + err.Error())
+		}
+	})
+}
+
+func (broker *CredentialBroker) handleConnection(conn net.Conn) {
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Debug("Failed closing a credential broker connection: " + err.Error())
+		}
+	}()
+	if err := conn.SetDeadline(time.Now().Add(brokerRequestTimeout)); err != nil {
+		log.Debug(err.Error())
+		return
+	}
+	request := new(brokerRequest)
+	if err := json.NewDecoder(conn).Decode(request); err != nil {
+		log.Debug("Failed reading a credential broker request: " + err.Error())
+		return
+	}
+	response := broker.handleRequest(request)
+	if err := json.NewEncoder(conn).Encode(response); err != nil {
+		log.Debug("Failed writing a credential broker response: " + err.Error())
+	}
+	if request.Action == brokerActionStop {
+		broker.stop()
+	}
+}
+
+func (broker *CredentialBroker) handleRequest(request *brokerRequest) *brokerResponse {
+	switch request.Action {
+	case brokerActionToken:
+		accessToken, err := broker.getAccessToken(request)
+		if err != nil {
+			return &brokerResponse{Error: err.Error()}
+		}
+		return &brokerResponse{AccessToken: accessToken}
+	case brokerActionStatus:
+		return &brokerResponse{Status: broker.status()}
+	case brokerActionStop:
+		log.Info("Stopping the credential broker.")
+		return &brokerResponse{}
+	default:
+		return &brokerResponse{Error: "unsupported credential broker action: " + request.Action}
+	}
+}
+
+// Returns a valid access token of the requested server, refreshing the token if needed.
+func (broker *CredentialBroker) getAccessToken(request *brokerRequest) (string, error) {
+	if request.TokenType != AccessToken && request.TokenType != ArtifactoryToken {
+		return "", errors.New("unsupported refreshable token type: " + string(request.TokenType))
+	}
+	serverDetails, err := GetSpecificConfig(request.ServerId, true, false)
+	if err != nil {
+		return "", err
+	}
+	if !isSameBrokerServerUrl(serverDetails.Url, request.Url) || !isSameBrokerServerUrl(serverDetails.ArtifactoryUrl, request.ArtifactoryUrl) {
+		return "", errors.New("the URLs of server ID '" + serverDetails.ServerId + "' differ from the URLs in the credential broker's config")
+	}
+	server := broker.addServer(serverDetails.ServerId, request.TokenType)
+	// The token was already refreshed by the broker.
+	if serverDetails.AccessToken != "" && serverDetails.AccessToken != request.AccessToken {
+		return serverDetails.AccessToken, nil
+	}
+	return server.refreshToken(request.AccessToken)
+}
+
+func (broker *CredentialBroker) addServer(serverId string, tokenType TokenType) *brokerServer {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	server, exists := broker.servers[serverId]
+	if !exists || server.tokenType != tokenType {
+		log.Debug("The credential broker owns the tokens of server ID '" + serverId + "'.")
+		server = &brokerServer{serverId: serverId, tokenType: tokenType}
+		broker.servers[serverId] = server
+	}
+	return server
+}
+
+// Refreshes the server's token, unless it was already refreshed, and writes the new tokens to the config.
+// The wait before the refresh is done before locking the server, so that the requests of the server don't wait for each other.
+func (server *brokerServer) refreshToken(currentAccessToken string) (string, error) {
+	if err := waitBeforeTokenRefresh(currentAccessToken); err != nil {
+		return "", err
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return refreshTokenAndWriteToConfig(server.serverId, currentAccessToken, server.tokenType, false)
+}
+
+func (broker *CredentialBroker) getServers() []*brokerServer {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	servers := make([]*brokerServer, 0, len(broker.servers))
+	for _, server := range broker.servers {
+		servers = append(servers, server)
+	}
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].serverId < servers[j].serverId
+	})
+	return servers
+}
+
+func (broker *CredentialBroker) status() *CredentialBrokerStatus {
+	status := &CredentialBrokerStatus{
+		Pid:        os.Getpid(),
+		SocketPath: broker.socketPath,
+		StartTime:  broker.startTime.Format(time.RFC3339),
+		ServerIds:  []string{},
+	}
+	for _, server := range broker.getServers() {
+		status.ServerIds = append(status.ServerIds, server.serverId)
+	}
+	return status
+}
+
+func (broker *CredentialBroker) refreshTokensPeriodically() {
+	ticker := time.NewTicker(brokerRefreshCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-broker.stopped:
+			return
+		case <-ticker.C:
+			for _, server := range broker.getServers() {
+				refreshBrokerServerTokenIfNeeded(server)
+			}
+		}
+	}
+}
+
+// Refreshes the server's token ahead of its expiry, so that the CLI processes receive a valid token without waiting for the refresh.
+func refreshBrokerServerTokenIfNeeded(server *brokerServer) {
+	serverDetails, err := GetSpecificConfig(server.serverId, true, false)
+	if err != nil {
+		log.Warn("The credential broker failed reading server ID '" + server.serverId + "': " + err.Error())
+		return
+	}
+	if serverDetails.AccessToken == "" {
+		return
+	}
+	refreshBeforeExpiryMinutes, err := refreshThresholdForTokenType(serverDetails.AccessToken, server.tokenType)
+	if err != nil {
+		return
+	}
+	timeLeft, err := auth.GetTokenMinutesLeft(serverDetails.AccessToken)
+	if err != nil || timeLeft > refreshBeforeExpiryMinutes {
+		return
+	}
+	log.Debug("The credential broker is refreshing the token of server ID '" + server.serverId + "', which expires in " + strconv.FormatInt(timeLeft, 10) + " minutes.")
+	if _, err = server.refreshToken(serverDetails.AccessToken); err != nil {
+		log.Warn("The credential broker failed refreshing the token of server ID '" + server.serverId + "': " + err.Error())
+	}
+}
+
+func isSameBrokerServerUrl(brokerUrl, requestUrl string) bool {
+	return requestUrl == "" || clientUtils.AddTrailingSlashIfNeeded(brokerUrl) == clientUtils.AddTrailingSlashIfNeeded(requestUrl)
+}
+
+func removeSocketFile(socketPath string) error {
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Requests a valid access token of the server from the credential broker.
+// Returns an empty token if the broker isn't running or can't provide the token, in which case the current process should refresh the token itself.
+func getAccessTokenFromCredentialBroker(serverDetails *ServerDetails, currentAccessToken string, tokenType TokenType) string {
+	// The broker refreshes the tokens using service managers without refresh interceptors, so it never sends requests to itself.
+	if isCredentialBrokerDisabled() {
+		return ""
+	}
+	socketPath, err := coreutils.GetJfrogCredentialBrokerSocketPath()
+	if err != nil {
+		return ""
+	}
+	if exists, err := fileutils.IsFileExists(socketPath, false); err != nil || !exists {
+		return ""
+	}
+	response, err := sendBrokerRequest(socketPath, &brokerRequest{
+		Action:         brokerActionToken,
+		ServerId:       serverDetails.ServerId,
+		TokenType:      tokenType,
+		Url:            serverDetails.Url,
+		ArtifactoryUrl: serverDetails.ArtifactoryUrl,
+		AccessToken:    currentAccessToken,
+	})
+	if err != nil {
+		log.Debug("Refreshing the token without the credential broker: " + err.Error())
+		return ""
+	}
+	log.Debug("Received a new token from the credential broker.")
+	return response.AccessToken
+}
+
+func isCredentialBrokerDisabled() bool {
+	disabled, err := strconv.ParseBool(os.Getenv(coreutils.CredentialBrokerDisabled))
+	return err == nil && disabled
+}
+
+func sendBrokerRequest(socketPath string, request *brokerRequest) (response *brokerResponse, err error) {
+	conn, err := net.DialTimeout("unix", socketPath, brokerDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, conn.Close())
+	}()
+	if err = conn.SetDeadline(time.Now().Add(brokerRequestTimeout)); err != nil {
+		return nil, err
+	}
+	if err = json.NewEncoder(conn).Encode(request); err != nil {
+		return nil, err
+	}
+	response = new(brokerResponse)
+	if err = json.NewDecoder(conn).Decode(response); err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return nil, errors.New("credential broker: " + response.Error)
+	}
+	return response, nil
+}
+
+// Returns the status of the credential broker listening on the socket path, or an error if no broker is running.
+// If the socket path is empty, the default socket path is used.
+func GetCredentialBrokerStatus(socketPath string) (*CredentialBrokerStatus, error) {
+	socketPath, err := getBrokerSocketPath(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sendBrokerRequest(socketPath, &brokerRequest{Action: brokerActionStatus})
+	if err != nil {
+		return nil, errorutils.CheckErrorf("the credential broker is not running: %s", err.Error())
+	}
+	return response.Status, nil
+}
+
+// Stops the credential broker listening on the socket path. If the socket path is empty, the default socket path is used.
+func StopCredentialBroker(socketPath string) error {
+	socketPath, err := getBrokerSocketPath(socketPath)
+	if err != nil {
+		return err
+	}
+	if _, err = sendBrokerRequest(socketPath, &brokerRequest{Action: brokerActionStop}); err != nil {
+		return errorutils.CheckErrorf("the credential broker is not running: %s", err.Error())
+	}
+	return nil
+}
+
+func getBrokerSocketPath(socketPath string) (string, error) {
+	if socketPath != "" {
+		return socketPath, nil
+	}
+	return coreutils.GetJfrogCredentialBrokerSocketPath()
+}
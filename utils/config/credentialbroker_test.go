@@ -0,0 +1,117 @@
+package config
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Starts a credential broker on the default socket path, and returns a function stopping it.
+func startTestCredentialBroker(t *testing.T) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- NewCredentialBroker().Serve(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		_, err := GetCredentialBrokerStatus("")
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	return func() {
+		cancel()
+		assert.NoError(t, <-served)
+	}
+}
+
+func TestCredentialBrokerStatusAndStop(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	_, err := GetCredentialBrokerStatus("")
+	assert.ErrorContains(t, err, "not running")
+
+	served := make(chan error, 1)
+	go func() {
+		served <- NewCredentialBroker().Serve(context.Background())
+	}()
+	require.Eventually(t, func() bool {
+		_, err = GetCredentialBrokerStatus("")
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	status, err := GetCredentialBrokerStatus("")
+	require.NoError(t, err)
+	socketPath, err := coreutils.GetJfrogCredentialBrokerSocketPath()
+	require.NoError(t, err)
+	assert.Equal(t, socketPath, status.SocketPath)
+	assert.Empty(t, status.ServerIds)
+	if !coreutils.IsWindows() {
+		// The socket is accessible by the current user only.
+		socketInfo, err := os.Stat(socketPath)
+		require.NoError(t, err)
+		assert.Zero(t, socketInfo.Mode().Perm()&0077)
+	}
+
+	// A second broker can't listen on the same socket.
+	assert.ErrorContains(t, NewCredentialBroker().Serve(context.Background()), "already listening")
+
+	require.NoError(t, StopCredentialBroker(""))
+	assert.NoError(t, <-served)
+	assert.NoFileExists(t, socketPath)
+}
+
+func TestTokenRefreshWithCredentialBroker(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	now := time.Now().Unix()
+	expiredToken := buildTestAccessToken(t, now-2*60*60, now-60*60)
+	newAccessToken := buildTestAccessToken(t, now, now+365*24*60*60)
+	requests := new(int32)
+	testServer := createTokenRefreshTestServer(t, newAccessToken, requests)
+	serverDetails := &ServerDetails{ServerId: "server", Url: testServer.URL + "/", AccessToken: expiredToken, RefreshToken: "refresh", IsDefault: true}
+	require.NoError(t, SaveServersConf([]*ServerDetails{serverDetails}))
+	defer startTestCredentialBroker(t)()
+
+	// The broker refreshes the token once, and hands it to every request.
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, newAccessToken, getAccessTokenFromCredentialBroker(serverDetails, expiredToken, AccessToken))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	// The refreshed tokens are written to the config, for the processes which don't use the broker.
+	configServerDetails, err := GetSpecificConfig("server", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, newAccessToken, configServerDetails.AccessToken)
+	assert.Equal(t, "new-refresh-"+newAccessToken, configServerDetails.RefreshToken)
+	status, err := GetCredentialBrokerStatus("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"server"}, status.ServerIds)
+
+	// The interceptor receives the token from the broker.
+	accessDetails, err := serverDetails.CreateAccessAuthConfig()
+	require.NoError(t, err)
+	httpClientDetails := httputils.HttpClientDetails{AccessToken: expiredToken}
+	require.NoError(t, accessDetails.RunPreRequestFunctions(&httpClientDetails))
+	assert.Equal(t, newAccessToken, httpClientDetails.AccessToken)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+
+	// The broker doesn't hand tokens of a server whose URLs differ from the broker's config.
+	otherServerDetails := &ServerDetails{ServerId: "server", Url: "https://other.jfrog.io/"}
+	assert.Empty(t, getAccessTokenFromCredentialBroker(otherServerDetails, expiredToken, AccessToken))
+
+	// The broker isn't used if disabled.
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.CredentialBrokerDisabled, "true")()
+	assert.Empty(t, getAccessTokenFromCredentialBroker(serverDetails, expiredToken, AccessToken))
+}
+
+func TestTokenRefreshWithoutCredentialBroker(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	assert.Empty(t, getAccessTokenFromCredentialBroker(&ServerDetails{ServerId: "server"}, "token", AccessToken))
+}
@@ -0,0 +1,41 @@
+//go:build linux || darwin || freebsd || openbsd
+// +build linux darwin freebsd openbsd
+
+package config
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// This file will be compiled only on unix systems.
+// Listens on the Unix socket, which is accessible by the current user only.
+// The socket is created in a new directory accessible by the current user only, and moved to its path once its permissions are restricted,
+// so that it's never accessible by other users.
+func listenOnPrivateSocket(socketPath string) (listener net.Listener, err error) {
+	privateDirPath, err := os.MkdirTemp(filepath.Dir(socketPath), ".credential-broker-")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(os.RemoveAll(privateDirPath)))
+	}()
+	privateSocketPath := filepath.Join(privateDirPath, filepath.Base(socketPath))
+	unixListener, err := net.ListenUnix("unix", &net.UnixAddr{Name: privateSocketPath, Net: "unix"})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	// The socket is removed from its final path by the broker.
+	unixListener.SetUnlinkOnClose(false)
+	if err = os.Chmod(privateSocketPath, 0600); err == nil {
+		err = os.Rename(privateSocketPath, socketPath)
+	}
+	if err != nil {
+		return nil, errors.Join(errorutils.CheckError(err), errorutils.CheckError(unixListener.Close()))
+	}
+	return unixListener, nil
+}
@@ -0,0 +1,14 @@
+package config
+
+import (
+	"net"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// This file will be compiled on Windows.
+// Listens on the Unix socket. On Windows, the socket's access is determined by the ACL it inherits from its directory.
+func listenOnPrivateSocket(socketPath string) (net.Listener, error) {
+	listener, err := net.Listen("unix", socketPath)
+	return listener, errorutils.CheckError(err)
+}
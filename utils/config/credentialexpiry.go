@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+type ExpiringCredential string
+
+const (
+	ExpiringAccessToken       ExpiringCredential = "accessToken"
+	ExpiringClientCertificate ExpiringCredential = "clientCertificate"
+)
+
+// CredentialExpiry is a credential of a server, which expires within the warning window, or has already expired.
+type CredentialExpiry struct {
+	ServerId   string             `json:"serverId,omitempty"`
+	Credential ExpiringCredential `json:"credential"`
+	ExpiresAt  time.Time          `json:"expiresAt"`
+	Expired    bool               `json:"expired"`
+}
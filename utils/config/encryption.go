@@ -67,6 +67,10 @@ func updateEncryptionIfNeeded(config *Config) error {
 	if err != nil || masterKey == "" {
 		return err
 	}
+	if IsConfigReadOnly() {
+		log.Debug("The config is not encrypted, since the config is read-only.")
+		return nil
+	}
 	// The encryption key exists and will be loaded again in encrypt()
 	return saveConfig(config)
 }
@@ -87,6 +91,10 @@ func handleSecrets(config *Config, handler secretHandler, key string) error {
 		if err != nil {
 			return err
 		}
+		serverDetails.ClientCertKeyPassphrase, err = handler(serverDetails.ClientCertKeyPassphrase, key)
+		if err != nil {
+			return err
+		}
 		serverDetails.RefreshToken, err = handler(serverDetails.RefreshToken, key)
 		if err != nil {
 			return err
@@ -95,6 +103,19 @@ func handleSecrets(config *Config, handler secretHandler, key string) error {
 		if err != nil {
 			return err
 		}
+		for _, serviceCredentials := range serverDetails.ServiceCredentials {
+			if serviceCredentials == nil {
+				continue
+			}
+			serviceCredentials.Password, err = handler(serviceCredentials.Password, key)
+			if err != nil {
+				return err
+			}
+			serviceCredentials.AccessToken, err = handler(serviceCredentials.AccessToken, key)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
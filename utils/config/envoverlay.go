@@ -0,0 +1,201 @@
+package config
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A server field which can be set by an environment variable.
+type envServerField struct {
+	// The suffix of the environment variable, following the server ID.
+	suffix string
+	// The JSON name of the field in the config file.
+	field string
+	set   func(details *ServerDetails, value string)
+}
+
+var envServerFields = []envServerField{
+	{"URL", "url", func(details *ServerDetails, value string) { details.Url = utils.AddTrailingSlashIfNeeded(value) }},
+	{"ARTIFACTORY_URL", "artifactoryUrl", func(details *ServerDetails, value string) {
+		details.ArtifactoryUrl = utils.AddTrailingSlashIfNeeded(value)
+	}},
+	{"DISTRIBUTION_URL", "distributionUrl", func(details *ServerDetails, value string) {
+		details.DistributionUrl = utils.AddTrailingSlashIfNeeded(value)
+	}},
+	{"XRAY_URL", "xrayUrl", func(details *ServerDetails, value string) { details.XrayUrl = utils.AddTrailingSlashIfNeeded(value) }},
+	{"MISSION_CONTROL_URL", "missionControlUrl", func(details *ServerDetails, value string) {
+		details.MissionControlUrl = utils.AddTrailingSlashIfNeeded(value)
+	}},
+	{"PIPELINES_URL", "pipelinesUrl", func(details *ServerDetails, value string) {
+		details.PipelinesUrl = utils.AddTrailingSlashIfNeeded(value)
+	}},
+	{"ACCESS_URL", "accessUrl", func(details *ServerDetails, value string) { details.AccessUrl = utils.AddTrailingSlashIfNeeded(value) }},
+	{"USER", "user", func(details *ServerDetails, value string) { details.User = value }},
+	{"PASSWORD", "password", func(details *ServerDetails, value string) { details.Password = value }},
+	{"ACCESS_TOKEN", "accessToken", func(details *ServerDetails, value string) { details.AccessToken = value }},
+	{"REFRESH_TOKEN", "refreshToken", func(details *ServerDetails, value string) { details.RefreshToken = value }},
+	{"SSH_KEY_PATH", "sshKeyPath", func(details *ServerDetails, value string) { details.SshKeyPath = value }},
+	{"SSH_PASSPHRASE", "sshPassphrase", func(details *ServerDetails, value string) { details.SshPassphrase = value }},
+	{"CLIENT_CERT_PATH", "clientCertPath", func(details *ServerDetails, value string) { details.ClientCertPath = value }},
+	{"CLIENT_CERT_KEY_PATH", "clientCertKeyPath", func(details *ServerDetails, value string) { details.ClientCertKeyPath = value }},
+	{"CLIENT_CERT_KEY_PASSPHRASE", "clientCertKeyPassphrase", func(details *ServerDetails, value string) {
+		details.ClientCertKeyPassphrase = value
+	}},
+	{"DEFAULT", "isDefault", func(details *ServerDetails, value string) {
+		isDefault, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Warn("Ignoring the invalid boolean value '" + value + "' of the server's default environment variable.")
+			return
+		}
+		details.IsDefault = isDefault
+	}},
+}
+
+var envServerIdInvalidChars = regexp.MustCompile(`[^A-Z0-9]`)
+
+// Returns the server ID as it appears in the names of the server's environment variables.
+func toEnvServerId(serverId string) string {
+	return envServerIdInvalidChars.ReplaceAllString(strings.ToUpper(serverId), "_")
+}
+
+// Returns the fields set by environment variables, keyed by the server ID as it appears in the variable names.
+// The values of each server are keyed by the field suffix of the environment variable.
+func getEnvServers() map[string]map[string]string {
+	envServers := make(map[string]map[string]string)
+	for _, envVar := range os.Environ() {
+		name, value, found := strings.Cut(envVar, "=")
+		if !found || value == "" || !strings.HasPrefix(name, coreutils.ServerEnvPrefix) {
+			continue
+		}
+		envServerId, suffix := parseServerEnvVarName(name)
+		if envServerId == "" {
+			continue
+		}
+		if envServers[envServerId] == nil {
+			envServers[envServerId] = make(map[string]string)
+		}
+		envServers[envServerId][suffix] = value
+	}
+	return envServers
+}
+
+// Splits the environment variable name to the server ID and the field suffix.
+// Since both may contain underscores, the longest matching field suffix is used.
+// Returns empty strings if the variable doesn't match any field.
+func parseServerEnvVarName(name string) (envServerId, suffix string) {
+	trimmed := strings.TrimPrefix(name, coreutils.ServerEnvPrefix)
+	for _, field := range envServerFields {
+		if len(field.suffix) <= len(suffix) {
+			continue
+		}
+		if id, found := strings.CutSuffix(trimmed, "_"+field.suffix); found && id != "" {
+			envServerId, suffix = id, field.suffix
+		}
+	}
+	return
+}
+
+// Servers can be defined or overridden by environment variables in the form of JFROG_CLI_SERVER_<ID>_<FIELD>, for example:
+// JFROG_CLI_SERVER_PROD_URL, JFROG_CLI_SERVER_PROD_ACCESS_TOKEN and JFROG_CLI_SERVER_PROD_XRAY_URL.
+// <ID> is the server ID in upper case, with every character which is not a letter or a digit replaced by an underscore.
+// For example, the variables of the 'my-server' server start with JFROG_CLI_SERVER_MY_SERVER_.
+//
+// The environment variables are applied over the config file when reading a server, and are never written to the config file.
+// The precedence of each field is:
+//  1. The field's environment variable.
+//  2. For the service URLs - the URL derived from the JFrog Platform URL environment variable, if it is set.
+//  3. The config file.
+//
+// If any of the credentials (user, password or access token) is set by an environment variable, the credentials of the config
+// file are ignored, to avoid mixing credentials from different sources.
+// If the server ID doesn't match a server in the config file, a new server is defined by the environment variables.
+// The ID of such a server is the lower case <ID>, but it can be used with any ID whose normalized form is <ID>, such as 'my-server'.
+// The returned servers include values which aren't in the config file, and therefore should not be saved.
+func applyEnvOverlay(configs []*ServerDetails) []*ServerDetails {
+	envServers := getEnvServers()
+	if len(envServers) == 0 {
+		return configs
+	}
+	for _, details := range configs {
+		envServerId := toEnvServerId(details.ServerId)
+		if values, exists := envServers[envServerId]; exists {
+			applyServerEnvVars(details, envServerId, values, configs)
+			delete(envServers, envServerId)
+		}
+	}
+	envOnlyServerIds := make([]string, 0, len(envServers))
+	for envServerId := range envServers {
+		envOnlyServerIds = append(envOnlyServerIds, envServerId)
+	}
+	sort.Strings(envOnlyServerIds)
+	for _, envServerId := range envOnlyServerIds {
+		// Environment variable names are upper case by convention, so the ID of the server is lower case.
+		details := &ServerDetails{ServerId: strings.ToLower(envServerId), definedByEnv: true}
+		applyServerEnvVars(details, envServerId, envServers[envServerId], configs)
+		configs = append(configs, details)
+	}
+	// If no server is marked as default, the first server defined by environment variables is used as the default.
+	if len(envOnlyServerIds) > 0 {
+		if _, err := GetDefaultConfiguredConf(configs); err != nil {
+			configs[len(configs)-len(envOnlyServerIds)].IsDefault = true
+		}
+	}
+	return configs
+}
+
+func applyServerEnvVars(details *ServerDetails, envServerId string, values map[string]string, configs []*ServerDetails) {
+	if values["USER"] != "" || values["PASSWORD"] != "" || values["ACCESS_TOKEN"] != "" {
+		details.User = ""
+		details.Password = ""
+		details.AccessToken = ""
+		details.RefreshToken = ""
+		details.ArtifactoryRefreshToken = ""
+		details.ServiceCredentials = nil
+	}
+	if values["URL"] != "" {
+		platformUrl := utils.AddTrailingSlashIfNeeded(values["URL"])
+		details.ArtifactoryUrl = platformUrl + "artifactory/"
+		details.DistributionUrl = platformUrl + "distribution/"
+		details.XrayUrl = platformUrl + "xray/"
+		details.MissionControlUrl = platformUrl + "mc/"
+		details.PipelinesUrl = platformUrl + "pipelines/"
+	}
+	if isTrue(values["DEFAULT"]) {
+		// Only one server can be the default.
+		for _, other := range configs {
+			other.IsDefault = false
+		}
+	}
+	details.envVars = make(map[string]string, len(values))
+	for _, field := range envServerFields {
+		value, exists := values[field.suffix]
+		if !exists {
+			continue
+		}
+		field.set(details, value)
+		details.envVars[field.field] = coreutils.ServerEnvPrefix + envServerId + "_" + field.suffix
+	}
+}
+
+func isTrue(value string) bool {
+	parsed, err := strconv.ParseBool(value)
+	return err == nil && parsed
+}
+
+// Returns the name of the environment variable which set the provided field, or an empty string if the field was not set by an environment variable.
+// The field is the JSON name of the field in the config file, such as "url" or "accessToken".
+func (serverDetails *ServerDetails) GetSourceEnvVar(field string) string {
+	return serverDetails.envVars[field]
+}
+
+// Returns true if any of the server's fields was set by an environment variable.
+func (serverDetails *ServerDetails) IsOverriddenByEnv() bool {
+	return len(serverDetails.envVars) > 0
+}
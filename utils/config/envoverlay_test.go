@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvOverlayOverridesConfiguredServer(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	require.NoError(t, SaveServersConf([]*ServerDetails{
+		{ServerId: "my-server", Url: "http://file/", ArtifactoryUrl: "http://file/artifactory/", XrayUrl: "http://file/xray/", User: "admin", Password: "password", IsDefault: true},
+	}))
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_MY_SERVER_ACCESS_TOKEN", "env-token")()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_MY_SERVER_XRAY_URL", "http://env/xray")()
+
+	details, err := GetSpecificConfig("my-server", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "http://file/artifactory/", details.ArtifactoryUrl)
+	assert.Equal(t, "http://env/xray/", details.XrayUrl)
+	assert.Equal(t, "env-token", details.AccessToken)
+	// The file credentials should not be mixed with the environment credentials.
+	assert.Empty(t, details.User)
+	assert.Empty(t, details.Password)
+	assert.Equal(t, "JFROG_CLI_SERVER_MY_SERVER_ACCESS_TOKEN", details.GetSourceEnvVar("accessToken"))
+	assert.Empty(t, details.GetSourceEnvVar("artifactoryUrl"))
+
+	// The environment variables should not be written to the config file.
+	configs, err := GetAllServersConfigs()
+	require.NoError(t, err)
+	require.NoError(t, SaveServersConf(configs))
+	confFilePath, err := getConfFilePath()
+	require.NoError(t, err)
+	content, err := os.ReadFile(confFilePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "env-token")
+	assert.NotContains(t, string(content), "http://env/xray/")
+}
+
+func TestEnvOverlayDefinesServer(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_PROD_URL", "https://prod.jfrog.io")()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_PROD_ACCESS_TOKEN", "prod-token")()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_PROD_DISTRIBUTION_URL", "https://dist.jfrog.io")()
+
+	details, err := GetSpecificConfig("prod", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", details.ServerId)
+	assert.Equal(t, "https://prod.jfrog.io/", details.Url)
+	assert.Equal(t, "https://prod.jfrog.io/artifactory/", details.ArtifactoryUrl)
+	assert.Equal(t, "https://prod.jfrog.io/xray/", details.XrayUrl)
+	assert.Equal(t, "https://dist.jfrog.io/", details.DistributionUrl)
+	assert.Equal(t, "prod-token", details.AccessToken)
+	assert.True(t, details.IsOverriddenByEnv())
+
+	// The only server should be the default.
+	details, err = GetSpecificConfig("", true, false)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", details.ServerId)
+	assert.True(t, details.IsDefault)
+
+	exists, err := IsServerConfExists()
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestEnvOverlayServerIdCasing(t *testing.T) {
+	configs := []*ServerDetails{{ServerId: "my_server", definedByEnv: true}}
+	details, err := getServerConfByServerId("My-Server", configs)
+	require.NoError(t, err)
+	assert.Equal(t, "My-Server", details.ServerId)
+	// The server of the provided configs should not be modified.
+	assert.Equal(t, "my_server", configs[0].ServerId)
+}
+
+func TestEnvOverlayDefault(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	require.NoError(t, SaveServersConf([]*ServerDetails{{ServerId: "file", Url: "http://file/", IsDefault: true}}))
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_PROD_URL", "https://prod.jfrog.io")()
+
+	// The default server of the config file should be kept.
+	details, err := GetDefaultServerConf()
+	require.NoError(t, err)
+	assert.Equal(t, "file", details.ServerId)
+
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_PROD_DEFAULT", "true")()
+	details, err = GetDefaultServerConf()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", details.ServerId)
+	configs, err := GetAllServersConfigsWithEnvOverlay()
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.False(t, configs[0].IsDefault)
+}
+
+func TestSavedServerConfigIgnoresEnvOverlay(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	require.NoError(t, SaveServersConf([]*ServerDetails{{ServerId: "my-server", Url: "http://file/", XrayUrl: "http://file/xray/", AccessToken: "file-token", IsDefault: true}}))
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_MY_SERVER_ACCESS_TOKEN", "env-token")()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_MY_SERVER_XRAY_URL", "http://env/xray")()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_PROD_URL", "https://prod.jfrog.io")()
+	defer testsutils.SetEnvWithCallbackAndAssert(t, "JFROG_CLI_SERVER_PROD_DEFAULT", "true")()
+
+	details, err := GetSavedServerConfig("my-server", false)
+	require.NoError(t, err)
+	assert.Equal(t, "file-token", details.AccessToken)
+	assert.Equal(t, "http://file/xray/", details.XrayUrl)
+	assert.False(t, details.IsOverriddenByEnv())
+
+	// The exported config token includes only the saved config.
+	configToken, err := Export(details)
+	require.NoError(t, err)
+	exported, err := Import(configToken)
+	require.NoError(t, err)
+	assert.Equal(t, "file-token", exported.AccessToken)
+	assert.Equal(t, "http://file/xray/", exported.XrayUrl)
+
+	// The servers defined by environment variables aren't saved, and don't change the saved default.
+	details, err = GetSavedServerConfig("", true)
+	require.NoError(t, err)
+	assert.Equal(t, "my-server", details.ServerId)
+	_, err = GetSavedServerConfig("prod", false)
+	assert.Error(t, err)
+}
+
+func TestParseServerEnvVarName(t *testing.T) {
+	testCases := []struct {
+		name             string
+		expectedServerId string
+		expectedSuffix   string
+	}{
+		{"JFROG_CLI_SERVER_PROD_URL", "PROD", "URL"},
+		{"JFROG_CLI_SERVER_PROD_XRAY_URL", "PROD", "XRAY_URL"},
+		{"JFROG_CLI_SERVER_MY_SERVER_ACCESS_TOKEN", "MY_SERVER", "ACCESS_TOKEN"},
+		{"JFROG_CLI_SERVER_PROD_CLIENT_CERT_KEY_PATH", "PROD", "CLIENT_CERT_KEY_PATH"},
+		{"JFROG_CLI_SERVER_ID", "", ""},
+		{"JFROG_CLI_SERVER_PROD_UNKNOWN", "", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			serverId, suffix := parseServerEnvVarName(testCase.name)
+			assert.Equal(t, testCase.expectedServerId, serverId)
+			assert.Equal(t, testCase.expectedSuffix, suffix)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// Returns true if the server has HTTP client settings, which require a dedicated HTTP client.
+// The HTTP retries aren't included, since they're applied by the services config.
+// A PKCS#12 or encrypted client certificate also requires a dedicated client, since the default client loads unencrypted PEM files only.
+func (serverDetails *ServerDetails) HasHttpClientSettings() bool {
+	return serverDetails.Proxy != "" || serverDetails.CaBundlePath != "" || serverDetails.DialTimeoutSecs > 0 || serverDetails.RequestTimeoutSecs > 0 ||
+		serverDetails.hasCustomClientCert()
+}
+
+// Copies the HTTP client settings of the source server, including the insecure TLS setting.
+func (serverDetails *ServerDetails) copyHttpClientSettings(source *ServerDetails) {
+	serverDetails.InsecureTls = source.InsecureTls
+	serverDetails.Proxy = source.Proxy
+	serverDetails.CaBundlePath = source.CaBundlePath
+	serverDetails.DialTimeoutSecs = source.DialTimeoutSecs
+	serverDetails.RequestTimeoutSecs = source.RequestTimeoutSecs
+	serverDetails.HttpRetries = source.HttpRetries
+	serverDetails.HttpRetryWaitMilliSecs = source.HttpRetryWaitMilliSecs
+}
+
+// HttpSettingsConfigBuilder is implemented by the services config builder of jfrog-client-go, whose setters return the builder itself.
+type HttpSettingsConfigBuilder[B any] interface {
+	SetHttpClient(httpClient *http.Client) B
+	SetHttpRetries(httpRetries int) B
+	SetHttpRetryWaitMilliSecs(httpRetryWaitMilliSecs int) B
+}
+
+// Applies the server's HTTP client settings to the services config builder: the HTTP client with the server's proxy, CA bundle,
+// timeouts and client certificate, and the server's HTTP retries, if configured.
+// Every service manager of the server should be created with the settings applied by this function.
+// Returns the server's HTTP client, or nil if the default client of the services is used.
+func ApplyHttpSettings[B HttpSettingsConfigBuilder[B]](configBuilder B, serverDetails *ServerDetails) (*http.Client, error) {
+	httpClient, err := serverDetails.CreateHttpClient()
+	if err != nil {
+		return nil, err
+	}
+	configBuilder.SetHttpClient(httpClient)
+	if serverDetails.HttpRetries != nil {
+		configBuilder.SetHttpRetries(*serverDetails.HttpRetries)
+		configBuilder.SetHttpRetryWaitMilliSecs(serverDetails.HttpRetryWaitMilliSecs)
+	}
+	return httpClient, nil
+}
+
+// Validates the HTTP client settings of the server.
+func (serverDetails *ServerDetails) ValidateHttpClientSettings() error {
+	if serverDetails.Proxy != "" {
+		if _, err := parseProxyUrl(serverDetails.Proxy); err != nil {
+			return err
+		}
+	}
+	if serverDetails.CaBundlePath != "" {
+		exists, err := fileutils.IsFileExists(serverDetails.CaBundlePath, false)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errorutils.CheckErrorf("the CA bundle file '%s' does not exist", serverDetails.CaBundlePath)
+		}
+	}
+	if serverDetails.DialTimeoutSecs < 0 || serverDetails.RequestTimeoutSecs < 0 || serverDetails.HttpRetryWaitMilliSecs < 0 {
+		return errorutils.CheckErrorf("the HTTP timeouts and retry wait of server ID '%s' must not be negative", serverDetails.ServerId)
+	}
+	if serverDetails.HttpRetries != nil && *serverDetails.HttpRetries < 0 {
+		return errorutils.CheckErrorf("the HTTP retries of server ID '%s' must not be negative", serverDetails.ServerId)
+	}
+	return nil
+}
+
+// Creates the HTTP client used to access the server's services, applying the server's proxy, CA bundle and timeouts.
+// Returns nil if the server has no HTTP client settings, in which case the default client of the services should be used.
+// Since the client replaces the default client, it also applies the certificates in the JFrog CLI certs directory, the client certificate and the insecure TLS setting.
+func (serverDetails *ServerDetails) CreateHttpClient() (*http.Client, error) {
+	if !serverDetails.HasHttpClientSettings() {
+		return nil, nil
+	}
+	if err := serverDetails.ValidateHttpClientSettings(); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := serverDetails.createTlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if serverDetails.Proxy != "" {
+		// The proxy was already validated.
+		proxyUrl, _ := parseProxyUrl(serverDetails.Proxy)
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+	if serverDetails.DialTimeoutSecs > 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   time.Duration(serverDetails.DialTimeoutSecs) * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(serverDetails.RequestTimeoutSecs) * time.Second,
+	}, nil
+}
+
+func (serverDetails *ServerDetails) createTlsConfig() (*tls.Config, error) {
+	// #nosec G402 -- Insecure TLS is applied only if explicitly requested.
+	tlsConfig := &tls.Config{InsecureSkipVerify: serverDetails.InsecureTls}
+	rootCas, err := x509.SystemCertPool()
+	if err != nil || rootCas == nil {
+		rootCas = x509.NewCertPool()
+	}
+	certsDir, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		return nil, err
+	}
+	if err = appendCertsFromDir(rootCas, certsDir); err != nil {
+		return nil, err
+	}
+	if serverDetails.CaBundlePath != "" {
+		if err = appendCertsFromFile(rootCas, serverDetails.CaBundlePath); err != nil {
+			return nil, err
+		}
+	}
+	tlsConfig.RootCAs = rootCas
+	if serverDetails.ClientCertPath != "" {
+		certificate, err := serverDetails.LoadClientCertificate()
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed loading the client certificate: %s", err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+func parseProxyUrl(proxy string) (*url.URL, error) {
+	// The proxy URL isn't included in the errors, since it may include credentials.
+	proxyUrl, err := url.Parse(proxy)
+	if err != nil || proxyUrl.Scheme == "" || proxyUrl.Host == "" {
+		return nil, errorutils.CheckErrorf("invalid proxy URL. The proxy URL must include a scheme and a host, for example http://proxy.example.com:8080")
+	}
+	// The proxy URL is saved, printed and audited as plain text, and therefore must not include credentials.
+	if proxyUrl.User != nil {
+		return nil, errorutils.CheckErrorf("the proxy URL must not include credentials. To use a proxy which requires authentication, don't configure the proxy for the server, and set the proxy URL with its credentials in the HTTP_PROXY or HTTPS_PROXY environment variable instead")
+	}
+	return proxyUrl, nil
+}
+
+// Appends the certificates of every file in the directory, as done for the default client of the services.
+func appendCertsFromDir(certPool *x509.CertPool, dir string) error {
+	exists, err := fileutils.IsDirExists(dir, false)
+	if err != nil || !exists {
+		return err
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		// Files which aren't PEM certificates are ignored.
+		content, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		certPool.AppendCertsFromPEM(content)
+	}
+	return nil
+}
+
+func appendCertsFromFile(certPool *x509.CertPool, filePath string) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if !certPool.AppendCertsFromPEM(content) {
+		return errorutils.CheckErrorf("no PEM certificates were found in the CA bundle file '%s'", filePath)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	rotateKeyErrorPrefix = "cannot rotate the master key: "
+	rotateBackupSuffix   = ".rotate-backup"
+)
+
+// Rotates the master key used to encrypt the config secrets.
+// The secrets are decrypted with the current master key from the security configuration file, and re-encrypted with a newly generated key.
+// A backup of the JFrog home directory is created before the rotation. If the rotation fails, both the security
+// configuration file and the config file are restored.
+// The caller is responsible for locking the config file.
+func RotateMasterKey() (err error) {
+	if IsConfigReadOnly() {
+		return errorutils.CheckError(ErrConfigReadOnly)
+	}
+	if _, exists := os.LookupEnv(coreutils.EncryptionKey); exists {
+		return errorutils.CheckErrorf(rotateKeyErrorPrefix+"the master key is provided by the '%s' environment variable and should be rotated by updating it", coreutils.EncryptionKey)
+	}
+	oldKey, err := getEncryptionKeyFromSecurityConfFile()
+	if err != nil {
+		return err
+	}
+	if oldKey == "" {
+		return errorutils.CheckErrorf(rotateKeyErrorPrefix + "the config is not encrypted, since the security configuration file was not found")
+	}
+	// Decrypt the config with the current master key.
+	config, err := readConf()
+	if err != nil {
+		return err
+	}
+	if err = createHomeDirBackup(); err != nil {
+		return err
+	}
+
+	secFilePath, err := coreutils.GetJfrogSecurityConfFilePath()
+	if err != nil {
+		return err
+	}
+	confFilePath, err := getConfFilePath()
+	if err != nil {
+		return err
+	}
+	restoreSecFile, err := ioutils.BackupFile(secFilePath, filepath.Base(secFilePath)+rotateBackupSuffix)
+	if err != nil {
+		return err
+	}
+	restoreConfFile, err := ioutils.BackupFile(confFilePath, filepath.Base(confFilePath)+rotateBackupSuffix)
+	if err != nil {
+		return errors.Join(err, removeRotateBackup(secFilePath))
+	}
+	defer func() {
+		if err != nil {
+			log.Error("Master key rotation failed. Restoring the previous master key and config.")
+			err = errors.Join(err, restoreSecFile(), restoreConfFile())
+			return
+		}
+		// The backups contain the previous master key and the secrets encrypted with it.
+		err = errors.Join(removeRotateBackup(secFilePath), removeRotateBackup(confFilePath))
+	}()
+
+	newKey, err := generateMasterKey()
+	if err != nil {
+		return err
+	}
+	if err = writeMasterKey(secFilePath, newKey); err != nil {
+		return err
+	}
+	// Encrypt the config with the new master key, which is now read from the security configuration file.
+	if err = saveConfig(config); err != nil {
+		return err
+	}
+	// Verify the config can be decrypted with the new master key.
+	if _, err = readConf(); err != nil {
+		return err
+	}
+	log.Info("The master key was rotated successfully.")
+	return nil
+}
+
+func removeRotateBackup(filePath string) error {
+	return errorutils.CheckError(os.RemoveAll(filePath + rotateBackupSuffix))
+}
+
+// Generates a random master key of exactly masterKeyLength printable characters.
+func generateMasterKey() (string, error) {
+	randomBytes := make([]byte, base64.RawURLEncoding.DecodedLen(masterKeyLength))
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+// Writes the master key to the security configuration file, preserving its other fields.
+// The file is replaced atomically, so it never contains a partially written key.
+func writeMasterKey(secFilePath, key string) error {
+	securityConf := new(SecurityConf)
+	content, err := os.ReadFile(secFilePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = yaml.Unmarshal(content, securityConf); err != nil {
+		return errorutils.CheckError(err)
+	}
+	securityConf.MasterKey = key
+	if content, err = yaml.Marshal(securityConf); err != nil {
+		return errorutils.CheckError(err)
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(secFilePath), filepath.Base(secFilePath)+".*.tmp")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		// Ignore the error, since the temp file doesn't exist after a successful rename.
+		_ = os.Remove(tempFile.Name())
+	}()
+	if _, err = tempFile.Write(content); err != nil {
+		return errorutils.CheckError(errors.Join(err, tempFile.Close()))
+	}
+	if err = tempFile.Sync(); err != nil {
+		return errorutils.CheckError(errors.Join(err, tempFile.Close()))
+	}
+	if err = tempFile.Close(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.Rename(tempFile.Name(), secFilePath))
+}
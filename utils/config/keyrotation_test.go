@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateMasterKey(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, true)
+	defer cleanUpTempEnv()
+
+	// Encrypt the config with the current master key.
+	originalConfig := createEncryptionTestConfig()
+	require.NoError(t, saveConfig(originalConfig))
+	oldKey, err := getEncryptionKeyFromSecurityConfFile()
+	require.NoError(t, err)
+	encryptedWithOldKey := readConfFromFile(t)
+
+	require.NoError(t, RotateMasterKey())
+
+	newKey, err := getEncryptionKeyFromSecurityConfFile()
+	require.NoError(t, err)
+	assert.Len(t, newKey, masterKeyLength)
+	assert.NotEqual(t, oldKey, newKey)
+
+	// The secrets should be encrypted with the new key.
+	encryptedWithNewKey := readConfFromFile(t)
+	verifyEncryptionStatus(t, originalConfig, encryptedWithNewKey, true)
+	assert.NotEqual(t, encryptedWithOldKey.Servers[0].Password, encryptedWithNewKey.Servers[0].Password)
+	password, err := decrypt(encryptedWithNewKey.Servers[0].Password, newKey)
+	assert.NoError(t, err)
+	assert.Equal(t, originalConfig.Servers[0].Password, password)
+
+	// The config should be decrypted successfully.
+	readConfig, err := readConf()
+	require.NoError(t, err)
+	verifyEncryptionStatus(t, originalConfig, readConfig, false)
+
+	// A home dir backup should be created, and the rotation backups should be removed.
+	backupDir, err := coreutils.GetJfrogBackupDir()
+	require.NoError(t, err)
+	backups, err := os.ReadDir(backupDir)
+	require.NoError(t, err)
+	assert.NotEmpty(t, backups)
+	secFilePath, err := coreutils.GetJfrogSecurityConfFilePath()
+	require.NoError(t, err)
+	assert.NoFileExists(t, secFilePath+rotateBackupSuffix)
+}
+
+func TestRotateMasterKeyRollback(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, true)
+	defer cleanUpTempEnv()
+	require.NoError(t, saveConfig(createEncryptionTestConfig()))
+	secFilePath, err := coreutils.GetJfrogSecurityConfFilePath()
+	require.NoError(t, err)
+	confFilePath, err := getConfFilePath()
+	require.NoError(t, err)
+	originalSecContent, err := os.ReadFile(secFilePath)
+	require.NoError(t, err)
+	originalConfContent, err := os.ReadFile(confFilePath)
+	require.NoError(t, err)
+
+	// Fail saving the config after the new master key was written, by configuring an unsupported secret store.
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.SecretStore, "unsupported-store")()
+	assert.Error(t, RotateMasterKey())
+
+	// Both files should be restored.
+	secContent, err := os.ReadFile(secFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, originalSecContent, secContent)
+	confContent, err := os.ReadFile(confFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, originalConfContent, confContent)
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(secFilePath), filepath.Base(secFilePath)+rotateBackupSuffix))
+}
+
+func TestRotateMasterKeyNotEncrypted(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	assert.ErrorContains(t, RotateMasterKey(), "the config is not encrypted")
+
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.EncryptionKey, "randomkeywithlengthofexactly32!!")()
+	assert.ErrorContains(t, RotateMasterKey(), coreutils.EncryptionKey)
+}
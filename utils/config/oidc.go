@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	accessservices "github.com/jfrog/jfrog-client-go/access/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	//#nosec G101 // False positive: This is not a hardcoded credential.
+	OidcTokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	//#nosec G101 jfrog-ignore
+	OidcSubjectTokenType = "urn:ietf:params:oauth:token-type:id_token"
+)
+
+// The parameters of an OIDC token exchange, kept on the server for re-exchanging its access token.
+// The OIDC token itself is short-lived, so it's acquired again from its source on every exchange.
+type OidcExchangeParams struct {
+	ProviderName   string `json:"providerName,omitempty"`
+	ProviderType   string `json:"providerType,omitempty"`
+	Audience       string `json:"audience,omitempty"`
+	ProjectKey     string `json:"projectKey,omitempty"`
+	ApplicationKey string `json:"applicationKey,omitempty"`
+}
+
+// Acquires the OIDC token of the provider type from the local environment, for re-exchanging OIDC access tokens.
+// The acquisition is implemented by the token package, which depends on this package, and registers it on init.
+var oidcTokenIdAcquirer func(providerType, audience string) (string, error)
+
+// Sets the function which acquires the OIDC token of the provider type from the local environment.
+func SetOidcTokenIdAcquirer(acquirer func(providerType, audience string) (string, error)) {
+	oidcTokenIdAcquirer = acquirer
+}
+
+// Exchanges a new OIDC token for an access token, using the OIDC exchange parameters of the server, and writes the access token to the config.
+// The OIDC token is acquired from the provider's environment, or from the JFROG_CLI_OIDC_EXCHANGE_TOKEN_ID environment variable.
+func reExchangeOidcTokenAndWriteToConfig(serverConfiguration *ServerDetails) (string, error) {
+	oidcParams := serverConfiguration.OidcExchangeParams
+	var tokenId string
+	if oidcTokenIdAcquirer != nil {
+		var err error
+		if tokenId, err = oidcTokenIdAcquirer(oidcParams.ProviderType, oidcParams.Audience); err != nil {
+			return "", err
+		}
+	}
+	if tokenId == "" {
+		tokenId = os.Getenv(coreutils.OidcExchangeTokenId)
+	}
+	if tokenId == "" {
+		return "", errorutils.CheckErrorf("the OIDC access token of server ID '%s' can't be re-exchanged, since no OIDC token is available in the environment of the %s provider", serverConfiguration.ServerId, oidcParams.ProviderName)
+	}
+	// Exchanging without credentials, to avoid triggering the token refresh recursively.
+	noCredsDetails := new(ServerDetails)
+	noCredsDetails.Url = serverConfiguration.Url
+	noCredsDetails.ClientCertPath = serverConfiguration.ClientCertPath
+	noCredsDetails.ClientCertKeyPath = serverConfiguration.ClientCertKeyPath
+	noCredsDetails.ClientCertKeyPassphrase = serverConfiguration.ClientCertKeyPassphrase
+	noCredsDetails.ServerId = serverConfiguration.ServerId
+	noCredsDetails.IsDefault = serverConfiguration.IsDefault
+	noCredsDetails.copyHttpClientSettings(serverConfiguration)
+	servicesManager, err := createAccessTokensServiceManager(noCredsDetails)
+	if err != nil {
+		return "", err
+	}
+	response, err := servicesManager.ExchangeOidcToken(accessservices.CreateOidcTokenParams{
+		GrantType:        OidcTokenExchangeGrantType,
+		SubjectTokenType: OidcSubjectTokenType,
+		OidcTokenID:      tokenId,
+		ProviderName:     oidcParams.ProviderName,
+		ProviderType:     oidcParams.ProviderType,
+		Audience:         oidcParams.Audience,
+		ProjectKey:       oidcParams.ProjectKey,
+		ApplicationKey:   oidcParams.ApplicationKey,
+	})
+	if err != nil {
+		return "", errorutils.CheckErrorf("Re-exchanging the OIDC token failed: %s", err.Error())
+	}
+	log.Debug("The OIDC token was re-exchanged successfully.")
+	err = writeNewTokens(serverConfiguration, serverConfiguration.ServerId, response.AccessToken, "", AccessToken)
+	return response.AccessToken, err
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOidcTokenReExchange(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	now := time.Now().Unix()
+	expiringToken := buildTestAccessToken(t, now-60*60, now+60)
+	newAccessToken := buildTestAccessToken(t, now, now+60*60)
+	var exchangedTokenIds []string
+	accessServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/access/api/v1/oidc/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var request map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "github-oidc", request["provider_name"])
+		exchangedTokenIds = append(exchangedTokenIds, request["subject_token"])
+		content, err := json.Marshal(auth.OidcTokenResponseData{CreateTokenResponseData: auth.CreateTokenResponseData{CommonTokenParams: auth.CommonTokenParams{AccessToken: newAccessToken}}})
+		assert.NoError(t, err)
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	}))
+	defer accessServer.Close()
+	// A stand-in for the acquisition of the token package.
+	idTokenAvailable := true
+	SetOidcTokenIdAcquirer(func(providerType, audience string) (string, error) {
+		assert.Equal(t, "GitHub", providerType)
+		if !idTokenAvailable {
+			return "", nil
+		}
+		return "github-token-for-" + audience, nil
+	})
+	defer SetOidcTokenIdAcquirer(nil)
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.CredentialBrokerDisabled, "true")()
+
+	serverDetails := &ServerDetails{ServerId: "oidc", Url: accessServer.URL + "/", AccessToken: expiringToken, IsDefault: true,
+		OidcExchangeParams: &OidcExchangeParams{ProviderName: "github-oidc", ProviderType: "GitHub", Audience: "jfrog-github"}}
+	require.NoError(t, SaveServersConf([]*ServerDetails{serverDetails}))
+
+	// The interceptor re-exchanges the expiring access token, with a new ID token from the provider.
+	accessDetails, err := serverDetails.CreateAccessAuthConfig()
+	require.NoError(t, err)
+	httpClientDetails := httputils.HttpClientDetails{AccessToken: expiringToken}
+	require.NoError(t, accessDetails.RunPreRequestFunctions(&httpClientDetails))
+	assert.Equal(t, newAccessToken, httpClientDetails.AccessToken)
+	assert.Equal(t, []string{"github-token-for-jfrog-github"}, exchangedTokenIds)
+
+	savedServerDetails, err := GetSpecificConfig("oidc", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, newAccessToken, savedServerDetails.AccessToken)
+	assert.Equal(t, serverDetails.OidcExchangeParams, savedServerDetails.OidcExchangeParams)
+
+	// Without an ID token source, the token can't be re-exchanged.
+	idTokenAvailable = false
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.OidcExchangeTokenId, "")()
+	serverDetails.AccessToken = savedServerDetails.AccessToken
+	_, err = reExchangeOidcTokenAndWriteToConfig(serverDetails)
+	assert.ErrorContains(t, err, "no OIDC token is available")
+}
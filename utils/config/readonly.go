@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// ErrConfigReadOnly is returned when attempting to write the config file while the config is read-only.
+var ErrConfigReadOnly = errors.New("the config is read-only, since the " + coreutils.ConfigReadOnly + " environment variable is set")
+
+// The tokens refreshed by the current process, which were not written to the config file.
+type inMemoryTokens struct {
+	accessToken             string
+	refreshToken            string
+	artifactoryRefreshToken string
+}
+
+var (
+	inMemoryTokensByServerId = make(map[string]*inMemoryTokens)
+	inMemoryTokensMutex      sync.Mutex
+)
+
+// Returns true if the config file should not be written, as set by the JFROG_CLI_CONFIG_READ_ONLY environment variable.
+func IsConfigReadOnly() bool {
+	readOnly, err := strconv.ParseBool(os.Getenv(coreutils.ConfigReadOnly))
+	return err == nil && readOnly
+}
+
+// Keeps the refreshed tokens of the server for the rest of the process, instead of writing them to the config file.
+func keepTokensInMemory(serverId, accessToken, refreshToken, artifactoryRefreshToken string) {
+	inMemoryTokensMutex.Lock()
+	defer inMemoryTokensMutex.Unlock()
+	inMemoryTokensByServerId[serverId] = &inMemoryTokens{
+		accessToken:             accessToken,
+		refreshToken:            refreshToken,
+		artifactoryRefreshToken: artifactoryRefreshToken,
+	}
+}
+
+// Replaces the server's tokens with the tokens refreshed by the current process, if any.
+func applyInMemoryTokens(details *ServerDetails) {
+	inMemoryTokensMutex.Lock()
+	defer inMemoryTokensMutex.Unlock()
+	tokens, exists := inMemoryTokensByServerId[details.ServerId]
+	if !exists {
+		return
+	}
+	log.Debug("Using the tokens refreshed in memory for server ID '" + details.ServerId + "'.")
+	details.AccessToken = tokens.accessToken
+	details.RefreshToken = tokens.refreshToken
+	details.ArtifactoryRefreshToken = tokens.artifactoryRefreshToken
+}
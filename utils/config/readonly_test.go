@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigReadOnly(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	defer func() {
+		inMemoryTokensByServerId = make(map[string]*inMemoryTokens)
+	}()
+	require.NoError(t, SaveServersConf([]*ServerDetails{{ServerId: "server", Url: "http://server/", AccessToken: "token", RefreshToken: "refresh", IsDefault: true}}))
+	confFilePath, err := getConfFilePath()
+	require.NoError(t, err)
+	originalContent, err := os.ReadFile(confFilePath)
+	require.NoError(t, err)
+
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.ConfigReadOnly, "true")()
+	assert.ErrorIs(t, SaveServersConf([]*ServerDetails{{ServerId: "other", Url: "http://other/"}}), ErrConfigReadOnly)
+	assert.ErrorIs(t, RotateMasterKey(), ErrConfigReadOnly)
+
+	// Refreshed tokens should be kept in memory only.
+	details, err := GetSpecificConfig("server", false, false)
+	require.NoError(t, err)
+	require.NoError(t, writeNewTokens(details, "server", "new-token", "new-refresh", AccessToken))
+	details, err = GetSpecificConfig("server", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", details.AccessToken)
+	assert.Equal(t, "new-refresh", details.RefreshToken)
+	details, err = GetDefaultServerConf()
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", details.AccessToken)
+
+	content, err := os.ReadFile(confFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, originalContent, content)
+}
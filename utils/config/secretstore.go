@@ -0,0 +1,416 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+const (
+	// Secrets kept in a secret store are saved in the config file as references, starting with this prefix.
+	//#nosec G101 -- False positive - no hardcoded credentials.
+	secretReferencePrefix = "jfrog-secret-ref:"
+	// The service name, under which the secrets are kept in the secret store.
+	secretStoreService = "jfrog-cli"
+
+	SecretServiceStoreType = "secret-service"
+	PassStoreType          = "pass"
+	CredentialHelperType   = "helper"
+	FileSecretStoreType    = "file"
+	// Disables the secret store, keeping the secrets in the config file, even if the config was saved with a secret store.
+	NoSecretStoreType = "none"
+)
+
+// The file secret store keeps the secrets as plain text, so it's allowed in tests only.
+var fileSecretStoreAllowed = false
+
+// SecretStore is an external store, in which the secrets of the config file are kept.
+// When a secret store is used, the config file contains only references to the secrets,
+// which are resolved when the server configuration is loaded.
+type SecretStore interface {
+	// Returns the secret stored under the provided key.
+	Get(key string) (string, error)
+	// Stores the secret under the provided key, replacing the existing secret if exists.
+	Store(key, secret string) error
+	// Deletes the secret stored under the provided key. Deleting a missing secret should not fail.
+	Delete(key string) error
+}
+
+// Creates the secret store described by the provided spec. The supported specs are:
+// secret-service - The freedesktop Secret Service (GNOME Keyring, KWallet), accessed over D-Bus using the 'secret-tool' executable.
+// pass - The standard Unix password manager, which encrypts the secrets with GPG.
+// helper:<executable> - An external credential helper executable, implementing the docker credential helpers protocol.
+// file:<path> - A plain text JSON file. Allowed in tests only.
+func NewSecretStore(spec string) (SecretStore, error) {
+	storeType, arg, _ := strings.Cut(spec, ":")
+	switch storeType {
+	case SecretServiceStoreType:
+		return &SecretServiceStore{}, nil
+	case PassStoreType:
+		return &PassStore{}, nil
+	case CredentialHelperType:
+		if arg == "" {
+			return nil, errorutils.CheckErrorf("the credential helper executable must be provided in the form of '%s:<executable>'", CredentialHelperType)
+		}
+		return &CredentialHelperStore{Executable: arg}, nil
+	case FileSecretStoreType:
+		if !fileSecretStoreAllowed {
+			return nil, errorutils.CheckErrorf("the '%s' secret store keeps the secrets as plain text, and is allowed in tests only", FileSecretStoreType)
+		}
+		if arg == "" {
+			return nil, errorutils.CheckErrorf("the secrets file path must be provided in the form of '%s:<path>'", FileSecretStoreType)
+		}
+		return &FileSecretStore{Path: arg}, nil
+	}
+	return nil, errorutils.CheckErrorf("unsupported secret store '%s'. Supported secret stores: %s, %s, %s:<executable>, %s",
+		spec, SecretServiceStoreType, PassStoreType, CredentialHelperType, NoSecretStoreType)
+}
+
+// Returns the secret store spec configured by the JFROG_CLI_SECRET_STORE environment variable.
+// If the variable is not set, the secret store the config was saved with is kept.
+// If the variable is set to 'none', an empty spec is returned, and the secrets are saved in the config file.
+func getSecretStoreSpec(config *Config) string {
+	switch spec := os.Getenv(coreutils.SecretStore); spec {
+	case NoSecretStoreType:
+		return ""
+	case "":
+		return config.SecretStore
+	default:
+		return spec
+	}
+}
+
+// Returns true if the value is a reference to a secret kept in the secret store.
+// The servers returned by GetAllServersConfigs keep such references, until their secrets are resolved by ResolveServerSecrets.
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, secretReferencePrefix)
+}
+
+func hasSecretReferences(details *ServerDetails) bool {
+	for _, field := range getSecretFields(details) {
+		if IsSecretReference(*field.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func getSecretKey(serverId, field string) string {
+	return serverId + "/" + field
+}
+
+type secretField struct {
+	name  string
+	value *string
+}
+
+func getSecretFields(details *ServerDetails) []secretField {
+	fields := []secretField{
+		{"password", &details.Password},
+		{"accessToken", &details.AccessToken},
+		{"refreshToken", &details.RefreshToken},
+		{"artifactoryRefreshToken", &details.ArtifactoryRefreshToken},
+		{"sshPassphrase", &details.SshPassphrase},
+		{"clientCertKeyPassphrase", &details.ClientCertKeyPassphrase},
+	}
+	for service, serviceCredentials := range details.ServiceCredentials {
+		if serviceCredentials == nil {
+			continue
+		}
+		fields = append(fields,
+			secretField{"serviceCredentials/" + service + "/password", &serviceCredentials.Password},
+			secretField{"serviceCredentials/" + service + "/accessToken", &serviceCredentials.AccessToken})
+	}
+	return fields
+}
+
+// Moves the secrets of all servers into the secret store, replacing them with references.
+// Secrets which are already references are kept as is.
+func storeSecrets(config *Config, spec string) error {
+	if spec == "" {
+		return nil
+	}
+	store, err := NewSecretStore(spec)
+	if err != nil {
+		return err
+	}
+	config.SecretStore = spec
+	for _, serverDetails := range config.Servers {
+		for _, field := range getSecretFields(serverDetails) {
+			if *field.value == "" || IsSecretReference(*field.value) {
+				continue
+			}
+			key := getSecretKey(serverDetails.ServerId, field.name)
+			if err = store.Store(key, *field.value); err != nil {
+				return err
+			}
+			*field.value = secretReferencePrefix + key
+		}
+	}
+	return nil
+}
+
+// Moves the secrets of all servers from the secret store the config was saved with back into the config,
+// so they can be saved in the config file or in another secret store.
+func restoreSecrets(config *Config) error {
+	for _, serverDetails := range config.Servers {
+		if err := resolveSecrets(serverDetails, config.SecretStore); err != nil {
+			return err
+		}
+	}
+	config.SecretStore = ""
+	return nil
+}
+
+// Deletes the secrets of the previous servers from the secret store, if they are no longer used by the new servers.
+func deleteUnusedSecrets(previousServers, newServers []*ServerDetails, spec string) error {
+	if spec == "" {
+		return nil
+	}
+	usedKeys := make(map[string]bool)
+	for _, serverDetails := range newServers {
+		for _, field := range getSecretFields(serverDetails) {
+			if IsSecretReference(*field.value) {
+				usedKeys[strings.TrimPrefix(*field.value, secretReferencePrefix)] = true
+			} else if *field.value != "" {
+				usedKeys[getSecretKey(serverDetails.ServerId, field.name)] = true
+			}
+		}
+	}
+	var store SecretStore
+	var err error
+	for _, serverDetails := range previousServers {
+		for _, field := range getSecretFields(serverDetails) {
+			key := strings.TrimPrefix(*field.value, secretReferencePrefix)
+			if !IsSecretReference(*field.value) || usedKeys[key] {
+				continue
+			}
+			if store == nil {
+				if store, err = NewSecretStore(spec); err != nil {
+					return err
+				}
+			}
+			if err = store.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Replaces the secret references of the provided server with the secrets from the secret store of the config.
+// The servers returned by GetAllServersConfigs keep their secrets as references, so their secrets must be resolved before they are used.
+func ResolveServerSecrets(details *ServerDetails) error {
+	if !hasSecretReferences(details) {
+		return nil
+	}
+	conf, err := readConf()
+	if err != nil {
+		return err
+	}
+	return resolveSecrets(details, conf.SecretStore)
+}
+
+// Replaces the secret references of the provided server with the secrets from the secret store.
+func resolveSecrets(details *ServerDetails, spec string) error {
+	var store SecretStore
+	var err error
+	for _, field := range getSecretFields(details) {
+		if !IsSecretReference(*field.value) {
+			continue
+		}
+		if store == nil {
+			if spec == "" {
+				return errorutils.CheckErrorf("the config of server ID '%s' references secrets, but no secret store is configured", details.ServerId)
+			}
+			if store, err = NewSecretStore(spec); err != nil {
+				return err
+			}
+		}
+		if *field.value, err = store.Get(strings.TrimPrefix(*field.value, secretReferencePrefix)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SecretServiceStore keeps the secrets in the freedesktop Secret Service, using the 'secret-tool' executable of libsecret.
+type SecretServiceStore struct{}
+
+func (s *SecretServiceStore) Get(key string) (string, error) {
+	output, err := runSecretStoreExecutable("", "secret-tool", "lookup", "service", secretStoreService, "key", key)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(output, "\n"), nil
+}
+
+func (s *SecretServiceStore) Store(key, secret string) error {
+	_, err := runSecretStoreExecutable(secret, "secret-tool", "store", "--label", secretStoreService+" "+key, "service", secretStoreService, "key", key)
+	return err
+}
+
+func (s *SecretServiceStore) Delete(key string) error {
+	_, err := runSecretStoreExecutable("", "secret-tool", "clear", "service", secretStoreService, "key", key)
+	return err
+}
+
+// PassStore keeps the secrets in the 'pass' password store, under the jfrog-cli directory.
+type PassStore struct{}
+
+func (p *PassStore) Get(key string) (string, error) {
+	output, err := runSecretStoreExecutable("", "pass", "show", p.getPassName(key))
+	if err != nil {
+		return "", err
+	}
+	// 'pass' stores the password in the first line.
+	secret, _, _ := strings.Cut(output, "\n")
+	return secret, nil
+}
+
+func (p *PassStore) Store(key, secret string) error {
+	_, err := runSecretStoreExecutable(secret+"\n", "pass", "insert", "--multiline", "--force", p.getPassName(key))
+	return err
+}
+
+func (p *PassStore) Delete(key string) error {
+	_, err := runSecretStoreExecutable("", "pass", "rm", "--force", p.getPassName(key))
+	return err
+}
+
+func (p *PassStore) getPassName(key string) string {
+	return secretStoreService + "/" + key
+}
+
+// CredentialHelperStore keeps the secrets using an external credential helper executable.
+// The helper must implement the docker credential helpers protocol:
+// 'store' reads a JSON {"ServerURL", "Username", "Secret"} from stdin.
+// 'get' reads the key from stdin and writes a JSON {"ServerURL", "Username", "Secret"} to stdout.
+// 'erase' reads the key from stdin.
+type CredentialHelperStore struct {
+	Executable string
+}
+
+type credentialHelperPayload struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+func (ch *CredentialHelperStore) Get(key string) (string, error) {
+	output, err := runSecretStoreExecutable(key, ch.Executable, "get")
+	if err != nil {
+		return "", err
+	}
+	payload := new(credentialHelperPayload)
+	if err = json.Unmarshal([]byte(output), payload); err != nil {
+		return "", errorutils.CheckErrorf("failed parsing the output of the credential helper '%s': %s", ch.Executable, err.Error())
+	}
+	return payload.Secret, nil
+}
+
+func (ch *CredentialHelperStore) Store(key, secret string) error {
+	content, err := json.Marshal(credentialHelperPayload{ServerURL: key, Username: secretStoreService, Secret: secret})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = runSecretStoreExecutable(string(content), ch.Executable, "store")
+	return err
+}
+
+func (ch *CredentialHelperStore) Delete(key string) error {
+	_, err := runSecretStoreExecutable(key, ch.Executable, "erase")
+	return err
+}
+
+// Runs the executable of a secret store, writing the provided input to its stdin. Returns the stdout of the executable.
+// The secrets are passed only through stdin and stdout, to avoid exposing them in the process arguments.
+func runSecretStoreExecutable(input, executable string, args ...string) (string, error) {
+	cmd := exec.Command(executable, args...)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errorutils.CheckErrorf("secret store command '%s %s' failed: %s %s", executable, args[0], err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// FileSecretStore keeps the secrets as plain text in a JSON file.
+// It doesn't protect the secrets, and is intended to be used in tests only.
+type FileSecretStore struct {
+	Path  string
+	mutex sync.Mutex
+}
+
+func (f *FileSecretStore) Get(key string) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	secrets, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	secret, exists := secrets[key]
+	if !exists {
+		return "", errorutils.CheckErrorf("secret '%s' was not found in %s", key, f.Path)
+	}
+	return secret, nil
+}
+
+func (f *FileSecretStore) Store(key, secret string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	secrets, err := f.read()
+	if err != nil {
+		return err
+	}
+	secrets[key] = secret
+	return f.write(secrets)
+}
+
+func (f *FileSecretStore) Delete(key string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	secrets, err := f.read()
+	if err != nil {
+		return err
+	}
+	delete(secrets, key)
+	return f.write(secrets)
+}
+
+func (f *FileSecretStore) read() (map[string]string, error) {
+	secrets := make(map[string]string)
+	exists, err := fileutils.IsFileExists(f.Path, false)
+	if err != nil || !exists {
+		return secrets, err
+	}
+	content, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(content) == 0 {
+		return secrets, nil
+	}
+	return secrets, errorutils.CheckError(json.Unmarshal(content, &secrets))
+}
+
+func (f *FileSecretStore) write(secrets map[string]string) error {
+	content, err := json.Marshal(secrets)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = os.MkdirAll(filepath.Dir(f.Path), 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(f.Path, content, 0600))
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Allows the plain text file secret store, until the test ends.
+func allowFileSecretStore(t *testing.T) {
+	fileSecretStoreAllowed = true
+	t.Cleanup(func() {
+		fileSecretStoreAllowed = false
+	})
+}
+
+func TestSecretStoreReferences(t *testing.T) {
+	allowFileSecretStore(t)
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+	unsetEnv := testsutils.SetEnvWithCallbackAndAssert(t, coreutils.SecretStore, FileSecretStoreType+":"+secretsFile)
+
+	require.NoError(t, SaveServersConf([]*ServerDetails{
+		{ServerId: "server1", Url: "http://server1/", User: "admin", Password: "password1", IsDefault: true},
+		{ServerId: "server2", Url: "http://server2/", AccessToken: "token2", RefreshToken: "refresh2"},
+	}))
+
+	// The config file should contain references only.
+	confFilePath, err := getConfFilePath()
+	require.NoError(t, err)
+	content, err := os.ReadFile(confFilePath)
+	require.NoError(t, err)
+	for _, secret := range []string{"password1", "token2", "refresh2"} {
+		assert.NotContains(t, string(content), secret)
+	}
+	assert.Contains(t, string(content), secretReferencePrefix+"server1/password")
+
+	// The secret store the config was saved with should be used, even if the environment variable is unset.
+	unsetEnv()
+	details, err := GetSpecificConfig("server2", false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "token2", details.AccessToken)
+	assert.Equal(t, "refresh2", details.RefreshToken)
+	details, err = GetSpecificConfig("", true, false)
+	require.NoError(t, err)
+	assert.Equal(t, "password1", details.Password)
+
+	// The listed servers should keep their secrets as references, until resolved.
+	configs, err := GetAllServersConfigs()
+	require.NoError(t, err)
+	assert.Equal(t, secretReferencePrefix+"server2/accessToken", configs[1].AccessToken)
+	assert.True(t, IsSecretReference(configs[0].Password))
+	require.NoError(t, ResolveServerSecrets(configs[1]))
+	assert.Equal(t, "token2", configs[1].AccessToken)
+	assert.Equal(t, "refresh2", configs[1].RefreshToken)
+
+	// Saving the servers as read should store references, and removing a server should delete its secrets.
+	require.NoError(t, SaveServersConf(configs[:1]))
+	content, err = os.ReadFile(confFilePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "password1")
+	assert.Contains(t, string(content), secretReferencePrefix+"server1/password")
+	store := &FileSecretStore{Path: secretsFile}
+	_, err = store.Get("server2/accessToken")
+	assert.Error(t, err)
+	secret, err := store.Get("server1/password")
+	assert.NoError(t, err)
+	assert.Equal(t, "password1", secret)
+}
+
+func TestSecretStoreOptOut(t *testing.T) {
+	allowFileSecretStore(t)
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+	unsetEnv := testsutils.SetEnvWithCallbackAndAssert(t, coreutils.SecretStore, FileSecretStoreType+":"+secretsFile)
+	require.NoError(t, SaveServersConf([]*ServerDetails{{ServerId: "server", Url: "http://server/", AccessToken: "token", IsDefault: true}}))
+	unsetEnv()
+
+	// Disabling the secret store should move the secrets back into the config file.
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.SecretStore, NoSecretStoreType)()
+	configs, err := GetAllServersConfigs()
+	require.NoError(t, err)
+	require.NoError(t, SaveServersConf(configs))
+	conf, err := readConf()
+	require.NoError(t, err)
+	assert.Empty(t, conf.SecretStore)
+	assert.Equal(t, "token", conf.Servers[0].AccessToken)
+}
+
+func TestResolveSecretsWithoutStore(t *testing.T) {
+	details := &ServerDetails{ServerId: "server", AccessToken: secretReferencePrefix + "server/accessToken"}
+	assert.ErrorContains(t, resolveSecrets(details, ""), "no secret store is configured")
+}
+
+func TestNewSecretStore(t *testing.T) {
+	testCases := []struct {
+		spec          string
+		expectedStore SecretStore
+	}{
+		{SecretServiceStoreType, &SecretServiceStore{}},
+		{PassStoreType, &PassStore{}},
+		{"helper:docker-credential-pass", &CredentialHelperStore{Executable: "docker-credential-pass"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.spec, func(t *testing.T) {
+			store, err := NewSecretStore(testCase.spec)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedStore, store)
+		})
+	}
+	for _, spec := range []string{"", "keychain", "helper", "none", "file:/tmp/secrets.json"} {
+		_, err := NewSecretStore(spec)
+		assert.Error(t, err, spec)
+	}
+
+	// The file secret store is allowed in tests only.
+	allowFileSecretStore(t)
+	store, err := NewSecretStore("file:/tmp/secrets.json")
+	assert.NoError(t, err)
+	assert.Equal(t, &FileSecretStore{Path: "/tmp/secrets.json"}, store)
+	_, err = NewSecretStore("file:")
+	assert.Error(t, err)
+}
+
+func TestCredentialHelperStore(t *testing.T) {
+	if coreutils.IsWindows() {
+		t.Skip("The credential helper used by this test is a shell script.")
+	}
+	helperDir := t.TempDir()
+	helperPath := filepath.Join(helperDir, "credential-helper")
+	script := `#!/bin/sh
+case "$1" in
+  store) cat > "$(dirname "$0")/stored.json" ;;
+  get) cat "$(dirname "$0")/stored.json" ;;
+  erase) rm -f "$(dirname "$0")/stored.json" ;;
+esac
+`
+	require.NoError(t, os.WriteFile(helperPath, []byte(script), 0700))
+
+	store := &CredentialHelperStore{Executable: helperPath}
+	require.NoError(t, store.Store("server/accessToken", "token"))
+	secret, err := store.Get("server/accessToken")
+	assert.NoError(t, err)
+	assert.Equal(t, "token", secret)
+	assert.NoError(t, store.Delete("server/accessToken"))
+	assert.NoFileExists(t, filepath.Join(helperDir, "stored.json"))
+}
@@ -19,12 +19,6 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
-// Internal golang locking for the same process.
-var mutex sync.Mutex
-
-// The serverId used for authentication. Use for reading and writing tokens from/to the config file, and for reading the credentials if needed.
-var tokenRefreshServerId string
-
 const (
 	ArtifactoryToken TokenType = "artifactory"
 	AccessToken      TokenType = "access"
@@ -32,16 +26,53 @@ const (
 
 type TokenType string
 
+// The token refresh state of a server in the current process.
+// The state is shared by the interceptors of all the service managers created for the server,
+// so that concurrent requests to the same server refresh its token once, while requests to other servers aren't blocked.
+type tokenRefreshState struct {
+	// Locked while refreshing the tokens of the server.
+	mutex sync.Mutex
+}
+
+var (
+	// The token refresh states, keyed by server ID. An empty server ID stands for the default server.
+	tokenRefreshStates      = make(map[string]*tokenRefreshState)
+	tokenRefreshStatesMutex sync.Mutex
+)
+
+func getTokenRefreshState(serverId string) *tokenRefreshState {
+	tokenRefreshStatesMutex.Lock()
+	defer tokenRefreshStatesMutex.Unlock()
+	state, exists := tokenRefreshStates[serverId]
+	if !exists {
+		state = new(tokenRefreshState)
+		tokenRefreshStates[serverId] = state
+	}
+	return state
+}
+
+// Returns an interceptor, which refreshes the access token of the provided server before it expires.
+// The interceptor reads and writes the tokens of the server by its ID. If the ID is empty, the default server is used.
+func NewTokenRefreshPreRequestInterceptor(serverDetails *ServerDetails, tokenType TokenType) auth.ServiceDetailsPreRequestFunc {
+	// Keep the fields identifying the server, since the server details may be modified after the interceptor is created.
+	server := &ServerDetails{ServerId: serverDetails.ServerId, Url: serverDetails.Url, ArtifactoryUrl: serverDetails.ArtifactoryUrl}
+	return func(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails) error {
+		return tokenRefreshPreRequestInterceptor(server, fields, httpClientDetails, tokenType)
+	}
+}
+
+// Deprecated: Refreshes the token of the default server. Use NewTokenRefreshPreRequestInterceptor to refresh the token of a specific server.
 func AccessTokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails) (err error) {
-	return tokenRefreshPreRequestInterceptor(fields, httpClientDetails, AccessToken)
+	return tokenRefreshPreRequestInterceptor(new(ServerDetails), fields, httpClientDetails, AccessToken)
 }
 
+// Deprecated: Refreshes the token of the default server. Use NewTokenRefreshPreRequestInterceptor to refresh the token of a specific server.
 func ArtifactoryTokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails) (err error) {
-	return tokenRefreshPreRequestInterceptor(fields, httpClientDetails, ArtifactoryToken)
+	return tokenRefreshPreRequestInterceptor(new(ServerDetails), fields, httpClientDetails, ArtifactoryToken)
 }
 
-func tokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails, tokenType TokenType) (err error) {
-	if fields.GetAccessToken() == "" || httpClientDetails.AccessToken == "" {
+func tokenRefreshPreRequestInterceptor(server *ServerDetails, fields *auth.CommonConfigFields, httpClientDetails *httputils.HttpClientDetails, tokenType TokenType) (err error) {
+	if httpClientDetails.AccessToken == "" {
 		return nil
 	}
 
@@ -54,14 +85,23 @@ func tokenRefreshPreRequestInterceptor(fields *auth.CommonConfigFields, httpClie
 	if err != nil || timeLeft > refreshBeforeExpiryMinutes {
 		return err
 	}
-	// Lock to make sure only one thread is trying to refresh
-	mutex.Lock()
-	defer mutex.Unlock()
+	// Lock to make sure only one thread is trying to refresh the server's token
+	state := getTokenRefreshState(server.ServerId)
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	// The shared fields are accessed while locked only, since they're updated by concurrent requests.
+	if fields.AccessToken == "" {
+		return nil
+	}
 	// Refresh only if a new token wasn't acquired (by another thread) while waiting at mutex.
 	if fields.AccessToken == httpClientDetails.AccessToken {
-		newAccessToken, err := tokenRefreshHandler(httpClientDetails.AccessToken, tokenType)
-		if err != nil {
-			return err
+		// Prefer the token owned by the credential broker, if running, over refreshing the token by the current process.
+		newAccessToken := getAccessTokenFromCredentialBroker(server, httpClientDetails.AccessToken, tokenType)
+		if newAccessToken == "" {
+			newAccessToken, err = tokenRefreshHandler(server.ServerId, httpClientDetails.AccessToken, tokenType)
+			if err != nil {
+				return err
+			}
 		}
 		if newAccessToken != "" && newAccessToken != httpClientDetails.AccessToken {
 			fields.AccessToken = newAccessToken
@@ -83,7 +123,13 @@ func refreshThresholdForTokenType(token string, tokenType TokenType) (int64, err
 	return auth.GetPlatformTokenRefreshThreshold(token)
 }
 
-func tokenRefreshHandler(currentAccessToken string, tokenType TokenType) (newAccessToken string, err error) {
+func tokenRefreshHandler(serverId, currentAccessToken string, tokenType TokenType) (newAccessToken string, err error) {
+	return refreshTokenAndWriteToConfig(serverId, currentAccessToken, tokenType, true)
+}
+
+// Refreshes the server's token and writes the new tokens to the config, while holding the config lock.
+// If waitBeforeRefresh is false, the caller is expected to call waitBeforeTokenRefresh before, without holding the config lock.
+func refreshTokenAndWriteToConfig(serverId, currentAccessToken string, tokenType TokenType, waitBeforeRefresh bool) (newAccessToken string, err error) {
 	log.Debug("Refreshing token...")
 	// Lock config to prevent access from different processes
 	lockDirPath, err := coreutils.GetJfrogConfigLockDir()
@@ -99,13 +145,10 @@ func tokenRefreshHandler(currentAccessToken string, tokenType TokenType) (newAcc
 		return
 	}
 
-	serverConfiguration, err := GetSpecificConfig(tokenRefreshServerId, true, false)
+	serverConfiguration, err := GetSpecificConfig(serverId, true, false)
 	if err != nil {
 		return
 	}
-	if tokenRefreshServerId == "" && serverConfiguration != nil {
-		tokenRefreshServerId = serverConfiguration.ServerId
-	}
 	// If token already refreshed, get new token from config
 	if serverConfiguration.AccessToken != "" && serverConfiguration.AccessToken != currentAccessToken {
 		log.Debug("Fetched new token from config.")
@@ -113,13 +156,16 @@ func tokenRefreshHandler(currentAccessToken string, tokenType TokenType) (newAcc
 		return
 	}
 
-	// If token isn't already expired, Wait to make sure requests using the current token are sent before it is refreshed and becomes invalid
-	timeLeft, err := auth.GetTokenMinutesLeft(currentAccessToken)
-	if err != nil {
+	// The exchanged access token isn't invalidated by a new exchange, so there's no need to wait before re-exchanging it.
+	if tokenType == AccessToken && serverConfiguration.RefreshToken == "" && serverConfiguration.OidcExchangeParams != nil {
+		newAccessToken, err = reExchangeOidcTokenAndWriteToConfig(serverConfiguration)
 		return
 	}
-	if timeLeft > 0 {
-		time.Sleep(auth.WaitBeforeRefreshSeconds * time.Second)
+
+	if waitBeforeRefresh {
+		if err = waitBeforeTokenRefresh(currentAccessToken); err != nil {
+			return
+		}
 	}
 
 	if tokenType == ArtifactoryToken {
@@ -134,6 +180,18 @@ func tokenRefreshHandler(currentAccessToken string, tokenType TokenType) (newAcc
 	return
 }
 
+// If token isn't already expired, Wait to make sure requests using the current token are sent before it is refreshed and becomes invalid
+func waitBeforeTokenRefresh(currentAccessToken string) error {
+	timeLeft, err := auth.GetTokenMinutesLeft(currentAccessToken)
+	if err != nil {
+		return err
+	}
+	if timeLeft > 0 {
+		time.Sleep(auth.WaitBeforeRefreshSeconds * time.Second)
+	}
+	return nil
+}
+
 func refreshArtifactoryTokenAndWriteToConfig(serverConfiguration *ServerDetails, currentAccessToken string) (string, error) {
 	refreshToken := serverConfiguration.ArtifactoryRefreshToken
 	// Remove previous tokens
@@ -160,7 +218,7 @@ func refreshArtifactoryTokenAndWriteToConfig(serverConfiguration *ServerDetails,
 		log.Debug("Token refreshed successfully.")
 	}
 
-	err = writeNewTokens(serverConfiguration, tokenRefreshServerId, newToken.AccessToken, newToken.RefreshToken, ArtifactoryToken)
+	err = writeNewTokens(serverConfiguration, serverConfiguration.ServerId, newToken.AccessToken, newToken.RefreshToken, ArtifactoryToken)
 	return newToken.AccessToken, err
 }
 
@@ -170,7 +228,7 @@ func refreshAccessTokenAndWriteToConfig(serverConfiguration *ServerDetails, curr
 	if err != nil {
 		return "", errorutils.CheckErrorf("Refresh access token failed: %s", err.Error())
 	}
-	err = writeNewTokens(serverConfiguration, tokenRefreshServerId, newToken.AccessToken, newToken.RefreshToken, AccessToken)
+	err = writeNewTokens(serverConfiguration, serverConfiguration.ServerId, newToken.AccessToken, newToken.RefreshToken, AccessToken)
 	return newToken.AccessToken, err
 }
 
@@ -184,12 +242,22 @@ func writeNewTokens(serverConfiguration *ServerDetails, serverId, accessToken, r
 		serverConfiguration.SetRefreshToken(refreshToken)
 	}
 
+	if IsConfigReadOnly() {
+		log.Debug("The refreshed token is kept in memory only, since the config is read-only.")
+		keepTokensInMemory(serverId, serverConfiguration.AccessToken, serverConfiguration.RefreshToken, serverConfiguration.ArtifactoryRefreshToken)
+		return nil
+	}
+
 	// Get configurations list
 	configurations, err := GetAllServersConfigs()
 	if err != nil {
 		return err
 	}
 
+	if serverConfiguration.IsOverriddenByEnv() {
+		return writeNewTokensOfEnvOverriddenServer(serverConfiguration, serverId, configurations)
+	}
+
 	// Remove and get the server details from the configurations list
 	_, configurations = GetAndRemoveConfiguration(serverId, configurations)
 
@@ -198,6 +266,27 @@ func writeNewTokens(serverConfiguration *ServerDetails, serverId, accessToken, r
 	return SaveServersConf(configurations)
 }
 
+// Writes only the tokens of a server which was overridden by environment variables, since its other fields may not be from the config file.
+// If the server or its tokens are defined by environment variables, the new tokens are kept in memory for the current process only.
+func writeNewTokensOfEnvOverriddenServer(serverConfiguration *ServerDetails, serverId string, configurations []*ServerDetails) error {
+	if serverConfiguration.GetSourceEnvVar("accessToken") != "" || serverConfiguration.GetSourceEnvVar("refreshToken") != "" {
+		log.Debug("The refreshed token is kept in memory only, since the token is set by an environment variable.")
+		keepTokensInMemory(serverId, serverConfiguration.AccessToken, serverConfiguration.RefreshToken, serverConfiguration.ArtifactoryRefreshToken)
+		return nil
+	}
+	for _, details := range configurations {
+		if details.ServerId == serverId {
+			details.SetAccessToken(serverConfiguration.AccessToken)
+			details.SetArtifactoryRefreshToken(serverConfiguration.ArtifactoryRefreshToken)
+			details.SetRefreshToken(serverConfiguration.RefreshToken)
+			return SaveServersConf(configurations)
+		}
+	}
+	log.Debug("The refreshed token is kept in memory only, since the server is defined by environment variables.")
+	keepTokensInMemory(serverId, serverConfiguration.AccessToken, serverConfiguration.RefreshToken, serverConfiguration.ArtifactoryRefreshToken)
+	return nil
+}
+
 func createTokensForConfig(serverDetails *ServerDetails, expirySeconds int) (auth.CreateTokenResponseData, error) {
 	servicesManager, err := createArtifactoryTokensServiceManager(serverDetails)
 	if err != nil {
@@ -222,8 +311,9 @@ func CreateInitialRefreshableTokensIfNeeded(serverDetails *ServerDetails) (err e
 	if serverDetails.ArtifactoryTokenRefreshInterval <= 0 || serverDetails.ArtifactoryRefreshToken != "" || serverDetails.AccessToken != "" {
 		return nil
 	}
-	mutex.Lock()
-	defer mutex.Unlock()
+	state := getTokenRefreshState(serverDetails.ServerId)
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
 	lockDirPath, err := coreutils.GetJfrogConfigLockDir()
 	if err != nil {
 		return
@@ -253,8 +343,10 @@ func refreshArtifactoryExpiredToken(serverDetails *ServerDetails, currentAccessT
 	noCredsDetails.ArtifactoryUrl = serverDetails.ArtifactoryUrl
 	noCredsDetails.ClientCertPath = serverDetails.ClientCertPath
 	noCredsDetails.ClientCertKeyPath = serverDetails.ClientCertKeyPath
+	noCredsDetails.ClientCertKeyPassphrase = serverDetails.ClientCertKeyPassphrase
 	noCredsDetails.ServerId = serverDetails.ServerId
 	noCredsDetails.IsDefault = serverDetails.IsDefault
+	noCredsDetails.copyHttpClientSettings(serverDetails)
 
 	servicesManager, err := createArtifactoryTokensServiceManager(noCredsDetails)
 	if err != nil {
@@ -274,8 +366,10 @@ func refreshExpiredAccessToken(serverDetails *ServerDetails, currentAccessToken
 	noCredServerDetails.Url = serverDetails.Url
 	noCredServerDetails.ClientCertPath = serverDetails.ClientCertPath
 	noCredServerDetails.ClientCertKeyPath = serverDetails.ClientCertKeyPath
+	noCredServerDetails.ClientCertKeyPassphrase = serverDetails.ClientCertKeyPassphrase
 	noCredServerDetails.ServerId = serverDetails.ServerId
 	noCredServerDetails.IsDefault = serverDetails.IsDefault
+	noCredServerDetails.copyHttpClientSettings(serverDetails)
 
 	servicesManager, err := createAccessTokensServiceManager(noCredServerDetails)
 	if err != nil {
@@ -297,12 +391,15 @@ func createArtifactoryTokensServiceManager(artDetails *ServerDetails) (artifacto
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := config.NewConfigBuilder().
+	configBuilder := config.NewConfigBuilder().
 		SetServiceDetails(artAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(artDetails.InsecureTls).
-		SetDryRun(false).
-		Build()
+		SetDryRun(false)
+	if _, err = ApplyHttpSettings(configBuilder, artDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
@@ -318,12 +415,15 @@ func createAccessTokensServiceManager(serviceDetails *ServerDetails) (*access.Ac
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := config.NewConfigBuilder().
+	configBuilder := config.NewConfigBuilder().
 		SetServiceDetails(accessAuth).
 		SetCertificatesPath(certsPath).
 		SetInsecureTls(serviceDetails.InsecureTls).
-		SetDryRun(false).
-		Build()
+		SetDryRun(false)
+	if _, err = ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}
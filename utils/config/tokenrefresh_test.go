@@ -3,10 +3,16 @@ package config
 import (
 	"encoding/base64"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	configtests "github.com/jfrog/jfrog-cli-core/v2/utils/config/tests"
 	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -604,3 +610,77 @@ func TestCreateInitialRefreshableTokensIfNeededBranchCoverage(t *testing.T) {
 		assert.NotNil(t, serverDetails)
 	})
 }
+
+// Creates a server which responds to access token refresh requests with the provided token, and counts the requests.
+func createTokenRefreshTestServer(t *testing.T, newAccessToken string, requests *int32) *httptest.Server {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/access/api/v1/tokens" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(requests, 1)
+		content, err := json.Marshal(auth.CreateTokenResponseData{CommonTokenParams: auth.CommonTokenParams{AccessToken: newAccessToken, RefreshToken: "new-refresh-" + newAccessToken}})
+		assert.NoError(t, err)
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(testServer.Close)
+	return testServer
+}
+
+func TestConcurrentTokenRefreshOfMultipleServers(t *testing.T) {
+	cleanUpTempEnv := configtests.CreateTempEnv(t, false)
+	defer cleanUpTempEnv()
+	now := time.Now().Unix()
+	expiredToken := buildTestAccessToken(t, now-2*60*60, now-60*60)
+	serverIds := []string{"source", "target"}
+	newAccessTokens := make(map[string]string, len(serverIds))
+	requests := make(map[string]*int32, len(serverIds))
+	var servers []*ServerDetails
+	for i, serverId := range serverIds {
+		newAccessTokens[serverId] = buildTestAccessToken(t, now+int64(i), now+365*24*60*60)
+		requests[serverId] = new(int32)
+		testServer := createTokenRefreshTestServer(t, newAccessTokens[serverId], requests[serverId])
+		servers = append(servers, &ServerDetails{ServerId: serverId, Url: testServer.URL + "/", AccessToken: expiredToken, RefreshToken: "refresh-" + serverId, IsDefault: i == 0})
+	}
+	require.NoError(t, SaveServersConf(servers))
+
+	// Create the service details of both servers before sending any request, as done when transferring files between servers.
+	var serviceDetails []auth.ServiceDetails
+	for _, serverId := range serverIds {
+		serverDetails, err := GetSpecificConfig(serverId, false, false)
+		require.NoError(t, err)
+		// Two service managers of the same server share the token refresh state of the server.
+		for i := 0; i < 2; i++ {
+			accessDetails, err := serverDetails.CreateAccessAuthConfig()
+			require.NoError(t, err)
+			serviceDetails = append(serviceDetails, accessDetails)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, details := range serviceDetails {
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(details auth.ServiceDetails) {
+				defer wg.Done()
+				httpClientDetails := httputils.HttpClientDetails{AccessToken: expiredToken}
+				assert.NoError(t, details.RunPreRequestFunctions(&httpClientDetails))
+				serverId := serverIds[0]
+				if details.GetUrl() == servers[1].Url+"access/" {
+					serverId = serverIds[1]
+				}
+				assert.Equal(t, newAccessTokens[serverId], httpClientDetails.AccessToken)
+			}(details)
+		}
+	}
+	wg.Wait()
+
+	for _, serverId := range serverIds {
+		assert.Equal(t, int32(1), atomic.LoadInt32(requests[serverId]), serverId)
+		serverDetails, err := GetSpecificConfig(serverId, false, false)
+		require.NoError(t, err)
+		assert.Equal(t, newAccessTokens[serverId], serverDetails.AccessToken)
+		assert.Equal(t, "new-refresh-"+newAccessTokens[serverId], serverDetails.RefreshToken)
+	}
+}
@@ -12,7 +12,9 @@ const (
 	// Home Dir
 	JfrogBackupDirName                  = "backup"
 	JfrogCertsDirName                   = "certs"
+	JfrogConfigAuditLogFile             = "config-audit.log"
 	JfrogConfigFile                     = "jfrog-cli.conf"
+	JfrogCredentialBrokerSocketFile     = "credential-broker.sock"
 	JfrogDependenciesDirName            = "dependencies"
 	JfrogLocksDirName                   = "locks"
 	JfrogLogsDirName                    = "logs"
@@ -50,10 +52,33 @@ const (
 	CI                      = "CI"
 	ServerID                = "JFROG_CLI_SERVER_ID"
 	TransitiveDownload      = "JFROG_CLI_TRANSITIVE_DOWNLOAD"
+	// If true, the config file is never written. Refreshed tokens are kept in memory only.
+	ConfigReadOnly = "JFROG_CLI_CONFIG_READ_ONLY"
+	// If true, every change of the config file is recorded in the config audit log, under the JFrog home directory.
+	ConfigAuditLog = "JFROG_CLI_CONFIG_AUDIT_LOG"
+	// The prefix of the environment variables defining or overriding servers, in the form of JFROG_CLI_SERVER_<ID>_<FIELD>.
+	ServerEnvPrefix = "JFROG_CLI_SERVER_"
+	// If true, tokens are refreshed by the CLI process itself, even if the credential broker is running.
+	CredentialBrokerDisabled = "JFROG_CLI_CREDENTIAL_BROKER_DISABLED"
+	// The implementation of the file locks. If set to "flock", the locks are advisory file locks, instead of lock files named by the process ID.
+	// All the CLI processes sharing the JFrog home directory should use the same implementation.
+	LockType = "JFROG_CLI_LOCK_TYPE"
+	// The window before the expiry of the active server's access token or client certificate, in which commands warn about the expiry.
+	// Either a duration such as "72h", or a number of days such as "7d". Set to "0" to disable the warning.
+	CredentialExpiryWindow = "JFROG_CLI_CREDENTIAL_EXPIRY_WINDOW"
+	// If true, commands fail instead of warning, when a credential of the active server expires within the window. Useful as a CI gate.
+	CredentialExpiryFail = "JFROG_CLI_CREDENTIAL_EXPIRY_FAIL"
+	// The name of the config context to use, overriding the context selected by the .jfrog/context.yaml file.
+	ContextName = "JFROG_CLI_CONTEXT"
 	// Token provided by the OIDC provider, used to exchange for an access token.
 	//#nosec G101 // False positive: This is not a hardcoded credential.
 	OidcExchangeTokenId = "JFROG_CLI_OIDC_EXCHANGE_TOKEN_ID"
 	OidcProviderType    = "JFROG_CLI_OIDC_PROVIDER_TYPE"
+	// The path of the file holding the OIDC token of a Kubernetes provider, if it's not the default service account token file.
+	OidcTokenFile = "JFROG_CLI_OIDC_TOKEN_FILE"
+	// The OIDC token of a GitLab CI job. GitLab sets it if the job declares an ID token with this name under the 'id_tokens' keyword.
+	//#nosec G101 // False positive: This is not a hardcoded credential.
+	OidcGitLabIdToken = "JFROG_CLI_OIDC_GITLAB_ID_TOKEN"
 	// These environment variables are used to adjust command names for more detailed tracking in the usage report.
 	// Set by the setup-jfrog-cli GitHub Action to identify specific command usage scenarios.
 	// True if an automatic build publication was triggered.
@@ -76,6 +101,9 @@ var (
 	KeyAlias       = "JFROG_CLI_KEY_ALIAS"
 	//#nosec G101
 	EncryptionKey = "JFROG_CLI_ENCRYPTION_KEY"
+	// The external store in which the config secrets are kept. See config.NewSecretStore for the supported values.
+	// Set to 'none' to keep the secrets in the config file.
+	SecretStore = "JFROG_CLI_SECRET_STORE"
 	// For CI runs
 	CIJobID       = "JFROG_CLI_CI_JOB_ID"
 	CIRunID       = "JFROG_CLI_CI_RUN_ID"
@@ -156,7 +156,7 @@ func ConvertExitCodeError(err error) error {
 
 // GetCliConfigVersion returns the latest version of the config.yml file on the file system at '.jfrog'.
 func GetCliConfigVersion() int {
-	return 6
+	return 7
 }
 
 // GetPluginsConfigVersion returns the latest plugins layout version on the file system (at '.jfrog/plugins').
@@ -356,6 +356,24 @@ func GetJfrogSecurityConfFilePath() (string, error) {
 	return filepath.Join(securityDir, JfrogSecurityConfFile), nil
 }
 
+// Returns the path of the append-only log, recording the changes made to the config file.
+func GetJfrogConfigAuditLogFilePath() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogConfigAuditLogFile), nil
+}
+
+// Returns the path of the Unix socket, on which the credential broker of the current user listens.
+func GetJfrogCredentialBrokerSocketPath() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogCredentialBrokerSocketFile), nil
+}
+
 func GetJfrogBackupDir() (string, error) {
 	homeDir, err := GetJfrogHomeDir()
 	if err != nil {
@@ -0,0 +1,193 @@
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/osutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The value of the JFROG_CLI_LOCK_TYPE environment variable, which selects the advisory file locks.
+const FlockLockType = "flock"
+
+const (
+	// The name of the locked file in the lock directory.
+	flockFileName = "jfrog-cli.flock"
+	// The time to wait for the lock when acquired by CreateLock, which is about the time the lock files implementation waits.
+	DefaultFileLockTimeout = 2 * time.Minute
+	fileLockRetryInterval  = 50 * time.Millisecond
+)
+
+type LockMode int
+
+const (
+	// An exclusive lock is held by a single process, and excludes all the other locks.
+	Exclusive LockMode = iota
+	// A shared lock may be held by multiple processes at once, and excludes exclusive locks only.
+	Shared
+)
+
+func (mode LockMode) String() string {
+	return [...]string{"exclusive", "shared"}[mode]
+}
+
+// The process holding an exclusive file lock, written to the locked file for diagnostics.
+type LockHolder struct {
+	Pid      int    `json:"pid"`
+	Hostname string `json:"hostname,omitempty"`
+	// The time when the lock was acquired, in nanoseconds since the epoch.
+	Timestamp int64 `json:"timestamp"`
+}
+
+func (holder *LockHolder) String() string {
+	if holder.Pid == 0 {
+		return "another process"
+	}
+	return fmt.Sprintf("process %d on host '%s' since %s", holder.Pid, holder.Hostname, time.Unix(0, holder.Timestamp).Format(time.RFC3339))
+}
+
+// Returns true if the lock files should be replaced by advisory file locks, as set by the JFROG_CLI_LOCK_TYPE environment variable.
+func isFileLockEnabled() bool {
+	return strings.EqualFold(os.Getenv(coreutils.LockType), FlockLockType)
+}
+
+// Acquires an advisory file lock (flock on Unix and LockFileEx on Windows) in the lock directory, waiting until the lock is acquired,
+// the context is done or the timeout expires. A zero timeout means waiting until the context is done.
+// Unlike the lock files, the lock is released by the OS if the holding process exits, so it's never left stale.
+// If the lock isn't acquired, the returned unlock function does nothing, since callers may defer it before checking the error.
+func CreateFileLock(ctx context.Context, lockDirPath string, mode LockMode, timeout time.Duration) (unlock func() error, err error) {
+	log.Debug(fmt.Sprintf("Acquiring a %s file lock in: %s", mode, lockDirPath))
+	if err = fileutils.CreateDirIfNotExist(lockDirPath); err != nil {
+		return noopUnlock, err
+	}
+	lockFilePath := filepath.Join(lockDirPath, flockFileName)
+	// #nosec G302 -- the lock file holds no secrets, and may be shared by the users of the JFrog home directory.
+	file, err := os.OpenFile(lockFilePath, os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return noopUnlock, errorutils.CheckError(err)
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	for {
+		var acquired bool
+		if acquired, err = tryLockFile(file, mode); err != nil || acquired {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			err = getFileLockTimeoutError(ctx, file, lockFilePath)
+		case <-time.After(fileLockRetryInterval):
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		return noopUnlock, errors.Join(err, errorutils.CheckError(file.Close()))
+	}
+	if mode == Exclusive {
+		writeLockHolder(file)
+	}
+	log.Debug("File lock has been acquired for", lockFilePath)
+	return func() error {
+		log.Debug("Releasing file lock:", lockFilePath)
+		if mode == Exclusive {
+			// Clear the holder before unlocking, since the file may be locked by another process right after.
+			if err := file.Truncate(0); err != nil {
+				log.Debug("Couldn't clear the holder of the file lock: " + err.Error())
+			}
+		}
+		return errors.Join(unlockFile(file), errorutils.CheckError(file.Close()))
+	}, nil
+}
+
+func noopUnlock() error {
+	return nil
+}
+
+// Returns the holder of the exclusive file lock in the lock directory, or nil if the lock isn't held exclusively.
+// The holder has no process ID if the lock is held by a process which doesn't write its details, such as an older process.
+func GetFileLockHolder(lockDirPath string) (holder *LockHolder, err error) {
+	lockFilePath := filepath.Join(lockDirPath, flockFileName)
+	file, err := os.OpenFile(lockFilePath, os.O_RDONLY, 0)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	acquired, err := tryLockFile(file, Shared)
+	if err != nil {
+		return nil, err
+	}
+	if acquired {
+		return nil, unlockFile(file)
+	}
+	return readLockHolder(file), nil
+}
+
+func writeLockHolder(file *os.File) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Debug("Couldn't get the hostname for the file lock: " + err.Error())
+	}
+	content, err := json.Marshal(LockHolder{Pid: os.Getpid(), Hostname: hostname, Timestamp: time.Now().UnixNano()})
+	if err == nil {
+		if err = file.Truncate(0); err == nil {
+			_, err = file.WriteAt(content, 0)
+		}
+	}
+	if err != nil {
+		log.Debug("Couldn't write the holder of the file lock: " + err.Error())
+	}
+}
+
+// Reads the holder of the file lock. Returns an empty holder if the holder is unknown.
+func readLockHolder(file *os.File) *LockHolder {
+	holder := new(LockHolder)
+	content, err := io.ReadAll(io.NewSectionReader(file, 0, 1<<16))
+	if err != nil || len(content) == 0 {
+		return holder
+	}
+	if err = json.Unmarshal(content, holder); err != nil {
+		log.Debug("Couldn't parse the holder of the file lock: " + err.Error())
+		return new(LockHolder)
+	}
+	return holder
+}
+
+// Returns the error of a lock which wasn't acquired, with the details of the lock's holder.
+// If the holder is a process of the current host which is no longer running, the lock is probably held by a stale lock of a network file system,
+// or by a process in another PID namespace, such as another container.
+func getFileLockTimeoutError(ctx context.Context, file *os.File, lockFilePath string) error {
+	reason := "timed out"
+	if errors.Is(ctx.Err(), context.Canceled) {
+		reason = "canceled"
+	}
+	holder := readLockHolder(file)
+	message := fmt.Sprintf("acquiring the file lock %s was %s, since it's held by %s.", lockFilePath, reason, holder)
+	if hostname, err := os.Hostname(); err == nil && holder.Pid != 0 && holder.Hostname == hostname {
+		if running, err := osutils.IsProcessRunning(holder.Pid); err == nil && !running {
+			message += " The holding process is no longer running on this host, so the lock may be held by a process in another PID namespace, such as another container, " +
+				"or by a stale lock of a network file system."
+		}
+	}
+	return errorutils.CheckErrorf("%s", message)
+}
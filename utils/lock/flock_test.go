@@ -0,0 +1,111 @@
+package lock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	testsutils "github.com/jfrog/jfrog-client-go/utils/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLockExclusive(t *testing.T) {
+	lockDirPath := t.TempDir()
+	unlock, err := CreateFileLock(context.Background(), lockDirPath, Exclusive, time.Second)
+	require.NoError(t, err)
+
+	holder, err := GetFileLockHolder(lockDirPath)
+	require.NoError(t, err)
+	require.NotNil(t, holder)
+	assert.Equal(t, os.Getpid(), holder.Pid)
+	assert.NotZero(t, holder.Timestamp)
+
+	// Another exclusive or shared lock isn't acquired until the timeout, and the error describes the holder.
+	failedUnlock, err := CreateFileLock(context.Background(), lockDirPath, Exclusive, 100*time.Millisecond)
+	assert.ErrorContains(t, err, "timed out")
+	assert.ErrorContains(t, err, holder.String())
+	// The unlock function of a lock which wasn't acquired may be deferred before checking the error, so it does nothing.
+	require.NotNil(t, failedUnlock)
+	assert.NoError(t, failedUnlock())
+	_, err = CreateFileLock(context.Background(), lockDirPath, Shared, 100*time.Millisecond)
+	assert.ErrorContains(t, err, "timed out")
+
+	// The lock is acquired once released.
+	acquired := make(chan error, 1)
+	go func() {
+		secondUnlock, err := CreateFileLock(context.Background(), lockDirPath, Exclusive, 10*time.Second)
+		if err == nil {
+			err = secondUnlock()
+		}
+		acquired <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, unlock())
+	assert.NoError(t, <-acquired)
+
+	holder, err = GetFileLockHolder(lockDirPath)
+	assert.NoError(t, err)
+	assert.Nil(t, holder)
+}
+
+func TestFileLockShared(t *testing.T) {
+	lockDirPath := t.TempDir()
+	firstUnlock, err := CreateFileLock(context.Background(), lockDirPath, Shared, time.Second)
+	require.NoError(t, err)
+	secondUnlock, err := CreateFileLock(context.Background(), lockDirPath, Shared, time.Second)
+	require.NoError(t, err)
+
+	// Shared locks aren't reported as held exclusively, and exclude exclusive locks.
+	holder, err := GetFileLockHolder(lockDirPath)
+	assert.NoError(t, err)
+	assert.Nil(t, holder)
+	_, err = CreateFileLock(context.Background(), lockDirPath, Exclusive, 100*time.Millisecond)
+	assert.ErrorContains(t, err, "another process")
+
+	assert.NoError(t, firstUnlock())
+	assert.NoError(t, secondUnlock())
+	unlock, err := CreateFileLock(context.Background(), lockDirPath, Exclusive, time.Second)
+	require.NoError(t, err)
+	assert.NoError(t, unlock())
+}
+
+func TestFileLockCanceled(t *testing.T) {
+	lockDirPath := t.TempDir()
+	unlock, err := CreateFileLock(context.Background(), lockDirPath, Exclusive, 0)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, unlock())
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	_, err = CreateFileLock(ctx, lockDirPath, Exclusive, 0)
+	assert.ErrorContains(t, err, "canceled")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestCreateLockWithFileLock(t *testing.T) {
+	defer testsutils.SetEnvWithCallbackAndAssert(t, coreutils.LockType, FlockLockType)()
+	lockDirPath := t.TempDir()
+	unlock, err := CreateLock(lockDirPath)
+	require.NoError(t, err)
+
+	// No lock files are created, and the lock's timestamp is read from the file lock.
+	files, err := listLockFiles(lockDirPath)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+	assert.FileExists(t, filepath.Join(lockDirPath, flockFileName))
+	timestamp, err := GetLastLockTimestamp(lockDirPath)
+	assert.NoError(t, err)
+	assert.NotZero(t, timestamp)
+
+	require.NoError(t, unlock())
+	timestamp, err = GetLastLockTimestamp(lockDirPath)
+	assert.NoError(t, err)
+	assert.Zero(t, timestamp)
+}
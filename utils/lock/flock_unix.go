@@ -0,0 +1,38 @@
+//go:build linux || darwin || freebsd || openbsd
+// +build linux darwin freebsd openbsd
+
+package lock
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// This file will be compiled only on unix systems.
+// Tries to acquire the advisory lock of the file without blocking. Returns false if the lock is held by another open file.
+func tryLockFile(file *os.File, mode LockMode) (bool, error) {
+	how := syscall.LOCK_EX
+	if mode == Shared {
+		how = syscall.LOCK_SH
+	}
+	for {
+		err := syscall.Flock(int(file.Fd()), how|syscall.LOCK_NB)
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, syscall.EWOULDBLOCK):
+			return false, nil
+		case errors.Is(err, syscall.EINTR):
+			continue
+		default:
+			return false, errorutils.CheckError(err)
+		}
+	}
+}
+
+func unlockFile(file *os.File) error {
+	return errorutils.CheckError(syscall.Flock(int(file.Fd()), syscall.LOCK_UN))
+}
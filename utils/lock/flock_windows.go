@@ -0,0 +1,35 @@
+package lock
+
+import (
+	"errors"
+	"math"
+	"os"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/sys/windows"
+)
+
+// This file will be compiled on Windows.
+// The locked byte range is beyond the content of the file, so that the holder details remain readable while the file is locked.
+const lockedRangeOffset = math.MaxUint32
+
+// Tries to acquire the lock of the file without blocking. Returns false if the lock is held by another handle.
+func tryLockFile(file *os.File, mode LockMode) (bool, error) {
+	flags := uint32(windows.LOCKFILE_FAIL_IMMEDIATELY)
+	if mode == Exclusive {
+		flags |= windows.LOCKFILE_EXCLUSIVE_LOCK
+	}
+	err := windows.LockFileEx(windows.Handle(file.Fd()), flags, 0, 1, 0, &windows.Overlapped{Offset: lockedRangeOffset})
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, windows.ERROR_LOCK_VIOLATION):
+		return false, nil
+	default:
+		return false, errorutils.CheckError(err)
+	}
+}
+
+func unlockFile(file *os.File) error {
+	return errorutils.CheckError(windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, &windows.Overlapped{Offset: lockedRangeOffset}))
+}
@@ -1,6 +1,7 @@
 package lock
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -153,12 +154,22 @@ func (lock *Lock) removeOtherLockOrWait(otherLock Lock, filesList *[]string) err
 }
 
 func (lock *Lock) getListOfFiles() ([]string, error) {
-	// Listing all the files in the lock directory
-	filesList, err := fileutils.ListFiles(filepath.Dir(lock.fileName), false)
+	return listLockFiles(filepath.Dir(lock.fileName))
+}
+
+// Lists the lock files in the lock directory, excluding the file of the advisory file locks.
+func listLockFiles(lockDirPath string) ([]string, error) {
+	filesList, err := fileutils.ListFiles(lockDirPath, false)
 	if err != nil {
 		return nil, err
 	}
-	return filesList, nil
+	lockFiles := make([]string, 0, len(filesList))
+	for _, path := range filesList {
+		if filepath.Base(path) != flockFileName {
+			lockFiles = append(lockFiles, path)
+		}
+	}
+	return lockFiles, nil
 }
 
 // Returns a list of all available locks.
@@ -209,7 +220,12 @@ func (lock *Lock) Unlock() error {
 	return nil
 }
 
+// Acquires an exclusive lock of the lock directory, by creating a lock file named by the process ID,
+// or by an advisory file lock if JFROG_CLI_LOCK_TYPE is set to "flock". See CreateFileLock.
 func CreateLock(lockDirPath string) (unlock func() error, err error) {
+	if isFileLockEnabled() {
+		return CreateFileLock(context.Background(), lockDirPath, Exclusive, DefaultFileLockTimeout)
+	}
 	log.Debug("Creating lock in:", lockDirPath)
 	lockFile := new(Lock)
 	unlock = func() error { return lockFile.Unlock() }
@@ -227,7 +243,14 @@ func CreateLock(lockDirPath string) (unlock func() error, err error) {
 }
 
 func GetLastLockTimestamp(lockDirPath string) (int64, error) {
-	filesList, err := fileutils.ListFiles(lockDirPath, false)
+	if isFileLockEnabled() {
+		holder, err := GetFileLockHolder(lockDirPath)
+		if err != nil || holder == nil {
+			return 0, err
+		}
+		return holder.Timestamp, nil
+	}
+	filesList, err := listLockFiles(lockDirPath)
 	if err != nil {
 		return 0, err
 	}
@@ -11,9 +11,12 @@ func CreateXrayServiceManager(serviceDetails *config.ServerDetails) (*xray.XrayS
 	if err != nil {
 		return nil, err
 	}
-	serviceConfig, err := clientconfig.NewConfigBuilder().
-		SetServiceDetails(xrayDetails).
-		Build()
+	configBuilder := clientconfig.NewConfigBuilder().
+		SetServiceDetails(xrayDetails)
+	if _, err = config.ApplyHttpSettings(configBuilder, serviceDetails); err != nil {
+		return nil, err
+	}
+	serviceConfig, err := configBuilder.Build()
 	if err != nil {
 		return nil, err
 	}